@@ -27,6 +27,9 @@ type Server struct {
 	services *services.Container
 	logger   *log.Logger
 	server   *http.Server
+
+	// Cancels background jobs on shutdown
+	stopJobs context.CancelFunc
 }
 
 // New creates a new server with all dependencies
@@ -40,7 +43,7 @@ func New(cfg *config.Config, db *database.Connections, logger *log.Logger) *Serv
 	serviceContainer := services.NewContainer(db, cfg, logger)
 
 	// Create router with middleware
-	router := setupRouter(cfg, serviceContainer, logger)
+	router := setupRouter(cfg, db, serviceContainer, logger)
 
 	// Create HTTP server
 	srv := &http.Server{
@@ -51,17 +54,30 @@ func New(cfg *config.Config, db *database.Connections, logger *log.Logger) *Serv
 		IdleTimeout:  cfg.Server.IdleTimeout,
 	}
 
+	// Start background jobs
+	jobs, stopJobs := context.WithCancel(context.Background())
+	startBackgroundJobs(jobs, serviceContainer)
+
 	return &Server{
 		config:   cfg,
 		router:   router,
 		services: serviceContainer,
 		logger:   logger,
 		server:   srv,
+		stopJobs: stopJobs,
 	}
 }
 
+// startBackgroundJobs runs the workers every instance hosts until ctx is cancelled
+func startBackgroundJobs(ctx context.Context, services *services.Container) {
+	go services.Dispatcher.Run(ctx)
+	go services.Reminders.Run(ctx)
+	go services.Webhook.Run(ctx)
+	go services.Payment.Run(ctx)
+}
+
 // setupRouter configures all routes and middleware
-func setupRouter(cfg *config.Config, services *services.Container, logger *log.Logger) *gin.Engine {
+func setupRouter(cfg *config.Config, db *database.Connections, services *services.Container, logger *log.Logger) *gin.Engine {
 	router := gin.New()
 
 	// Global middleware
@@ -74,7 +90,7 @@ func setupRouter(cfg *config.Config, services *services.Container, logger *log.L
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{cfg.External.FrontendURL},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "X-Request-ID"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "X-Request-ID", "Idempotency-Key"},
 		ExposeHeaders:    []string{"Content-Length", "X-Request-ID"},
 		AllowCredentials: true,
 		MaxAge:           12 * 3600, // 12 hours
@@ -102,9 +118,14 @@ func setupRouter(cfg *config.Config, services *services.Container, logger *log.L
 
 	// WebSocket endpoint (if enabled)
 	if cfg.Features.EnableWebSocket {
-		hub := websocket.NewHub(services, logger)
+		// Hubs on every instance share broadcasts through Redis pub/sub
+		hub := websocket.NewHub(services, db.Redis, logger)
 		go hub.Run()
-		router.GET("/ws", middleware.OptionalAuth(services.Auth), websocket.HandleConnection(hub))
+		// Live connections authenticate themselves so tokens can arrive by query or subprotocol
+		router.GET("/ws", websocket.HandleConnection(hub, cfg.External.WebSocketOrigins))
+
+		// The same updates as Server-Sent Events for kiosks and scoreboards behind proxies
+		v1.GET("/tournaments/:id/events", websocket.HandleEventStream(hub))
 	}
 
 	// Static file serving
@@ -121,5 +142,6 @@ func (s *Server) Start() error {
 // Shutdown gracefully shuts down the server
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.Println("Shutting down server...")
+	s.stopJobs()
 	return s.server.Shutdown(ctx)
 }
@@ -5,7 +5,9 @@ package api
 
 import (
 	"net/http"
+	"strconv"
 
+	"tournament-planner/internal/models"
 	"tournament-planner/internal/services"
 
 	"github.com/gin-gonic/gin"
@@ -70,3 +72,55 @@ func HandleForceDeleteTournament(tournamentService *services.TournamentService)
 		c.JSON(http.StatusNotImplemented, gin.H{"error": "Force delete not implemented yet"})
 	}
 }
+
+// HandleListOutbox lists notification outbox entries by status, dead-lettered ones by default
+func HandleListOutbox(notificationService *services.NotificationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		status := models.OutboxStatus(c.DefaultQuery("status", string(models.OutboxDead)))
+		switch status {
+		case models.OutboxPending, models.OutboxDelivered, models.OutboxDead:
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+			return
+		}
+
+		page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+		if page < 1 {
+			page = 1
+		}
+		if limit < 1 || limit > 200 {
+			limit = 50
+		}
+
+		entries, err := notificationService.ListOutbox(c.Request.Context(), status, limit, (page-1)*limit)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve outbox"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"entries": entries,
+			"page":    page,
+			"limit":   limit,
+		})
+	}
+}
+
+// HandleReplayOutbox makes a dead-lettered notification due for delivery again
+func HandleReplayOutbox(notificationService *services.NotificationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		entryID := c.Param("id")
+
+		if err := notificationService.ReplayOutbox(c.Request.Context(), entryID); err != nil {
+			if err == services.ErrNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "No dead-lettered notification with this ID"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to replay notification"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Notification queued for redelivery"})
+	}
+}
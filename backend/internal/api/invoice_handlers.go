@@ -0,0 +1,123 @@
+// internal/api/invoice_handlers.go
+// Invoice, credit note and billing profile HTTP handlers
+
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"tournament-planner/internal/render"
+	"tournament-planner/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleGetBillingProfile returns the details the signed-in organizer prints on invoices
+func HandleGetBillingProfile(paymentService *services.PaymentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		profile, err := paymentService.GetBillingProfile(c.Request.Context(), c.GetString("user_id"))
+		if err != nil {
+			if errors.Is(err, services.ErrNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "No billing profile set"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve billing profile"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"billing_profile": profile})
+	}
+}
+
+// HandleUpdateBillingProfile sets the details the signed-in organizer prints on invoices
+func HandleUpdateBillingProfile(paymentService *services.PaymentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req services.BillingProfileRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+
+		profile, err := paymentService.UpdateBillingProfile(c.Request.Context(), c.GetString("user_id"), req)
+		if err != nil {
+			respondPaymentError(c, err, "Failed to update billing profile")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"billing_profile": profile})
+	}
+}
+
+// HandleListInvoices lists the invoices and credit notes issued for a tournament
+func HandleListInvoices(paymentService *services.PaymentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		invoices, err := paymentService.ListInvoices(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve invoices"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"invoices": invoices})
+	}
+}
+
+// HandleListMyInvoices lists the invoices and credit notes issued to the signed-in user
+func HandleListMyInvoices(paymentService *services.PaymentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		invoices, err := paymentService.ListUserInvoices(c.Request.Context(), c.GetString("user_id"))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve invoices"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"invoices": invoices})
+	}
+}
+
+// HandleGetInvoice returns an invoice or credit note to its payer or organizer
+func HandleGetInvoice(paymentService *services.PaymentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		invoice, err := paymentService.GetInvoice(c.Request.Context(), c.Param("id"), c.GetString("user_id"))
+		if err != nil {
+			respondInvoiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"invoice": invoice})
+	}
+}
+
+// HandleGetInvoicePDF downloads an invoice or credit note as a PDF
+func HandleGetInvoicePDF(paymentService *services.PaymentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		invoice, err := paymentService.GetInvoice(c.Request.Context(), c.Param("id"), c.GetString("user_id"))
+		if err != nil {
+			respondInvoiceError(c, err)
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := render.InvoicePDF(&buf, invoice); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render invoice"})
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.pdf"`, invoice.Number))
+		c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+	}
+}
+
+// respondInvoiceError maps invoice lookup errors to responses
+func respondInvoiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Invoice not found"})
+	case errors.Is(err, services.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve invoice"})
+	}
+}
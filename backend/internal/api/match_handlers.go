@@ -4,6 +4,7 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
@@ -106,6 +107,146 @@ func HandleReportScore(matchService *services.MatchService) gin.HandlerFunc {
 	}
 }
 
+// HandleCorrectResult lets the organizer correct a reported result
+func HandleCorrectResult(matchService *services.MatchService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		matchID := c.Param("id")
+		userID := c.GetString("user_id")
+
+		var req struct {
+			Score1       int                  `json:"score1" binding:"min=0"`
+			Score2       int                  `json:"score2" binding:"min=0"`
+			ScoreDetails *models.ScoreDetails `json:"score_details"`
+			Reason       string               `json:"reason" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+
+		correction, err := matchService.CorrectResult(c.Request.Context(), matchID, userID, req.Score1, req.Score2, req.ScoreDetails, req.Reason)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidInput) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to correct result", "details": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":    "Result corrected successfully",
+			"correction": correction,
+		})
+	}
+}
+
+// HandleGetCorrections retrieves the correction history of a match
+func HandleGetCorrections(matchService *services.MatchService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		matchID := c.Param("id")
+
+		corrections, err := matchService.GetCorrections(c.Request.Context(), matchID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve corrections"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"corrections": corrections})
+	}
+}
+
+// HandleRecordScoreEvent appends a live scoring event to a match
+func HandleRecordScoreEvent(liveScoring *services.LiveScoringService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		matchID := c.Param("id")
+		userID := c.GetString("user_id")
+
+		var req struct {
+			Type   models.ScoreEventType `json:"type" binding:"required"`
+			Side   int                   `json:"side" binding:"required"`
+			Value  int                   `json:"value"`
+			Detail string                `json:"detail"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+
+		event, score, err := liveScoring.RecordEvent(c.Request.Context(), matchID, userID, services.ScoreEventInput{
+			Type:   req.Type,
+			Side:   req.Side,
+			Value:  req.Value,
+			Detail: req.Detail,
+		})
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidInput) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record score event", "details": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"event": event,
+			"score": score,
+		})
+	}
+}
+
+// HandleGetScoreEvents retrieves a match's live scoring log
+func HandleGetScoreEvents(liveScoring *services.LiveScoringService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		matchID := c.Param("id")
+
+		events, err := liveScoring.GetEvents(c.Request.Context(), matchID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve score events"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"events": events})
+	}
+}
+
+// HandleGetLiveScore retrieves the running score derived from the scoring log
+func HandleGetLiveScore(liveScoring *services.LiveScoringService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		matchID := c.Param("id")
+
+		score, err := liveScoring.GetLiveScore(c.Request.Context(), matchID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve live score"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"score": score})
+	}
+}
+
+// HandleCompleteLiveScoring reports the score replayed from the log as the final result
+func HandleCompleteLiveScoring(liveScoring *services.LiveScoringService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		matchID := c.Param("id")
+
+		score, err := liveScoring.Complete(c.Request.Context(), matchID)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidInput) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to complete match", "details": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Match completed from live score",
+			"score":   score,
+		})
+	}
+}
+
 // HandleCancelMatch cancels a match
 func HandleCancelMatch(matchService *services.MatchService) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -4,63 +4,212 @@
 package api
 
 import (
+	"errors"
+	"io"
 	"net/http"
 
-	"tournament-planner/internal/config"
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/payments"
 	"tournament-planner/internal/services"
 
 	"github.com/gin-gonic/gin"
 )
 
-// HandleProcessPayment processes a payment
-func HandleProcessPayment(paymentService *services.PaymentService) gin.HandlerFunc {
+// maxPaymentWebhookBody caps the size of provider webhook requests
+const maxPaymentWebhookBody = 1 << 20
+
+// HandleCreatePayment starts an entry fee payment and returns what the client
+// needs to complete it with the payment provider
+func HandleCreatePayment(paymentService *services.PaymentService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req struct {
-			TournamentID  string  `json:"tournament_id" binding:"required"`
-			ParticipantID string  `json:"participant_id" binding:"required"`
-			Amount        float64 `json:"amount" binding:"required,min=0"`
-			PaymentMethod string  `json:"payment_method" binding:"required"`
+			TournamentID  string `json:"tournament_id" binding:"required"`
+			ParticipantID string `json:"participant_id" binding:"required"`
 		}
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
 			return
 		}
 
-		if err := paymentService.ProcessPayment(c.Request.Context(), req.TournamentID, req.ParticipantID, req.Amount); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process payment"})
+		checkout, err := paymentService.CreatePayment(c.Request.Context(), req.TournamentID, req.ParticipantID, c.GetString("user_id"))
+		if err != nil {
+			respondPaymentError(c, err, "Failed to start payment")
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"payment": checkout})
+	}
+}
+
+// HandleCapturePayment captures an authorized payment
+func HandleCapturePayment(paymentService *services.PaymentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := paymentService.CapturePayment(c.Request.Context(), c.Param("id"), c.GetString("user_id")); err != nil {
+			respondPaymentError(c, err, "Failed to capture payment")
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"message": "Payment processed successfully"})
+		c.JSON(http.StatusAccepted, gin.H{"message": "Capture requested"})
 	}
 }
 
-// HandleRefundPayment processes a refund
+// HandleRefundPayment requests a refund from the payment provider. Clients
+// should send an Idempotency-Key header so a retried request refunds once.
 func HandleRefundPayment(paymentService *services.PaymentService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req struct {
-			TournamentID  string `json:"tournament_id" binding:"required"`
-			ParticipantID string `json:"participant_id" binding:"required"`
+			TournamentID  string       `json:"tournament_id" binding:"required"`
+			ParticipantID string       `json:"participant_id" binding:"required"`
+			Amount        models.Money `json:"amount"` // zero refunds what is left
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+
+		key := c.GetHeader("Idempotency-Key")
+		if len(key) > 100 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key must be at most 100 characters"})
+			return
+		}
+
+		err := paymentService.RefundPayment(c.Request.Context(), req.TournamentID, req.ParticipantID, c.GetString("user_id"), req.Amount, key)
+		if err != nil {
+			respondPaymentError(c, err, "Failed to process refund")
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{"message": "Refund requested"})
+	}
+}
+
+// HandleGetLedger returns a participant's payment ledger and balance
+func HandleGetLedger(paymentService *services.PaymentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ledger, err := paymentService.Ledger(c.Request.Context(), c.Param("id"), c.Param("participantId"))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve ledger"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"ledger": ledger})
+	}
+}
+
+// HandleRecordLedgerEntry records a fee or adjustment in a participant's ledger
+func HandleRecordLedgerEntry(paymentService *services.PaymentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req services.LedgerEntryRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
 		}
+
+		entry, err := paymentService.RecordLedgerEntry(c.Request.Context(), c.Param("id"), c.Param("participantId"), c.GetString("user_id"), req)
+		if err != nil {
+			respondPaymentError(c, err, "Failed to record ledger entry")
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"entry": entry})
+	}
+}
+
+// HandleGetReconciliation compares a tournament's payment ledger with its participants' payment statuses
+func HandleGetReconciliation(paymentService *services.PaymentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report, err := paymentService.Reconcile(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reconcile payments"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"report": report})
+	}
+}
+
+// HandleRecordOnsitePayment records an entry fee collected at the venue by the signed-in staff member
+func HandleRecordOnsitePayment(paymentService *services.PaymentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req services.OnsitePaymentRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
 			return
 		}
 
-		if err := paymentService.RefundPayment(c.Request.Context(), req.TournamentID, req.ParticipantID); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process refund"})
+		payment, err := paymentService.RecordOnsitePayment(c.Request.Context(), c.Param("id"), c.Param("participantId"), c.GetString("user_id"), req)
+		if err != nil {
+			respondPaymentError(c, err, "Failed to record payment")
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"payment": payment})
+	}
+}
+
+// HandleGetCashSummary totals a day's onsite collections per staff member
+func HandleGetCashSummary(paymentService *services.PaymentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		summary, err := paymentService.CashSummary(c.Request.Context(), c.Param("id"), c.Query("date"))
+		if err != nil {
+			respondPaymentError(c, err, "Failed to summarize onsite payments")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"summary": summary})
+	}
+}
+
+// HandleGetRefundProgress reports the progress of a tournament's bulk refund
+func HandleGetRefundProgress(paymentService *services.PaymentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		status, err := paymentService.RefundProgress(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			if errors.Is(err, services.ErrNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "No refunds queued for this tournament"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve refund progress"})
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"message": "Refund processed successfully"})
+		c.JSON(http.StatusOK, gin.H{"refunds": status})
 	}
 }
 
-// HandleStripeWebhook handles Stripe webhook events
-func HandleStripeWebhook(paymentService *services.PaymentService, cfg *config.Config) gin.HandlerFunc {
+// HandlePaymentWebhook receives payment provider events. Requests without a
+// valid signature are rejected; other failures answer 500 so the provider retries.
+func HandlePaymentWebhook(paymentService *services.PaymentService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// TODO: Implement Stripe webhook handling
-		// This would verify the webhook signature and process events
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Stripe webhook not implemented yet"})
+		payload, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxPaymentWebhookBody))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+
+		if err := paymentService.HandleWebhook(c.Request.Context(), payload, c.Request.Header); err != nil {
+			if errors.Is(err, payments.ErrInvalidWebhook) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook signature"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process webhook"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"received": true})
+	}
+}
+
+// respondPaymentError maps payment service errors to responses
+func respondPaymentError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Payment or registration not found"})
+	case errors.Is(err, services.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+	case errors.Is(err, services.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
 	}
 }
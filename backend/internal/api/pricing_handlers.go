@@ -0,0 +1,180 @@
+// internal/api/pricing_handlers.go
+// Entry fee pricing, discount code and refund policy HTTP handlers
+
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleGetPriceQuote returns what registering now would cost. Signed-in
+// callers the organizer lists as members are quoted member prices.
+func HandleGetPriceQuote(tournamentService *services.TournamentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		quote, err := tournamentService.QuotePrice(c.Request.Context(), c.Param("id"), c.GetString("user_id"), c.Query("discount_code"))
+		if err != nil {
+			respondPricingError(c, err, "Failed to calculate price")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"price": quote})
+	}
+}
+
+// HandleUpdatePricing changes a tournament's entry fee and pricing tiers
+func HandleUpdatePricing(tournamentService *services.TournamentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req services.PricingRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+
+		tournament, err := tournamentService.UpdatePricing(c.Request.Context(), c.Param("id"), req)
+		if err != nil {
+			respondPricingError(c, err, "Failed to update pricing")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"currency":  tournament.Currency,
+			"entry_fee": tournament.EntryFee,
+			"pricing":   tournament.Pricing,
+		})
+	}
+}
+
+// HandleUpdateRefundPolicy sets the refund policy for withdrawals. A null policy
+// refunds withdrawals in full.
+func HandleUpdateRefundPolicy(tournamentService *services.TournamentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			RefundPolicy *models.RefundPolicy `json:"refund_policy"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+
+		tournament, err := tournamentService.UpdateRefundPolicy(c.Request.Context(), c.Param("id"), req.RefundPolicy)
+		if err != nil {
+			respondPricingError(c, err, "Failed to update refund policy")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"refund_policy": tournament.RefundPolicy})
+	}
+}
+
+// HandleListMembers lists the accounts that pay member prices for a tournament
+func HandleListMembers(tournamentService *services.TournamentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		members, err := tournamentService.ListMembers(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve members"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"members": members})
+	}
+}
+
+// HandleAddMember lists an account as a member of a tournament
+func HandleAddMember(tournamentService *services.TournamentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req services.MemberRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+
+		member, err := tournamentService.AddMember(c.Request.Context(), c.Param("id"), c.GetString("user_id"), req)
+		if err != nil {
+			respondPricingError(c, err, "Failed to add member")
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"member": member})
+	}
+}
+
+// HandleRemoveMember takes an account off a tournament's member list
+func HandleRemoveMember(tournamentService *services.TournamentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := tournamentService.RemoveMember(c.Request.Context(), c.Param("id"), c.Param("userId")); err != nil {
+			respondPricingError(c, err, "Failed to remove member")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Member removed"})
+	}
+}
+
+// HandleListDiscountCodes lists a tournament's discount codes
+func HandleListDiscountCodes(tournamentService *services.TournamentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		codes, err := tournamentService.ListDiscountCodes(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve discount codes"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"discount_codes": codes})
+	}
+}
+
+// HandleCreateDiscountCode adds a discount code to a tournament
+func HandleCreateDiscountCode(tournamentService *services.TournamentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req services.DiscountCodeRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+
+		code, err := tournamentService.CreateDiscountCode(c.Request.Context(), c.Param("id"), c.GetString("user_id"), req)
+		if err != nil {
+			respondPricingError(c, err, "Failed to create discount code")
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"discount_code": code})
+	}
+}
+
+// HandleUpdateDiscountCode changes a discount code's usage limit, expiry or active flag
+func HandleUpdateDiscountCode(tournamentService *services.TournamentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req services.DiscountCodeUpdate
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+
+		code, err := tournamentService.UpdateDiscountCode(c.Request.Context(), c.Param("id"), c.Param("codeId"), req)
+		if err != nil {
+			respondPricingError(c, err, "Failed to update discount code")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"discount_code": code})
+	}
+}
+
+// respondPricingError maps pricing errors to responses
+func respondPricingError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, services.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,80 @@
+// internal/api/revenue_handlers.go
+// Organizer revenue report HTTP handlers
+
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/render"
+	"tournament-planner/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleGetTournamentRevenue reports what a tournament's entry fees have earned
+func HandleGetTournamentRevenue(paymentService *services.PaymentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report, err := paymentService.TournamentRevenue(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build revenue report"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"report": report})
+	}
+}
+
+// HandleExportTournamentRevenue downloads a tournament's revenue report as CSV
+func HandleExportTournamentRevenue(paymentService *services.PaymentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report, err := paymentService.TournamentRevenue(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build revenue report"})
+			return
+		}
+
+		respondRevenueCSV(c, report, fmt.Sprintf("revenue-%s.csv", report.TournamentID))
+	}
+}
+
+// HandleGetMyRevenue reports what the signed-in organizer's tournaments have earned
+func HandleGetMyRevenue(paymentService *services.PaymentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report, err := paymentService.OrganizerRevenue(c.Request.Context(), c.GetString("user_id"))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build revenue report"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"report": report})
+	}
+}
+
+// HandleExportMyRevenue downloads the signed-in organizer's revenue report as CSV
+func HandleExportMyRevenue(paymentService *services.PaymentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report, err := paymentService.OrganizerRevenue(c.Request.Context(), c.GetString("user_id"))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build revenue report"})
+			return
+		}
+
+		respondRevenueCSV(c, report, fmt.Sprintf("revenue-%s.csv", report.GeneratedAt.Format("2006-01-02")))
+	}
+}
+
+// respondRevenueCSV sends a revenue report as a CSV download
+func respondRevenueCSV(c *gin.Context, report *models.RevenueReport, filename string) {
+	var buf bytes.Buffer
+	if err := render.RevenueCSV(&buf, report); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render revenue report"})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
@@ -47,9 +47,16 @@ func RegisterTournamentRoutes(router *gin.RouterGroup, services *services.Contai
 		// Public routes
 		tournaments.GET("", HandleListTournaments(services.Tournament))
 		tournaments.GET("/:id", HandleGetTournament(services.Tournament))
-		tournaments.GET("/:id/bracket", HandleGetBracket(services.Tournament, services.Match))
-		tournaments.GET("/:id/schedule", HandleGetSchedule(services.Tournament, services.Match))
+		tournaments.GET("/:id/bracket", HandleGetBracket(services.Bracket))
+		tournaments.GET("/:id/bracket.svg", HandleGetBracketSVG(services.Bracket))
+		tournaments.GET("/:id/bracket.pdf", HandleGetBracketPDF(services.Bracket))
+		tournaments.GET("/:id/schedule", HandleGetSchedule(services.Schedule))
+		tournaments.GET("/:id/schedule.ics", HandleGetScheduleCalendar(services.Schedule))
+		tournaments.GET("/:id/venues/:venueId/schedule.ics", HandleGetScheduleCalendar(services.Schedule))
+		tournaments.GET("/:id/participants/:participantId/schedule.ics", HandleGetScheduleCalendar(services.Schedule))
+		tournaments.GET("/:id/standings", HandleGetStandings(services.Standings))
 		tournaments.GET("/:id/participants", HandleGetParticipants(services.Tournament))
+		tournaments.GET("/:id/price", middleware.OptionalAuth(services.Auth), HandleGetPriceQuote(services.Tournament))
 		tournaments.POST("/:id/register", middleware.OptionalAuth(services.Auth), HandleRegisterParticipant(services.Tournament))
 		tournaments.POST("/:id/waitlist", middleware.OptionalAuth(services.Auth), HandleJoinWaitlist(services.Tournament))
 
@@ -61,11 +68,24 @@ func RegisterTournamentRoutes(router *gin.RouterGroup, services *services.Contai
 		tournaments.POST("/:id/publish", middleware.RequireTournamentOwner(services), HandlePublishTournament(services.Tournament))
 		tournaments.POST("/:id/start", middleware.RequireTournamentOwner(services), HandleStartTournament(services.Tournament))
 		tournaments.POST("/:id/complete", middleware.RequireTournamentOwner(services), HandleCompleteTournament(services.Tournament))
+		tournaments.POST("/:id/cancel", middleware.RequireTournamentOwner(services), HandleCancelTournament(services.Tournament))
+		tournaments.GET("/:id/refunds", middleware.RequireTournamentOwner(services), HandleGetRefundProgress(services.Payment))
+		tournaments.POST("/:id/withdraw", HandleWithdraw(services.Tournament))
 
 		// Fixture generation
 		tournaments.POST("/:id/fixtures/generate", middleware.RequireTournamentOwner(services), HandleGenerateFixtures(services.Tournament))
 		tournaments.POST("/:id/schedule/auto", middleware.RequireTournamentOwner(services), HandleAutoSchedule(services.Tournament))
 
+		// Pricing, members and discount codes
+		tournaments.PUT("/:id/pricing", middleware.RequireTournamentOwner(services), HandleUpdatePricing(services.Tournament))
+		tournaments.PUT("/:id/refund-policy", middleware.RequireTournamentOwner(services), HandleUpdateRefundPolicy(services.Tournament))
+		tournaments.GET("/:id/members", middleware.RequireTournamentOwner(services), HandleListMembers(services.Tournament))
+		tournaments.POST("/:id/members", middleware.RequireTournamentOwner(services), HandleAddMember(services.Tournament))
+		tournaments.DELETE("/:id/members/:userId", middleware.RequireTournamentOwner(services), HandleRemoveMember(services.Tournament))
+		tournaments.GET("/:id/discount-codes", middleware.RequireTournamentOwner(services), HandleListDiscountCodes(services.Tournament))
+		tournaments.POST("/:id/discount-codes", middleware.RequireTournamentOwner(services), HandleCreateDiscountCode(services.Tournament))
+		tournaments.PUT("/:id/discount-codes/:codeId", middleware.RequireTournamentOwner(services), HandleUpdateDiscountCode(services.Tournament))
+
 		// Venue management
 		tournaments.GET("/:id/venues", HandleGetVenues(services.Tournament))
 		tournaments.POST("/:id/venues", middleware.RequireTournamentOwner(services), HandleAddVenue(services.Tournament))
@@ -76,6 +96,17 @@ func RegisterTournamentRoutes(router *gin.RouterGroup, services *services.Contai
 		tournaments.PUT("/:id/participants/:participantId", middleware.RequireTournamentOwner(services), HandleUpdateParticipant(services.Tournament))
 		tournaments.DELETE("/:id/participants/:participantId", middleware.RequireTournamentOwner(services), HandleRemoveParticipant(services.Tournament))
 		tournaments.POST("/:id/participants/:participantId/checkin", middleware.RequireTournamentOwner(services), HandleCheckInParticipant(services.Tournament))
+		tournaments.POST("/:id/participants/:participantId/onsite-payment", middleware.RequireTournamentStaff(services), HandleRecordOnsitePayment(services.Payment))
+		tournaments.GET("/:id/onsite-payments/summary", middleware.RequireTournamentOwner(services), HandleGetCashSummary(services.Payment))
+		tournaments.GET("/:id/presence", middleware.RequireTournamentOwner(services), HandleGetPresence(services.Presence))
+
+		// Organizer webhooks
+		tournaments.GET("/:id/webhooks", middleware.RequireTournamentOwner(services), HandleListWebhooks(services.Webhook))
+		tournaments.POST("/:id/webhooks", middleware.RequireTournamentOwner(services), HandleCreateWebhook(services.Webhook))
+		tournaments.PUT("/:id/webhooks/:webhookId", middleware.RequireTournamentOwner(services), HandleUpdateWebhook(services.Webhook))
+		tournaments.DELETE("/:id/webhooks/:webhookId", middleware.RequireTournamentOwner(services), HandleDeleteWebhook(services.Webhook))
+		tournaments.GET("/:id/webhooks/:webhookId/deliveries", middleware.RequireTournamentOwner(services), HandleListWebhookDeliveries(services.Webhook))
+		tournaments.POST("/:id/webhooks/:webhookId/test", middleware.RequireTournamentOwner(services), HandleTestWebhook(services.Webhook))
 	}
 }
 
@@ -88,6 +119,12 @@ func RegisterMatchRoutes(router *gin.RouterGroup, services *services.Container)
 		matches.PUT("/:id", middleware.RequireMatchAccess(services), HandleUpdateMatch(services.Match))
 		matches.POST("/:id/start", middleware.RequireMatchAccess(services), HandleStartMatch(services.Match))
 		matches.POST("/:id/score", middleware.RequireMatchAccess(services), HandleReportScore(services.Match))
+		matches.POST("/:id/correct", middleware.RequireMatchOrganizer(services), HandleCorrectResult(services.Match))
+		matches.GET("/:id/corrections", middleware.RequireMatchAccess(services), HandleGetCorrections(services.Match))
+		matches.GET("/:id/events", HandleGetScoreEvents(services.LiveScoring))
+		matches.POST("/:id/events", middleware.RequireMatchAccess(services), HandleRecordScoreEvent(services.LiveScoring))
+		matches.GET("/:id/live", HandleGetLiveScore(services.LiveScoring))
+		matches.POST("/:id/live/complete", middleware.RequireMatchAccess(services), HandleCompleteLiveScoring(services.LiveScoring))
 		matches.POST("/:id/cancel", middleware.RequireMatchAccess(services), HandleCancelMatch(services.Match))
 	}
 }
@@ -98,12 +135,48 @@ func RegisterPaymentRoutes(router *gin.RouterGroup, services *services.Container
 		return
 	}
 
+	// The provider authenticates webhooks with their signature, not a user token.
+	// Without a webhook secret nothing could be verified, so the route is left out.
+	if cfg.External.StripeWebhookSecret != "" {
+		router.POST("/payments/webhook", HandlePaymentWebhook(services.Payment))
+	}
+
 	payments := router.Group("/payments")
 	payments.Use(middleware.RequireAuth(services.Auth))
 	{
-		payments.POST("/process", HandleProcessPayment(services.Payment))
+		payments.POST("", HandleCreatePayment(services.Payment))
+		payments.POST("/:id/capture", HandleCapturePayment(services.Payment))
 		payments.POST("/refund", HandleRefundPayment(services.Payment))
-		payments.POST("/webhook", HandleStripeWebhook(services.Payment, cfg))
+	}
+
+	// Invoices are open to their payer and the organizer who issued them
+	invoices := router.Group("/invoices")
+	invoices.Use(middleware.RequireAuth(services.Auth))
+	{
+		invoices.GET("/:id", HandleGetInvoice(services.Payment))
+		invoices.GET("/:id/pdf", HandleGetInvoicePDF(services.Payment))
+	}
+
+	billing := router.Group("/users/me")
+	billing.Use(middleware.RequireAuth(services.Auth))
+	{
+		billing.GET("/invoices", HandleListMyInvoices(services.Payment))
+		billing.GET("/billing-profile", HandleGetBillingProfile(services.Payment))
+		billing.PUT("/billing-profile", HandleUpdateBillingProfile(services.Payment))
+		billing.GET("/revenue", HandleGetMyRevenue(services.Payment))
+		billing.GET("/revenue.csv", HandleExportMyRevenue(services.Payment))
+	}
+
+	// Organizer views of a tournament's payments
+	ledger := router.Group("/tournaments/:id")
+	ledger.Use(middleware.RequireAuth(services.Auth), middleware.RequireTournamentOwner(services))
+	{
+		ledger.GET("/payments/reconciliation", HandleGetReconciliation(services.Payment))
+		ledger.GET("/payments/revenue", HandleGetTournamentRevenue(services.Payment))
+		ledger.GET("/payments/revenue.csv", HandleExportTournamentRevenue(services.Payment))
+		ledger.GET("/invoices", HandleListInvoices(services.Payment))
+		ledger.GET("/participants/:participantId/ledger", HandleGetLedger(services.Payment))
+		ledger.POST("/participants/:participantId/ledger", HandleRecordLedgerEntry(services.Payment))
 	}
 }
 
@@ -118,5 +191,9 @@ func RegisterAdminRoutes(router *gin.RouterGroup, services *services.Container)
 		admin.PUT("/users/:id/role", HandleUpdateUserRole(services.User))
 		admin.GET("/tournaments", HandleListAllTournaments(services.Tournament))
 		admin.DELETE("/tournaments/:id", HandleForceDeleteTournament(services.Tournament))
+
+		// Notification outbox
+		admin.GET("/notifications/outbox", HandleListOutbox(services.Notification))
+		admin.POST("/notifications/outbox/:id/replay", HandleReplayOutbox(services.Notification))
 	}
 }
@@ -4,9 +4,13 @@
 package api
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
+	"tournament-planner/internal/render"
 	"tournament-planner/internal/repositories"
 	"tournament-planner/internal/services"
 
@@ -187,59 +191,160 @@ func HandleAutoSchedule(tournamentService *services.TournamentService) gin.Handl
 	}
 }
 
-// HandleGetBracket retrieves tournament bracket
-func HandleGetBracket(tournamentService *services.TournamentService, matchService *services.MatchService) gin.HandlerFunc {
+// HandleGetBracket retrieves the structured tournament bracket
+func HandleGetBracket(bracketService *services.BracketService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tournamentID := c.Param("id")
 
-		// Get tournament
-		tournament, err := tournamentService.GetByID(c.Request.Context(), tournamentID)
+		bracket, err := bracketService.GetBracket(c.Request.Context(), tournamentID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"bracket": bracket,
+		})
+	}
+}
+
+// HandleGetBracketSVG renders the tournament bracket as an SVG image
+func HandleGetBracketSVG(bracketService *services.BracketService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tournamentID := c.Param("id")
+
+		bracket, err := bracketService.GetBracket(c.Request.Context(), tournamentID)
 		if err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
 			return
 		}
 
-		// Get all matches
-		matches, err := matchService.GetByTournamentID(c.Request.Context(), tournamentID)
+		var buf bytes.Buffer
+		if err := render.BracketSVG(&buf, bracket); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render bracket"})
+			return
+		}
+
+		c.Data(http.StatusOK, "image/svg+xml; charset=utf-8", buf.Bytes())
+	}
+}
+
+// HandleGetBracketPDF renders the tournament bracket as a printable PDF
+func HandleGetBracketPDF(bracketService *services.BracketService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tournamentID := c.Param("id")
+
+		bracket, err := bracketService.GetBracket(c.Request.Context(), tournamentID)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve matches"})
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := render.BracketPDF(&buf, bracket); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render bracket"})
 			return
 		}
 
-		// TODO: Structure matches into proper bracket format based on tournament type
+		c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="bracket-%s.pdf"`, tournamentID))
+		c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+	}
+}
+
+// HandleGetStandings retrieves league tables for round robin and group stages
+func HandleGetStandings(standingsService *services.StandingsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tournamentID := c.Param("id")
+
+		filter := services.StandingsFilter{
+			Group:    c.Query("group"),
+			Division: c.Query("division"),
+		}
+
+		tables, err := standingsService.GetStandings(c.Request.Context(), tournamentID, filter)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidFormat) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Standings are not available for this tournament format"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve standings"})
+			return
+		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"tournament": gin.H{
-				"id":     tournament.ID,
-				"name":   tournament.Name,
-				"format": tournament.FormatType,
-			},
-			"matches": matches,
+			"standings": tables,
 		})
 	}
 }
 
-// HandleGetSchedule retrieves tournament schedule
-func HandleGetSchedule(tournamentService *services.TournamentService, matchService *services.MatchService) gin.HandlerFunc {
+// HandleGetSchedule retrieves the tournament schedule grouped by local date and venue
+func HandleGetSchedule(scheduleService *services.ScheduleService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tournamentID := c.Param("id")
 
-		matches, err := matchService.GetByTournamentID(c.Request.Context(), tournamentID)
+		filter := services.ScheduleFilter{
+			ParticipantID: c.Query("participant_id"),
+			Division:      c.Query("division"),
+			VenueID:       c.Query("venue_id"),
+		}
+
+		schedule, err := scheduleService.GetSchedule(c.Request.Context(), tournamentID, filter)
 		if err != nil {
+			if err == services.ErrNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Tournament, participant or venue not found"})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve schedule"})
 			return
 		}
 
-		// Group matches by date and venue
-		schedule := make(map[string]interface{})
-		// TODO: Implement proper schedule grouping
-
 		c.JSON(http.StatusOK, gin.H{
-			"schedule": matches,
+			"schedule": schedule,
 		})
 	}
 }
 
+// HandleGetScheduleCalendar serves the schedule as an iCalendar feed.
+// The feed covers the whole tournament, or one venue or participant when
+// the route carries a venueId or participantId.
+func HandleGetScheduleCalendar(scheduleService *services.ScheduleService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tournamentID := c.Param("id")
+
+		filter := services.ScheduleFilter{
+			ParticipantID: c.Param("participantId"),
+			VenueID:       c.Param("venueId"),
+		}
+
+		schedule, err := scheduleService.GetSchedule(c.Request.Context(), tournamentID, filter)
+		if err != nil {
+			if err == services.ErrNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Tournament, participant or venue not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve schedule"})
+			return
+		}
+
+		name := schedule.TournamentName
+		switch {
+		case schedule.Participant != nil:
+			name = fmt.Sprintf("%s - %s", schedule.TournamentName, schedule.Participant.Name)
+		case schedule.Venue != nil:
+			name = fmt.Sprintf("%s - %s", schedule.TournamentName, schedule.Venue.Name)
+		}
+
+		var buf bytes.Buffer
+		if err := render.ScheduleICS(&buf, name, schedule); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render calendar"})
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="schedule-%s.ics"`, tournamentID))
+		c.Data(http.StatusOK, "text/calendar; charset=utf-8", buf.Bytes())
+	}
+}
+
 // HandleGetParticipants retrieves tournament participants
 func HandleGetParticipants(tournamentService *services.TournamentService) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -248,26 +353,41 @@ func HandleGetParticipants(tournamentService *services.TournamentService) gin.Ha
 	}
 }
 
-// HandleRegisterParticipant handles participant registration
+// HandleRegisterParticipant handles participant registration and returns the price owed
 func HandleRegisterParticipant(tournamentService *services.TournamentService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tournamentID := c.Param("id")
-		userID, _ := c.Get("user_id")
 
-		var req struct {
-			Name             string                 `json:"name" binding:"required"`
-			Type             string                 `json:"type" binding:"required,oneof=individual team"`
-			ContactEmail     string                 `json:"contact_email" binding:"required,email"`
-			ContactPhone     string                 `json:"contact_phone"`
-			RegistrationData map[string]interface{} `json:"registration_data"`
-		}
+		var req services.RegisterParticipantRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
 			return
 		}
 
-		// TODO: Implement registration logic
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Registration not implemented yet"})
+		registration, err := tournamentService.Register(c.Request.Context(), tournamentID, c.GetString("user_id"), req)
+		if err != nil {
+			switch {
+			case errors.Is(err, services.ErrNotFound):
+				c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+			case errors.Is(err, services.ErrRegistrationClosed):
+				c.JSON(http.StatusConflict, gin.H{"error": "Registration is closed"})
+			case errors.Is(err, services.ErrTournamentFull):
+				c.JSON(http.StatusConflict, gin.H{"error": "Tournament is full"})
+			case errors.Is(err, services.ErrAlreadyRegistered):
+				c.JSON(http.StatusConflict, gin.H{"error": "Already registered for this tournament"})
+			case errors.Is(err, services.ErrInvalidInput):
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register participant"})
+			}
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"participant":      registration.Participant,
+			"price":            registration.Price,
+			"payment_required": registration.Price.AmountDue.IsPositive(),
+		})
 	}
 }
 
@@ -320,17 +440,118 @@ func HandleUpdateParticipant(tournamentService *services.TournamentService) gin.
 	}
 }
 
+// HandleRemoveParticipant removes a participant and queues their refund under the refund policy
 func HandleRemoveParticipant(tournamentService *services.TournamentService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// TODO: Implement
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Not implemented yet"})
+		withdrawal, err := tournamentService.RemoveParticipant(c.Request.Context(), c.Param("id"), c.Param("participantId"), c.GetString("user_id"))
+		if err != nil {
+			respondWithdrawalError(c, err, "Failed to remove participant")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"withdrawal": withdrawal})
+	}
+}
+
+// HandleWithdraw withdraws the signed-in user's participant and queues their refund under the refund policy
+func HandleWithdraw(tournamentService *services.TournamentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		withdrawal, err := tournamentService.Withdraw(c.Request.Context(), c.Param("id"), c.GetString("user_id"))
+		if err != nil {
+			respondWithdrawalError(c, err, "Failed to withdraw")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"withdrawal": withdrawal})
+	}
+}
+
+// respondWithdrawalError maps withdrawal errors to responses
+func respondWithdrawalError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Participant not registered for this tournament"})
+	case errors.Is(err, services.ErrInvalidInput):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// HandleCancelTournament cancels a tournament and queues full refunds of its entry fees
+func HandleCancelTournament(tournamentService *services.TournamentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req services.CancelTournamentRequest
+		if err := c.ShouldBindJSON(&req); err != nil && c.Request.ContentLength > 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+
+		batch, err := tournamentService.Cancel(c.Request.Context(), c.Param("id"), c.GetString("user_id"), req)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidInput) {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel tournament"})
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{
+			"message":      "Tournament cancelled, refunds queued",
+			"refund_batch": batch,
+		})
 	}
 }
 
 func HandleCheckInParticipant(tournamentService *services.TournamentService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// TODO: Implement
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Not implemented yet"})
+		tournamentID := c.Param("id")
+		participantID := c.Param("participantId")
+
+		if err := tournamentService.CheckInParticipant(c.Request.Context(), tournamentID, participantID); err != nil {
+			if err == services.ErrNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Participant not registered for this tournament"})
+				return
+			}
+			if err == services.ErrPaymentRequired {
+				c.JSON(http.StatusPaymentRequired, gin.H{"error": "Entry fee must be paid before check-in"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check in participant"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Participant checked in successfully"})
+	}
+}
+
+// HandleGetPresence lists which participants are connected right now
+func HandleGetPresence(presenceService *services.PresenceService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tournamentID := c.Param("id")
+
+		presence, err := presenceService.GetTournamentPresence(c.Request.Context(), tournamentID)
+		if err != nil {
+			if err == services.ErrNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve presence"})
+			return
+		}
+
+		online := 0
+		for _, p := range presence {
+			if p.Online {
+				online++
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"participants": presence,
+			"online":       online,
+		})
 	}
 }
 
@@ -4,6 +4,7 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 
 	"tournament-planner/internal/services"
@@ -80,6 +81,10 @@ func HandleUpdatePreferences(userService *services.UserService) gin.HandlerFunc
 		}
 
 		if err := userService.UpdatePreferences(c.Request.Context(), userID, preferences); err != nil {
+			if errors.Is(err, services.ErrInvalidInput) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update preferences"})
 			return
 		}
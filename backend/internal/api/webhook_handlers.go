@@ -0,0 +1,142 @@
+// internal/api/webhook_handlers.go
+// Organizer webhook HTTP handlers
+
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"tournament-planner/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleListWebhooks lists a tournament's webhooks
+func HandleListWebhooks(webhookService *services.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		webhooks, err := webhookService.List(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve webhooks"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"webhooks": webhooks})
+	}
+}
+
+// HandleCreateWebhook registers a webhook. The signing secret is only returned here.
+func HandleCreateWebhook(webhookService *services.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req services.WebhookRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+
+		webhook, err := webhookService.Create(c.Request.Context(), c.Param("id"), c.GetString("user_id"), req)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidInput) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create webhook"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"webhook": webhook,
+			"secret":  webhook.Secret,
+		})
+	}
+}
+
+// HandleUpdateWebhook changes a webhook's URL, event filter or active flag
+func HandleUpdateWebhook(webhookService *services.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req services.WebhookRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+
+		webhook, err := webhookService.Update(c.Request.Context(), c.Param("id"), c.Param("webhookId"), req)
+		if err != nil {
+			switch {
+			case errors.Is(err, services.ErrNotFound):
+				c.JSON(http.StatusNotFound, gin.H{"error": "Webhook not found"})
+			case errors.Is(err, services.ErrInvalidInput):
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update webhook"})
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"webhook": webhook})
+	}
+}
+
+// HandleDeleteWebhook removes a webhook
+func HandleDeleteWebhook(webhookService *services.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := webhookService.Delete(c.Request.Context(), c.Param("id"), c.Param("webhookId")); err != nil {
+			if errors.Is(err, services.ErrNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Webhook not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete webhook"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted successfully"})
+	}
+}
+
+// HandleListWebhookDeliveries returns a webhook's delivery log
+func HandleListWebhookDeliveries(webhookService *services.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+		if page < 1 {
+			page = 1
+		}
+		if limit < 1 || limit > 200 {
+			limit = 50
+		}
+
+		deliveries, err := webhookService.ListDeliveries(c.Request.Context(), c.Param("id"), c.Param("webhookId"), limit, (page-1)*limit)
+		if err != nil {
+			if errors.Is(err, services.ErrNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Webhook not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve deliveries"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"deliveries": deliveries,
+			"page":       page,
+			"limit":      limit,
+		})
+	}
+}
+
+// HandleTestWebhook sends a test event to a webhook and reports how the receiver answered
+func HandleTestWebhook(webhookService *services.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		delivery, err := webhookService.SendTest(c.Request.Context(), c.Param("id"), c.Param("webhookId"))
+		if err != nil {
+			if errors.Is(err, services.ErrNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Webhook not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send test event"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"delivery": delivery})
+	}
+}
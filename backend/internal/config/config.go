@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -14,12 +15,13 @@ import (
 
 // Config holds all configuration for the application
 type Config struct {
-	Environment string
-	Server      ServerConfig
-	Database    DatabaseConfig
-	Auth        AuthConfig
-	External    ExternalConfig
-	Features    FeatureFlags
+	Environment   string
+	Server        ServerConfig
+	Database      DatabaseConfig
+	Auth          AuthConfig
+	External      ExternalConfig
+	Notifications NotificationConfig
+	Features      FeatureFlags
 }
 
 // ServerConfig contains HTTP server settings
@@ -68,14 +70,32 @@ type AuthConfig struct {
 
 // ExternalConfig contains third-party service configurations
 type ExternalConfig struct {
+	PaymentProvider     string // stripe or fake
 	StripeSecretKey     string
 	StripeWebhookSecret string
 	SendGridAPIKey      string
 	FrontendURL         string
+	WebSocketOrigins    []string
 	UploadPath          string
 	MaxUploadSize       int64
 }
 
+// NotificationConfig contains outgoing notification settings
+type NotificationConfig struct {
+	Sink          string // smtp, file or memory
+	FilePath      string
+	SMTPHost      string
+	SMTPPort      int
+	SMTPUsername  string
+	SMTPPassword  string
+	FromAddress   string
+	FromName      string
+	DefaultLocale string
+
+	// ReminderOffsets are how long before a match its reminders go out
+	ReminderOffsets []time.Duration
+}
+
 // FeatureFlags allows toggling features without code changes
 type FeatureFlags struct {
 	EnableWebSocket     bool
@@ -126,13 +146,28 @@ func Load() (*Config, error) {
 			BCryptCost:         getIntOrDefault("BCRYPT_COST", 10),
 		},
 		External: ExternalConfig{
+			PaymentProvider:     getEnvOrDefault("PAYMENT_PROVIDER", ""),
 			StripeSecretKey:     getEnvOrDefault("STRIPE_SECRET_KEY", ""),
 			StripeWebhookSecret: getEnvOrDefault("STRIPE_WEBHOOK_SECRET", ""),
 			SendGridAPIKey:      getEnvOrDefault("SENDGRID_API_KEY", ""),
 			FrontendURL:         getEnvOrDefault("FRONTEND_URL", "http://localhost:3000"),
+			WebSocketOrigins:    getListOrDefault("WEBSOCKET_ALLOWED_ORIGINS", nil),
 			UploadPath:          getEnvOrDefault("UPLOAD_PATH", "./uploads"),
 			MaxUploadSize:       getInt64OrDefault("MAX_UPLOAD_SIZE", 10*1024*1024), // 10MB
 		},
+		Notifications: NotificationConfig{
+			Sink:          getEnvOrDefault("NOTIFICATION_SINK", ""),
+			FilePath:      getEnvOrDefault("NOTIFICATION_FILE", "./notifications.log"),
+			SMTPHost:      getEnvOrDefault("SMTP_HOST", ""),
+			SMTPPort:      getIntOrDefault("SMTP_PORT", 587),
+			SMTPUsername:  getEnvOrDefault("SMTP_USERNAME", ""),
+			SMTPPassword:  getEnvOrDefault("SMTP_PASSWORD", ""),
+			FromAddress:   getEnvOrDefault("EMAIL_FROM_ADDRESS", "no-reply@tournament-planner.local"),
+			FromName:      getEnvOrDefault("EMAIL_FROM_NAME", "Tournament Planner"),
+			DefaultLocale: getEnvOrDefault("DEFAULT_LOCALE", "en"),
+			ReminderOffsets: getDurationListOrDefault("MATCH_REMINDER_OFFSETS",
+				[]time.Duration{24 * time.Hour, 30 * time.Minute}),
+		},
 		Features: FeatureFlags{
 			EnableWebSocket:     getBoolOrDefault("ENABLE_WEBSOCKET", true),
 			EnableNotifications: getBoolOrDefault("ENABLE_NOTIFICATIONS", true),
@@ -141,6 +176,31 @@ func Load() (*Config, error) {
 		},
 	}
 
+	// Browsers may open live connections from the frontend unless configured otherwise
+	if len(cfg.External.WebSocketOrigins) == 0 {
+		cfg.External.WebSocketOrigins = []string{cfg.External.FrontendURL}
+	}
+
+	// SendGrid is used through its SMTP relay when no other server is configured
+	if cfg.Notifications.SMTPHost == "" && cfg.External.SendGridAPIKey != "" {
+		cfg.Notifications.SMTPHost = "smtp.sendgrid.net"
+		cfg.Notifications.SMTPUsername = "apikey"
+		cfg.Notifications.SMTPPassword = cfg.External.SendGridAPIKey
+	}
+	// Payments go through Stripe once it is configured
+	if cfg.External.PaymentProvider == "" {
+		cfg.External.PaymentProvider = "fake"
+		if cfg.External.StripeSecretKey != "" {
+			cfg.External.PaymentProvider = "stripe"
+		}
+	}
+	if cfg.Notifications.Sink == "" {
+		cfg.Notifications.Sink = "file"
+		if cfg.Notifications.SMTPHost != "" {
+			cfg.Notifications.Sink = "smtp"
+		}
+	}
+
 	// Validate required configuration
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
@@ -160,14 +220,44 @@ func (c *Config) Validate() error {
 	if c.Auth.JWTSecret == "" {
 		return fmt.Errorf("JWT_SECRET is required")
 	}
-	if c.Environment == "production" {
-		if c.External.StripeSecretKey == "" {
-			return fmt.Errorf("STRIPE_SECRET_KEY is required in production")
+	switch c.Notifications.Sink {
+	case "smtp":
+		if c.Notifications.SMTPHost == "" {
+			return fmt.Errorf("SMTP_HOST or SENDGRID_API_KEY is required for the smtp notification sink")
 		}
-		if c.External.SendGridAPIKey == "" {
-			return fmt.Errorf("SENDGRID_API_KEY is required in production")
+	case "file", "memory":
+	default:
+		return fmt.Errorf("NOTIFICATION_SINK must be smtp, file or memory")
+	}
+	for _, offset := range c.Notifications.ReminderOffsets {
+		if offset <= 0 {
+			return fmt.Errorf("MATCH_REMINDER_OFFSETS must be positive durations such as 24h,30m")
 		}
 	}
+	// The payment provider is only checked when payments are on; with them off
+	// no payment routes are served and no provider is ever called
+	if c.Features.EnablePayments {
+		switch c.External.PaymentProvider {
+		case "stripe":
+			if c.External.StripeSecretKey == "" || c.External.StripeWebhookSecret == "" {
+				return fmt.Errorf("STRIPE_SECRET_KEY and STRIPE_WEBHOOK_SECRET are required for the stripe payment provider")
+			}
+		case "fake":
+			// The fake provider settles payments from events anyone holding the
+			// webhook secret can sign, so it never runs on a shared deployment
+			if c.Environment != "development" && c.Environment != "test" {
+				return fmt.Errorf("the fake payment provider is only allowed in development and test; configure STRIPE_SECRET_KEY")
+			}
+		default:
+			return fmt.Errorf("PAYMENT_PROVIDER must be stripe or fake")
+		}
+		if c.Environment == "production" && c.External.PaymentProvider != "stripe" {
+			return fmt.Errorf("PAYMENT_PROVIDER must be stripe in production")
+		}
+	}
+	if c.Environment == "production" && c.External.SendGridAPIKey == "" {
+		return fmt.Errorf("SENDGRID_API_KEY is required in production")
+	}
 	return nil
 }
 
@@ -197,6 +287,34 @@ func getInt64OrDefault(key string, defaultValue int64) int64 {
 	return defaultValue
 }
 
+func getListOrDefault(key string, defaultValue []string) []string {
+	if value := os.Getenv(key); value != "" {
+		list := make([]string, 0)
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		return list
+	}
+	return defaultValue
+}
+
+func getDurationListOrDefault(key string, defaultValue []time.Duration) []time.Duration {
+	if value := os.Getenv(key); value != "" {
+		list := make([]time.Duration, 0)
+		for _, item := range getListOrDefault(key, nil) {
+			duration, err := time.ParseDuration(item)
+			if err != nil {
+				return defaultValue
+			}
+			list = append(list, duration)
+		}
+		return list
+	}
+	return defaultValue
+}
+
 func getBoolOrDefault(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
 		if boolVal, err := strconv.ParseBool(value); err == nil {
@@ -0,0 +1,84 @@
+// internal/events/bus.go
+// In-process event bus that decouples services from real-time delivery
+
+package events
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Handler receives published events. Handlers run on the bus goroutine and must not block.
+type Handler func(event *Event)
+
+// Bus delivers events published by services to subscribers such as the websocket hub
+type Bus struct {
+	queue    chan *Event
+	handlers []Handler
+	logger   *log.Logger
+	mu       sync.RWMutex
+}
+
+// NewBus creates an event bus and starts its dispatch loop
+func NewBus(logger *log.Logger) *Bus {
+	b := &Bus{
+		queue:  make(chan *Event, 1024),
+		logger: logger,
+	}
+	go b.run()
+	return b
+}
+
+// Subscribe registers a handler for every event published from now on
+func (b *Bus) Subscribe(handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, handler)
+}
+
+// Publish queues an event for delivery. It never blocks the caller; when the
+// queue is full the event is dropped and logged, since clients can refetch state.
+func (b *Bus) Publish(eventType Type, tournamentID string, payload interface{}) {
+	event := &Event{
+		Type:         eventType,
+		TournamentID: tournamentID,
+		Payload:      payload,
+		OccurredAt:   time.Now(),
+	}
+
+	select {
+	case b.queue <- event:
+	default:
+		b.logger.Printf("Event bus full, dropping %s event for tournament %s", eventType, tournamentID)
+	}
+}
+
+// PublishToUser queues an event addressed to a single user, such as an in-app notification
+func (b *Bus) PublishToUser(eventType Type, userID string, payload interface{}) {
+	event := &Event{
+		Type:       eventType,
+		UserID:     userID,
+		Payload:    payload,
+		OccurredAt: time.Now(),
+	}
+
+	select {
+	case b.queue <- event:
+	default:
+		b.logger.Printf("Event bus full, dropping %s event for user %s", eventType, userID)
+	}
+}
+
+// run dispatches queued events to subscribers in publish order
+func (b *Bus) run() {
+	for event := range b.queue {
+		b.mu.RLock()
+		handlers := b.handlers
+		b.mu.RUnlock()
+
+		for _, handle := range handlers {
+			handle(event)
+		}
+	}
+}
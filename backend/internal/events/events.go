@@ -0,0 +1,112 @@
+// internal/events/events.go
+// Domain event types and their typed payloads
+
+package events
+
+import (
+	"time"
+
+	"tournament-planner/internal/models"
+)
+
+// Type identifies a domain event. Values double as websocket message types.
+type Type string
+
+const (
+	TournamentPublished   Type = "tournament_published"
+	TournamentCancelled   Type = "tournament_cancelled"
+	FixturesGenerated     Type = "fixtures_generated"
+	MatchScheduled        Type = "match_scheduled"
+	MatchStarted          Type = "match_started"
+	MatchScoreUpdated     Type = "match_score_updated"
+	MatchCompleted        Type = "match_completed"
+	BracketUpdated        Type = "bracket_updated"
+	ParticipantCheckedIn  Type = "participant_checked_in"
+	ParticipantRegistered Type = "participant_registered"
+	ParticipantWithdrawn  Type = "participant_withdrawn"
+
+	// Notification is addressed to a single user rather than a tournament
+	Notification Type = "notification"
+)
+
+// Event is a domain event scoped to a tournament, or to a user for notifications
+type Event struct {
+	Type         Type        `json:"type"`
+	TournamentID string      `json:"tournament_id"`
+	UserID       string      `json:"user_id,omitempty"`
+	Payload      interface{} `json:"payload"`
+	OccurredAt   time.Time   `json:"occurred_at"`
+}
+
+// TournamentPayload accompanies tournament lifecycle events
+type TournamentPayload struct {
+	TournamentID string                  `json:"tournament_id"`
+	Name         string                  `json:"name"`
+	Status       models.TournamentStatus `json:"status"`
+}
+
+// FixturesPayload accompanies FixturesGenerated
+type FixturesPayload struct {
+	TournamentID string `json:"tournament_id"`
+	MatchCount   int    `json:"match_count"`
+	Rounds       int    `json:"rounds"`
+}
+
+// MatchPayload accompanies match scheduling, start and completion events
+type MatchPayload struct {
+	MatchID           string             `json:"match_id"`
+	RoundNumber       int                `json:"round_number"`
+	MatchNumber       int                `json:"match_number"`
+	Status            models.MatchStatus `json:"status"`
+	Participant1ID    *string            `json:"participant1_id,omitempty"`
+	Participant2ID    *string            `json:"participant2_id,omitempty"`
+	WinnerID          *string            `json:"winner_id,omitempty"`
+	Score1            *int               `json:"score1,omitempty"`
+	Score2            *int               `json:"score2,omitempty"`
+	ScheduledDatetime *time.Time         `json:"scheduled_datetime,omitempty"`
+	VenueID           *string            `json:"venue_id,omitempty"`
+}
+
+// NewMatchPayload builds a MatchPayload from a match
+func NewMatchPayload(m *models.Match) *MatchPayload {
+	return &MatchPayload{
+		MatchID:           m.ID,
+		RoundNumber:       m.RoundNumber,
+		MatchNumber:       m.MatchNumber,
+		Status:            m.Status,
+		Participant1ID:    m.Participant1ID,
+		Participant2ID:    m.Participant2ID,
+		WinnerID:          m.WinnerID,
+		Score1:            m.Score1,
+		Score2:            m.Score2,
+		ScheduledDatetime: m.ScheduledDatetime,
+		VenueID:           m.VenueID,
+	}
+}
+
+// ScorePayload accompanies MatchScoreUpdated
+type ScorePayload struct {
+	MatchID string             `json:"match_id"`
+	Event   *models.ScoreEvent `json:"event"`
+	Score   *models.LiveScore  `json:"score"`
+}
+
+// BracketPayload accompanies BracketUpdated. MatchIDs lists the matches whose slots changed.
+type BracketPayload struct {
+	TournamentID string   `json:"tournament_id"`
+	MatchIDs     []string `json:"match_ids,omitempty"`
+}
+
+// ParticipantPayload accompanies participant events
+type ParticipantPayload struct {
+	TournamentID  string `json:"tournament_id"`
+	ParticipantID string `json:"participant_id"`
+	Name          string `json:"name,omitempty"`
+}
+
+// NotificationPayload accompanies Notification
+type NotificationPayload struct {
+	Template string `json:"template"`
+	Subject  string `json:"subject"`
+	Body     string `json:"body"`
+}
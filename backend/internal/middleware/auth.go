@@ -109,6 +109,23 @@ func RequireTournamentOwner(services *services.Container) gin.HandlerFunc {
 	}
 }
 
+// RequireTournamentStaff ensures the user is the tournament's organizer or one of their staff
+func RequireTournamentStaff(services *services.Container) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := c.Get("user_id")
+		tournamentID := c.Param("id")
+
+		isStaff, err := services.Tournament.IsStaff(c.Request.Context(), tournamentID, userID.(string))
+		if err != nil || !isStaff {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
 // RequireMatchAccess ensures the user can access/modify a match
 func RequireMatchAccess(services *services.Container) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -126,3 +143,20 @@ func RequireMatchAccess(services *services.Container) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// RequireMatchOrganizer ensures the user organizes the tournament a match belongs to
+func RequireMatchOrganizer(services *services.Container) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := c.Get("user_id")
+		matchID := c.Param("id")
+
+		isOrganizer, err := services.Match.IsOrganizer(c.Request.Context(), matchID, userID.(string))
+		if err != nil || !isOrganizer {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
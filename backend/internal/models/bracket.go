@@ -0,0 +1,65 @@
+// internal/models/bracket.go
+// Structured bracket document models used by the bracket endpoint and renderers
+
+package models
+
+import "time"
+
+// Bracket is a format-aware view of all matches in a tournament
+type Bracket struct {
+	TournamentID string            `json:"tournament_id"`
+	Name         string            `json:"name"`
+	Format       TournamentFormat  `json:"format"`
+	Sections     []*BracketSection `json:"sections"`
+	Groups       []*StandingsTable `json:"groups,omitempty"`
+}
+
+// BracketSection groups rounds of one part of a bracket (main, winners, losers, finals)
+type BracketSection struct {
+	Name   BracketSectionName `json:"name"`
+	Title  string             `json:"title"`
+	Rounds []*BracketRound    `json:"rounds"`
+}
+
+// BracketSectionName identifies a section of a bracket
+type BracketSectionName string
+
+const (
+	SectionMain    BracketSectionName = "main"
+	SectionWinners BracketSectionName = "winners"
+	SectionLosers  BracketSectionName = "losers"
+	SectionFinals  BracketSectionName = "finals"
+)
+
+// BracketRound is a single round within a section
+type BracketRound struct {
+	Number  int            `json:"number"`
+	Name    string         `json:"name"`
+	Matches []*BracketSlot `json:"matches"`
+}
+
+// BracketSlot is a match positioned in the bracket with its feeder links
+type BracketSlot struct {
+	MatchID           string          `json:"match_id"`
+	MatchNumber       int             `json:"match_number"`
+	Status            MatchStatus     `json:"status"`
+	Entrant1          *BracketEntrant `json:"entrant1"`
+	Entrant2          *BracketEntrant `json:"entrant2"`
+	WinnerID          *string         `json:"winner_id,omitempty"`
+	Score1            *int            `json:"score1,omitempty"`
+	Score2            *int            `json:"score2,omitempty"`
+	ScheduledDatetime *time.Time      `json:"scheduled_datetime,omitempty"`
+	VenueID           *string         `json:"venue_id,omitempty"`
+	FeederMatchIDs    []string        `json:"feeder_match_ids,omitempty"`
+	NextMatchID       *string         `json:"next_match_id,omitempty"`
+}
+
+// BracketEntrant is one side of a bracket slot, either a known participant
+// or a placeholder waiting on a feeder match
+type BracketEntrant struct {
+	ParticipantID *string `json:"participant_id,omitempty"`
+	Name          string  `json:"name"`
+	Seed          *int    `json:"seed,omitempty"`
+	FeederMatchID *string `json:"feeder_match_id,omitempty"`
+	IsBye         bool    `json:"is_bye,omitempty"`
+}
@@ -0,0 +1,134 @@
+// internal/models/invoice.go
+// Invoices and credit notes issued for entry fees, and the organizer billing
+// details printed on them
+
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// InvoiceKind distinguishes invoices from the credit notes that refund them
+type InvoiceKind string
+
+const (
+	InvoiceKindInvoice    InvoiceKind = "invoice"
+	InvoiceKindCreditNote InvoiceKind = "credit_note"
+)
+
+// NumberPrefix is put in front of the sequential number of each kind
+func (k InvoiceKind) NumberPrefix() string {
+	if k == InvoiceKindCreditNote {
+		return "CN"
+	}
+	return "INV"
+}
+
+// BillingProfile is what an organizer wants printed on the invoices they issue.
+// Entry fees include tax at the profile's rate; a zero rate prints no tax line.
+type BillingProfile struct {
+	UserID             string    `json:"user_id" db:"user_id"`
+	LegalName          string    `json:"legal_name" db:"legal_name"`
+	Address            *string   `json:"address,omitempty" db:"address"`
+	TaxID              *string   `json:"tax_id,omitempty" db:"tax_id"`
+	Email              *string   `json:"email,omitempty" db:"email"`
+	TaxLabel           string    `json:"tax_label" db:"tax_label"`
+	TaxRateBasisPoints int       `json:"tax_rate_basis_points" db:"tax_rate_basis_points"`
+	UpdatedAt          time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// InvoiceParty is the seller or buyer as printed on an invoice. It is copied
+// onto the invoice when issued, so later profile changes leave it untouched.
+type InvoiceParty struct {
+	Name    string `json:"name"`
+	Address string `json:"address,omitempty"`
+	TaxID   string `json:"tax_id,omitempty"`
+	Email   string `json:"email,omitempty"`
+}
+
+// Implement sql.Scanner and driver.Valuer for InvoiceParty
+func (p *InvoiceParty) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into InvoiceParty", value)
+	}
+	return json.Unmarshal(bytes, p)
+}
+
+func (p InvoiceParty) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// InvoiceLine is one line item. Amounts include tax; discounts are negative.
+type InvoiceLine struct {
+	Description string `json:"description"`
+	Quantity    int    `json:"quantity"`
+	UnitAmount  Money  `json:"unit_amount"`
+	Amount      Money  `json:"amount"`
+}
+
+// InvoiceLines are the line items of an invoice
+type InvoiceLines []InvoiceLine
+
+// Implement sql.Scanner and driver.Valuer for InvoiceLines
+func (l *InvoiceLines) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into InvoiceLines", value)
+	}
+	return json.Unmarshal(bytes, l)
+}
+
+func (l InvoiceLines) Value() (driver.Value, error) {
+	return json.Marshal(l)
+}
+
+// Invoice is an invoice for a settled entry fee, doubling as its receipt, or a
+// credit note for a refund of one. Numbers run per organizer and kind without
+// gaps. SourceReference is the payment, onsite collection or refund event the
+// document was issued for, so the same one is never issued twice.
+type Invoice struct {
+	ID                 string       `json:"id" db:"id"`
+	Number             string       `json:"number" db:"number"`
+	Kind               InvoiceKind  `json:"kind" db:"kind"`
+	OrganizerID        string       `json:"organizer_id" db:"organizer_id"`
+	TournamentID       string       `json:"tournament_id" db:"tournament_id"`
+	ParticipantID      string       `json:"participant_id" db:"participant_id"`
+	CreditedInvoiceID  *string      `json:"credited_invoice_id,omitempty" db:"credited_invoice_id"`
+	CreditedNumber     string       `json:"credited_number,omitempty" db:"-"`
+	SourceReference    string       `json:"-" db:"source_reference"`
+	Seller             InvoiceParty `json:"seller" db:"seller"`
+	Buyer              InvoiceParty `json:"buyer" db:"buyer"`
+	Lines              InvoiceLines `json:"lines" db:"line_items"`
+	Subtotal           Money        `json:"subtotal" db:"subtotal_cents"`
+	TaxLabel           string       `json:"tax_label,omitempty" db:"tax_label"`
+	TaxRateBasisPoints int          `json:"tax_rate_basis_points" db:"tax_rate_basis_points"`
+	Tax                Money        `json:"tax" db:"tax_cents"`
+	Total              Money        `json:"total" db:"total_cents"`
+	PaymentMethod      string       `json:"payment_method" db:"payment_method"`
+	IssuedAt           time.Time    `json:"issued_at" db:"issued_at"`
+}
+
+// InclusiveTax is the tax contained in a tax-inclusive amount, rounded to the
+// nearest minor unit
+func InclusiveTax(amount Money, rateBasisPoints int) Money {
+	tax := NewMoney(0, amount.Currency)
+	if rateBasisPoints <= 0 {
+		return tax
+	}
+	rate := int64(rateBasisPoints)
+	tax.Amount = amount.Amount * rate / (10000 + rate)
+	if 2*(amount.Amount*rate%(10000+rate)) >= 10000+rate {
+		tax.Amount++
+	}
+	return tax
+}
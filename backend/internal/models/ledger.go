@@ -0,0 +1,88 @@
+// internal/models/ledger.go
+// Payment ledger entries and balances per tournament participant
+
+package models
+
+import "time"
+
+// LedgerEntryType classifies money movements in the payment ledger
+type LedgerEntryType string
+
+const (
+	LedgerCharge     LedgerEntryType = "charge"
+	LedgerRefund     LedgerEntryType = "refund"
+	LedgerFee        LedgerEntryType = "fee"
+	LedgerAdjustment LedgerEntryType = "adjustment"
+)
+
+// LedgerEntry is one money movement for a participant. Amounts are signed from
+// the organizer's side: charges are positive, refunds and fees negative, and
+// adjustments either.
+type LedgerEntry struct {
+	ID                string          `json:"id" db:"id"`
+	TournamentID      string          `json:"tournament_id" db:"tournament_id"`
+	ParticipantID     string          `json:"participant_id" db:"participant_id"`
+	PaymentID         *string         `json:"payment_id,omitempty" db:"payment_id"`
+	Type              LedgerEntryType `json:"type" db:"entry_type"`
+	Amount            Money           `json:"amount" db:"amount_cents"`
+	ProviderReference *string         `json:"provider_reference,omitempty" db:"provider_reference"`
+	Description       string          `json:"description" db:"description"`
+	CreatedBy         *string         `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt         time.Time       `json:"created_at" db:"created_at"`
+}
+
+// LedgerBalance totals ledger entries. Refunded and Fees are positive totals of
+// negative entries. Paid is what the participant has paid net of refunds and
+// adjustments; Net is what the organizer keeps after fees.
+type LedgerBalance struct {
+	Charged     Money `json:"charged"`
+	Refunded    Money `json:"refunded"`
+	Fees        Money `json:"fees"`
+	Adjustments Money `json:"adjustments"`
+	Paid        Money `json:"paid"`
+	Net         Money `json:"net"`
+}
+
+// NewLedgerBalance creates an empty balance in a currency
+func NewLedgerBalance(currency string) LedgerBalance {
+	zero := NewMoney(0, currency)
+	return LedgerBalance{Charged: zero, Refunded: zero, Fees: zero, Adjustments: zero, Paid: zero, Net: zero}
+}
+
+// Add adds a signed amount of an entry type to the balance
+func (b *LedgerBalance) Add(entryType LedgerEntryType, amount Money) {
+	switch entryType {
+	case LedgerCharge:
+		b.Charged = b.Charged.Add(amount)
+	case LedgerRefund:
+		b.Refunded = b.Refunded.Sub(amount)
+	case LedgerFee:
+		b.Fees = b.Fees.Sub(amount)
+	case LedgerAdjustment:
+		b.Adjustments = b.Adjustments.Add(amount)
+	}
+	b.Paid = b.Charged.Sub(b.Refunded).Add(b.Adjustments)
+	b.Net = b.Paid.Sub(b.Fees)
+}
+
+// Merge adds another balance's totals to this one
+func (b *LedgerBalance) Merge(other LedgerBalance) {
+	b.Add(LedgerCharge, other.Charged)
+	b.Add(LedgerRefund, other.Refunded.Neg())
+	b.Add(LedgerFee, other.Fees.Neg())
+	b.Add(LedgerAdjustment, other.Adjustments)
+}
+
+// ExpectedPaymentStatuses lists the participant payment statuses the balance
+// supports: money kept means paid, money fully returned means refunded, and
+// no money taken means pending or waived.
+func (b LedgerBalance) ExpectedPaymentStatuses() []PaymentStatus {
+	switch {
+	case b.Paid.IsPositive():
+		return []PaymentStatus{PaymentPaid}
+	case b.Charged.IsPositive():
+		return []PaymentStatus{PaymentRefunded}
+	default:
+		return []PaymentStatus{PaymentPending, PaymentWaived}
+	}
+}
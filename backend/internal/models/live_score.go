@@ -0,0 +1,55 @@
+// internal/models/live_score.go
+// Live scoring event log models
+
+package models
+
+import "time"
+
+// ScoreEventType identifies an incremental scoring event
+type ScoreEventType string
+
+const (
+	ScoreEventPoint   ScoreEventType = "point"
+	ScoreEventGame    ScoreEventType = "game"
+	ScoreEventSet     ScoreEventType = "set"
+	ScoreEventGoal    ScoreEventType = "goal"
+	ScoreEventTimeout ScoreEventType = "timeout"
+	ScoreEventCard    ScoreEventType = "card"
+)
+
+// IsValid reports whether the event type is one the live scorer understands
+func (t ScoreEventType) IsValid() bool {
+	switch t {
+	case ScoreEventPoint, ScoreEventGame, ScoreEventSet, ScoreEventGoal, ScoreEventTimeout, ScoreEventCard:
+		return true
+	}
+	return false
+}
+
+// ScoreEvent is one entry in a match's live scoring log.
+// Side is 1 or 2 for the participant the event applies to. PlayEpoch is the
+// play of the match the event was scored in; voiding a result starts a new one.
+type ScoreEvent struct {
+	ID           string         `json:"id" bson:"event_id"`
+	MatchID      string         `json:"match_id" bson:"match_id"`
+	TournamentID string         `json:"tournament_id" bson:"tournament_id"`
+	PlayEpoch    int            `json:"play_epoch" bson:"play_epoch"`
+	Sequence     int64          `json:"sequence" bson:"sequence"`
+	Type         ScoreEventType `json:"type" bson:"event_type"`
+	Side         int            `json:"side" bson:"side"`
+	Value        int            `json:"value" bson:"value"`
+	Detail       string         `json:"detail,omitempty" bson:"detail,omitempty"`
+	RecordedBy   string         `json:"recorded_by,omitempty" bson:"updated_by,omitempty"`
+	Timestamp    time.Time      `json:"timestamp" bson:"timestamp"`
+}
+
+// LiveScore is the running score of a match derived by replaying its event log.
+// Score1 and Score2 count the highest unit in use: sets, then games, then points and goals.
+type LiveScore struct {
+	MatchID      string       `json:"match_id"`
+	Score1       int          `json:"score1"`
+	Score2       int          `json:"score2"`
+	Details      ScoreDetails `json:"score_details"`
+	LastSequence int64        `json:"last_sequence"`
+	UpdatedAt    *time.Time   `json:"updated_at,omitempty"`
+}
@@ -35,10 +35,23 @@ type Match struct {
 	RefereeID         *string       `json:"referee_id,omitempty" db:"referee_id"`
 	NextMatchID       *string       `json:"next_match_id,omitempty" db:"next_match_id"`
 	Notes             *string       `json:"notes,omitempty" db:"notes"`
+	PlayEpoch         int           `json:"play_epoch" db:"play_epoch"`
 	CreatedAt         time.Time     `json:"created_at" db:"created_at"`
 	UpdatedAt         time.Time     `json:"updated_at" db:"updated_at"`
 }
 
+// MatchReminder records a reminder sent for a match at one offset before the
+// time it was scheduled for. A reminder whose match has since moved is stale
+// until a correction is sent and CorrectedAt is set.
+type MatchReminder struct {
+	MatchID      string     `json:"match_id" db:"match_id"`
+	TournamentID string     `json:"tournament_id" db:"tournament_id"`
+	OffsetMins   int        `json:"offset_minutes" db:"offset_minutes"`
+	ScheduledFor time.Time  `json:"scheduled_for" db:"scheduled_for"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	CorrectedAt  *time.Time `json:"corrected_at,omitempty" db:"corrected_at"`
+}
+
 // MatchStatus represents the current state of a match
 type MatchStatus string
 
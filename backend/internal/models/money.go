@@ -0,0 +1,217 @@
+// internal/models/money.go
+// Exact money amounts in integer minor units of an ISO 4217 currency
+
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is used for tournaments that don't set one
+const DefaultCurrency = "USD"
+
+// currencyFormat is how many minor units digits a currency has and the symbol
+// it is written with. Currencies without a symbol are written with their code.
+type currencyFormat struct {
+	exponent int
+	symbol   string
+}
+
+// currencies are the ISO 4217 currencies entry fees can be charged in
+var currencies = map[string]currencyFormat{
+	"AUD": {2, "A$"},
+	"BGN": {2, ""},
+	"BRL": {2, "R$"},
+	"CAD": {2, "CA$"},
+	"CHF": {2, ""},
+	"CLP": {0, ""},
+	"CZK": {2, ""},
+	"DKK": {2, ""},
+	"EUR": {2, "€"},
+	"GBP": {2, "£"},
+	"HKD": {2, "HK$"},
+	"HUF": {2, ""},
+	"INR": {2, ""},
+	"ISK": {0, ""},
+	"JPY": {0, "¥"},
+	"KRW": {0, ""},
+	"KWD": {3, ""},
+	"MXN": {2, "MX$"},
+	"NOK": {2, ""},
+	"NZD": {2, "NZ$"},
+	"PLN": {2, ""},
+	"RON": {2, ""},
+	"SEK": {2, ""},
+	"SGD": {2, "S$"},
+	"USD": {2, "$"},
+	"ZAR": {2, ""},
+}
+
+// ValidCurrency reports whether a currency code is one fees can be charged in.
+// Codes are matched case-insensitively.
+func ValidCurrency(code string) bool {
+	_, ok := currencies[strings.ToUpper(code)]
+	return ok
+}
+
+// Money is an amount in the minor units of a currency, such as cents for USD
+// or yen for JPY. Amounts are never held as floating point.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// NewMoney creates an amount of minor units in a currency
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(currency)}
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsPositive reports whether the amount is above zero
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// IsNegative reports whether the amount is below zero
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// Add returns the sum of two amounts. An amount without a currency takes the
+// other's, so a zero Money can be used to start a total. Adding different
+// currencies is a programming error and panics.
+func (m Money) Add(other Money) Money {
+	currency := m.sameCurrency(other)
+	return Money{Amount: m.Amount + other.Amount, Currency: currency}
+}
+
+// Sub returns the difference of two amounts, under the same rules as Add
+func (m Money) Sub(other Money) Money {
+	currency := m.sameCurrency(other)
+	return Money{Amount: m.Amount - other.Amount, Currency: currency}
+}
+
+// Neg returns the amount with its sign flipped
+func (m Money) Neg() Money {
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+// Min returns the smaller of two amounts, under the same rules as Add
+func (m Money) Min(other Money) Money {
+	currency := m.sameCurrency(other)
+	return Money{Amount: min(m.Amount, other.Amount), Currency: currency}
+}
+
+// sameCurrency returns the currency two amounts share
+func (m Money) sameCurrency(other Money) string {
+	switch {
+	case m.Currency == "":
+		return other.Currency
+	case other.Currency == "" || strings.EqualFold(m.Currency, other.Currency):
+		return m.Currency
+	}
+	panic(fmt.Sprintf("cannot combine %s and %s amounts", m.Currency, other.Currency))
+}
+
+// Percent returns a percentage of the amount, rounded half away from zero to a minor unit
+func (m Money) Percent(percent int64) Money {
+	scaled := m.Amount * percent
+	amount := scaled / 100
+	if remainder := scaled % 100; remainder >= 50 {
+		amount++
+	} else if remainder <= -50 {
+		amount--
+	}
+	return Money{Amount: amount, Currency: m.Currency}
+}
+
+// String writes the amount the way its currency is usually written, such as
+// "$1,234.50", "¥1,235" or "1,234.500 KWD"
+func (m Money) String() string {
+	sign, whole, fraction := m.digits()
+
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+	number := grouped.String()
+	if fraction != "" {
+		number += "." + fraction
+	}
+
+	symbol := currencies[strings.ToUpper(m.Currency)].symbol
+	switch {
+	case symbol != "":
+		return sign + symbol + number
+	case m.Currency == "":
+		return sign + number
+	}
+	return sign + number + " " + strings.ToUpper(m.Currency)
+}
+
+// Decimal writes the amount in major units without grouping or a symbol, such
+// as "1234.50", for output read by spreadsheets and other programs
+func (m Money) Decimal() string {
+	sign, whole, fraction := m.digits()
+	if fraction == "" {
+		return sign + whole
+	}
+	return sign + whole + "." + fraction
+}
+
+// digits splits the amount into its sign and the digits of its major and minor
+// units. Unknown currencies are taken to have two minor digits.
+func (m Money) digits() (sign, whole, fraction string) {
+	exponent := 2
+	if format, ok := currencies[strings.ToUpper(m.Currency)]; ok {
+		exponent = format.exponent
+	}
+
+	amount := m.Amount
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	digits := strconv.FormatInt(amount, 10)
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	return sign, digits[:len(digits)-exponent], digits[len(digits)-exponent:]
+}
+
+// moneyJSON is how Money appears in requests and responses. Formatted is only
+// written, never read.
+type moneyJSON struct {
+	Amount    int64  `json:"amount"`
+	Currency  string `json:"currency"`
+	Formatted string `json:"formatted,omitempty"`
+}
+
+// MarshalJSON writes the amount with its formatted form for display
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{
+		Amount:    m.Amount,
+		Currency:  m.Currency,
+		Formatted: m.String(),
+	})
+}
+
+// UnmarshalJSON reads an amount and currency. The currency may be left out
+// where the context implies one.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var v moneyJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("money must be an object with an amount in minor units and a currency: %w", err)
+	}
+	*m = NewMoney(v.Amount, v.Currency)
+	return nil
+}
@@ -0,0 +1,107 @@
+// internal/models/money_test.go
+// Money rounding, formatting and currency checks
+
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMoneyPercent(t *testing.T) {
+	tests := []struct {
+		amount  int64
+		percent int64
+		want    int64
+	}{
+		{5000, 10, 500},
+		{5000, 0, 0},
+		{5000, 100, 5000},
+		{1250, 15, 188},   // 187.5 rounds up
+		{1249, 15, 187},   // 187.35 rounds down
+		{-1250, 15, -188}, // half rounds away from zero
+		{-1249, 15, -187},
+		{999, 33, 330}, // 329.67
+		{1, 50, 1},     // 0.5 rounds up
+		{1, 49, 0},
+	}
+	for _, tt := range tests {
+		got := NewMoney(tt.amount, "EUR").Percent(tt.percent)
+		if got.Amount != tt.want || got.Currency != "EUR" {
+			t.Errorf("%d.Percent(%d) = %d %s, want %d EUR", tt.amount, tt.percent, got.Amount, got.Currency, tt.want)
+		}
+	}
+}
+
+func TestMoneyFormatting(t *testing.T) {
+	tests := []struct {
+		money   Money
+		str     string
+		decimal string
+	}{
+		// Two minor digits
+		{NewMoney(123450, "USD"), "$1,234.50", "1234.50"},
+		{NewMoney(5, "USD"), "$0.05", "0.05"},
+		{NewMoney(0, "EUR"), "€0.00", "0.00"},
+		{NewMoney(-1250, "EUR"), "-€12.50", "-12.50"},
+		{NewMoney(100000000, "SEK"), "1,000,000.00 SEK", "1000000.00"},
+		{NewMoney(1999, ""), "19.99", "19.99"},
+		{NewMoney(1999, "XYZ"), "19.99 XYZ", "19.99"}, // unknown currencies have two digits
+
+		// No minor digits
+		{NewMoney(1235, "JPY"), "¥1,235", "1235"},
+		{NewMoney(0, "JPY"), "¥0", "0"},
+		{NewMoney(-500, "KRW"), "-500 KRW", "-500"},
+
+		// Three minor digits
+		{NewMoney(1234500, "KWD"), "1,234.500 KWD", "1234.500"},
+		{NewMoney(7, "kwd"), "0.007 KWD", "0.007"},
+		{NewMoney(-1500, "KWD"), "-1.500 KWD", "-1.500"},
+	}
+	for _, tt := range tests {
+		if got := tt.money.String(); got != tt.str {
+			t.Errorf("%d %s String() = %q, want %q", tt.money.Amount, tt.money.Currency, got, tt.str)
+		}
+		if got := tt.money.Decimal(); got != tt.decimal {
+			t.Errorf("%d %s Decimal() = %q, want %q", tt.money.Amount, tt.money.Currency, got, tt.decimal)
+		}
+	}
+}
+
+func TestMoneyCombinesCurrencies(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Money
+		want Money
+	}{
+		{"same currency", NewMoney(100, "EUR"), NewMoney(50, "EUR"), NewMoney(150, "EUR")},
+		{"zero total takes currency", Money{}, NewMoney(50, "EUR"), NewMoney(50, "EUR")},
+		{"currency-less operand", NewMoney(100, "EUR"), Money{Amount: 50}, NewMoney(150, "EUR")},
+		{"case-insensitive", NewMoney(100, "EUR"), Money{Amount: 50, Currency: "eur"}, NewMoney(150, "EUR")},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Add(tt.b); got != tt.want {
+			t.Errorf("%s: Add = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyMixedCurrenciesPanic(t *testing.T) {
+	ops := map[string]func(a, b Money) Money{
+		"Add": Money.Add,
+		"Sub": Money.Sub,
+		"Min": Money.Min,
+	}
+	for name, op := range ops {
+		func() {
+			defer func() {
+				r := recover()
+				msg, _ := r.(string)
+				if !strings.Contains(msg, "cannot combine EUR and USD") {
+					t.Errorf("%s of EUR and USD panicked with %v, want a currency mismatch", name, r)
+				}
+			}()
+			op(NewMoney(100, "EUR"), NewMoney(100, "USD"))
+		}()
+	}
+}
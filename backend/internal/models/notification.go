@@ -0,0 +1,159 @@
+// internal/models/notification.go
+// Notification outbox entries written alongside the domain changes that trigger them,
+// and rendered notifications held back by recipient preferences
+
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// NotificationKind identifies what a queued notification is about
+type NotificationKind string
+
+const (
+	NotificationTournamentPublished   NotificationKind = "tournament_published"
+	NotificationFixturesGenerated     NotificationKind = "fixtures_generated"
+	NotificationMatchScheduled        NotificationKind = "match_scheduled"
+	NotificationMatchResult           NotificationKind = "match_result"
+	NotificationMatchReminder         NotificationKind = "match_reminder"
+	NotificationReminderCorrection    NotificationKind = "match_reminder_correction"
+	NotificationParticipantRegistered NotificationKind = "participant_registered"
+	NotificationPaymentReceipt        NotificationKind = "payment_receipt"
+)
+
+// Digestible reports whether a notification is routine enough to be batched into
+// a daily digest for recipients who opted into one. Anything that asks the
+// recipient to act or be somewhere is sent straight away.
+func (k NotificationKind) Digestible() bool {
+	return k == NotificationMatchResult || k == NotificationParticipantRegistered
+}
+
+// Holdable reports whether a notification may wait out the recipient's quiet
+// hours. Payment receipts answer something the recipient just did and carry
+// their invoice, which a held message can't, so they are sent straight away.
+// Reminders and their corrections are about a match time that may have passed
+// or moved by the end of the quiet hours, so they aren't held either.
+func (k NotificationKind) Holdable() bool {
+	switch k {
+	case NotificationPaymentReceipt, NotificationMatchReminder, NotificationReminderCorrection:
+		return false
+	}
+	return true
+}
+
+// OutboxStatus is the delivery state of an outbox entry
+type OutboxStatus string
+
+const (
+	OutboxPending   OutboxStatus = "pending"
+	OutboxDelivered OutboxStatus = "delivered"
+	// OutboxDead entries ran out of attempts and wait for an admin to replay them
+	OutboxDead OutboxStatus = "dead"
+)
+
+// OutboxEntry is one notification waiting to be delivered. The idempotency key
+// makes enqueueing the same domain change twice a no-op, and DeliveredTo records
+// which recipient and channel pairs already succeeded so retries skip them.
+type OutboxEntry struct {
+	ID             string              `json:"id" db:"id"`
+	IdempotencyKey string              `json:"idempotency_key" db:"idempotency_key"`
+	Kind           NotificationKind    `json:"kind" db:"kind"`
+	Payload        NotificationPayload `json:"payload" db:"payload"`
+	Status         OutboxStatus        `json:"status" db:"status"`
+	Attempts       int                 `json:"attempts" db:"attempts"`
+	NextAttemptAt  time.Time           `json:"next_attempt_at" db:"next_attempt_at"`
+	LastError      *string             `json:"last_error,omitempty" db:"last_error"`
+	DeliveredTo    DeliveryKeys        `json:"delivered_to" db:"delivered_to"`
+	CreatedAt      time.Time           `json:"created_at" db:"created_at"`
+	DeliveredAt    *time.Time          `json:"delivered_at,omitempty" db:"delivered_at"`
+}
+
+// NotificationPayload identifies the records a notification is rendered from.
+// Current state is loaded at delivery time. ScheduledFor is the match time a
+// reminder was queued for, or the time a correction supersedes. InvoiceID is
+// the invoice a payment receipt attaches.
+type NotificationPayload struct {
+	TournamentID   string     `json:"tournament_id"`
+	MatchID        string     `json:"match_id,omitempty"`
+	ParticipantIDs []string   `json:"participant_ids,omitempty"`
+	ScheduledFor   *time.Time `json:"scheduled_for,omitempty"`
+	InvoiceID      string     `json:"invoice_id,omitempty"`
+}
+
+// Implement sql.Scanner and driver.Valuer for NotificationPayload
+func (p *NotificationPayload) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into NotificationPayload", value)
+	}
+	return json.Unmarshal(bytes, p)
+}
+
+func (p NotificationPayload) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// DeliveryKeys lists "recipient:channel" pairs that were delivered
+type DeliveryKeys []string
+
+// Implement sql.Scanner and driver.Valuer for DeliveryKeys
+func (d *DeliveryKeys) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into DeliveryKeys", value)
+	}
+	return json.Unmarshal(bytes, d)
+}
+
+func (d DeliveryKeys) Value() (driver.Value, error) {
+	if d == nil {
+		return json.Marshal([]string{})
+	}
+	return json.Marshal(d)
+}
+
+// Contains reports whether a key was delivered
+func (d DeliveryKeys) Contains(key string) bool {
+	for _, k := range d {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// HeldNotification is a rendered message waiting for its recipient's quiet hours
+// to end or for their daily digest. Held messages due for the same user at the
+// same time are sent as one digest.
+type HeldNotification struct {
+	ID            string       `json:"id" db:"id"`
+	SourceKey     string       `json:"source_key" db:"source_key"`
+	UserID        string       `json:"user_id" db:"user_id"`
+	ParticipantID *string      `json:"participant_id,omitempty" db:"participant_id"`
+	RecipientName string       `json:"recipient_name" db:"recipient_name"`
+	Email         *string      `json:"email,omitempty" db:"email"`
+	Phone         *string      `json:"phone,omitempty" db:"phone"`
+	Locale        string       `json:"locale" db:"locale"`
+	Channel       string       `json:"channel" db:"channel"`
+	Template      string       `json:"template" db:"template"`
+	Subject       string       `json:"subject" db:"subject"`
+	Body          string       `json:"body" db:"body"`
+	Summary       string       `json:"summary" db:"summary"`
+	Digest        bool         `json:"digest" db:"digest"`
+	DeliverAfter  time.Time    `json:"deliver_after" db:"deliver_after"`
+	Status        OutboxStatus `json:"status" db:"status"`
+	Attempts      int          `json:"attempts" db:"attempts"`
+	LastError     *string      `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt     time.Time    `json:"created_at" db:"created_at"`
+	SentAt        *time.Time   `json:"sent_at,omitempty" db:"sent_at"`
+}
@@ -0,0 +1,61 @@
+// internal/models/onsite_payment.go
+// Entry fees collected at the venue and the staff cash-up summary
+
+package models
+
+import "time"
+
+// CollectionMethod is how staff took an entry fee at the venue
+type CollectionMethod string
+
+const (
+	CollectedCash CollectionMethod = "cash"
+	CollectedCard CollectionMethod = "card"
+)
+
+// OnsitePayment is an entry fee a staff member collected at the venue
+type OnsitePayment struct {
+	ID              string           `json:"id" db:"id"`
+	TournamentID    string           `json:"tournament_id" db:"tournament_id"`
+	ParticipantID   string           `json:"participant_id" db:"participant_id"`
+	Amount          Money            `json:"amount" db:"amount_cents"`
+	Method          CollectionMethod `json:"method" db:"method"`
+	Note            *string          `json:"note,omitempty" db:"note"`
+	CollectedBy     string           `json:"collected_by" db:"collected_by"`
+	CollectedByName string           `json:"collected_by_name,omitempty" db:"-"`
+	CollectedAt     time.Time        `json:"collected_at" db:"collected_at"`
+}
+
+// CashSummary totals one day's onsite collections per staff member
+type CashSummary struct {
+	TournamentID string              `json:"tournament_id"`
+	Date         string              `json:"date"`
+	Timezone     string              `json:"timezone"`
+	Staff        []*StaffCashSummary `json:"staff"`
+	Cash         Money               `json:"cash"`
+	Card         Money               `json:"card"`
+	Total        Money               `json:"total"`
+	Collections  int                 `json:"collections"`
+}
+
+// StaffCashSummary is what one staff member collected over the day
+type StaffCashSummary struct {
+	UserID      string `json:"user_id"`
+	Name        string `json:"name,omitempty"`
+	Cash        Money  `json:"cash"`
+	Card        Money  `json:"card"`
+	Total       Money  `json:"total"`
+	Collections int    `json:"collections"`
+}
+
+// Add counts a collection towards the staff member's totals
+func (s *StaffCashSummary) Add(payment *OnsitePayment) {
+	switch payment.Method {
+	case CollectedCash:
+		s.Cash = s.Cash.Add(payment.Amount)
+	case CollectedCard:
+		s.Card = s.Card.Add(payment.Amount)
+	}
+	s.Total = s.Total.Add(payment.Amount)
+	s.Collections++
+}
@@ -28,6 +28,12 @@ type Participant struct {
 	Division         *string                `json:"division,omitempty" db:"division"`
 	GroupName        *string                `json:"group_name,omitempty" db:"group_name"`
 	PaymentStatus    *PaymentStatus         `json:"payment_status,omitempty" db:"payment_status"`
+	PaymentMethod    *PaymentMethod         `json:"payment_method,omitempty" db:"payment_method"`
+	PaymentDueAt     *time.Time             `json:"payment_due_at,omitempty" db:"payment_due_at"`
+	PriceTier        *PriceTier             `json:"price_tier,omitempty" db:"price_tier"`
+	BaseAmountCents  *int64                 `json:"base_amount_cents,omitempty" db:"base_amount_cents"`
+	DiscountCents    *int64                 `json:"discount_cents,omitempty" db:"discount_cents"`
+	AmountDueCents   *int64                 `json:"amount_due_cents,omitempty" db:"amount_due_cents"`
 	CheckedIn        *bool                  `json:"checked_in,omitempty" db:"checked_in"`
 	RegistrationData map[string]interface{} `json:"registration_data,omitempty" db:"registration_data"`
 }
@@ -50,6 +56,14 @@ const (
 	PaymentWaived   PaymentStatus = "waived"
 )
 
+// PaymentMethod is how a participant intends to pay their entry fee
+type PaymentMethod string
+
+const (
+	PaymentOnline PaymentMethod = "online"
+	PaymentOnsite PaymentMethod = "onsite"
+)
+
 // Implement sql.Scanner and driver.Valuer for RegistrationData
 func (r *map[string]interface{}) Scan(value interface{}) error {
 	if value == nil {
@@ -0,0 +1,57 @@
+// internal/models/payment.go
+// Payments made through the payment provider
+
+package models
+
+import "time"
+
+// Payment is an entry fee payment started with the payment provider. Its
+// status follows the provider's webhook events.
+type Payment struct {
+	ID                string       `json:"id" db:"id"`
+	TournamentID      string       `json:"tournament_id" db:"tournament_id"`
+	ParticipantID     string       `json:"participant_id" db:"participant_id"`
+	Provider          string       `json:"provider" db:"provider"`
+	ProviderPaymentID *string      `json:"provider_payment_id,omitempty" db:"provider_payment_id"`
+	Amount            Money        `json:"amount" db:"amount_cents"`
+	AmountRefunded    Money        `json:"amount_refunded" db:"amount_refunded_cents"`
+	Status            ChargeStatus `json:"status" db:"status"`
+	FailureMessage    *string      `json:"failure_message,omitempty" db:"failure_message"`
+	Overpayment       bool         `json:"overpayment,omitempty" db:"overpayment"`
+	CreatedBy         *string      `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt         time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// Refundable is what is left of the payment to refund
+func (p *Payment) Refundable() Money {
+	return p.Amount.Sub(p.AmountRefunded)
+}
+
+// ChargeStatus is the provider-confirmed state of a payment
+type ChargeStatus string
+
+const (
+	ChargeRequiresPayment ChargeStatus = "requires_payment"
+	ChargeAuthorized      ChargeStatus = "authorized"
+	ChargeSucceeded       ChargeStatus = "succeeded"
+	ChargeFailed          ChargeStatus = "failed"
+	ChargeCanceled        ChargeStatus = "canceled"
+	ChargeRefunded        ChargeStatus = "refunded"
+)
+
+// chargeStatusRank orders statuses so late or out-of-order events can't move a payment back
+var chargeStatusRank = map[ChargeStatus]int{
+	ChargeRequiresPayment: 0,
+	ChargeFailed:          1,
+	ChargeAuthorized:      2,
+	ChargeCanceled:        3,
+	ChargeSucceeded:       3,
+	ChargeRefunded:        4,
+}
+
+// CanBecome reports whether a payment may move to status. A failed attempt can
+// still be retried by the payer, so failed ranks below authorized and succeeded.
+func (s ChargeStatus) CanBecome(status ChargeStatus) bool {
+	return chargeStatusRank[status] > chargeStatusRank[s]
+}
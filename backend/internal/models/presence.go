@@ -0,0 +1,32 @@
+// internal/models/presence.go
+// Live connection records and participant presence
+
+package models
+
+import "time"
+
+// Connection is a live websocket connection as recorded in websocket_connections
+type Connection struct {
+	ConnectionID            string    `json:"connection_id" bson:"connection_id"`
+	UserID                  string    `json:"user_id" bson:"user_id"`
+	TournamentSubscriptions []string  `json:"tournament_subscriptions" bson:"tournament_subscriptions"`
+	ConnectedAt             time.Time `json:"connected_at" bson:"connected_at"`
+	LastPing                time.Time `json:"last_ping" bson:"last_ping"`
+}
+
+// UserPresence summarizes a user's live connections
+type UserPresence struct {
+	UserID      string    `json:"user_id" bson:"_id"`
+	Connections int       `json:"connections" bson:"connections"`
+	LastSeen    time.Time `json:"last_seen" bson:"last_seen"`
+}
+
+// ParticipantPresence reports whether a tournament participant is online
+type ParticipantPresence struct {
+	ParticipantID string     `json:"participant_id"`
+	Name          string     `json:"name"`
+	UserID        *string    `json:"user_id,omitempty"`
+	Online        bool       `json:"online"`
+	Connections   int        `json:"connections"`
+	LastSeen      *time.Time `json:"last_seen,omitempty"`
+}
@@ -0,0 +1,185 @@
+// internal/models/pricing.go
+// Entry fee pricing tiers, discount codes and registration price quotes
+
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EntryPricing varies a tournament's entry fee by registration date and
+// membership. The tournament's EntryFee stays the standard non-member price.
+// Members are the accounts the organizer lists as TournamentMembers. Fees are
+// in the tournament's currency.
+type EntryPricing struct {
+	MemberFee *Money       `json:"member_fee,omitempty"`
+	EarlyBird *PriceWindow `json:"early_bird,omitempty"` // applies before its date
+	Late      *PriceWindow `json:"late,omitempty"`       // applies from its date on
+}
+
+// PriceWindow is a price that replaces the standard one before or after a date.
+// Without a member fee, members pay the window's fee too.
+type PriceWindow struct {
+	Date      time.Time `json:"date"`
+	Fee       Money     `json:"fee"`
+	MemberFee *Money    `json:"member_fee,omitempty"`
+}
+
+// PriceTier names the pricing tier a registration fell into
+type PriceTier string
+
+const (
+	PriceEarlyBird PriceTier = "early_bird"
+	PriceStandard  PriceTier = "standard"
+	PriceLate      PriceTier = "late"
+)
+
+// Price picks the fee for a registration made at a time. A nil pricing charges
+// the standard fee.
+func (p *EntryPricing) Price(standardFee Money, member bool, at time.Time) (Money, PriceTier) {
+	if p == nil {
+		return standardFee, PriceStandard
+	}
+
+	fee, memberFee, tier := standardFee, p.MemberFee, PriceStandard
+	switch {
+	case p.EarlyBird != nil && at.Before(p.EarlyBird.Date):
+		fee, memberFee, tier = p.EarlyBird.Fee, p.EarlyBird.MemberFee, PriceEarlyBird
+	case p.Late != nil && !at.Before(p.Late.Date):
+		fee, memberFee, tier = p.Late.Fee, p.Late.MemberFee, PriceLate
+	}
+	if member && memberFee != nil {
+		fee = *memberFee
+	}
+	return fee, tier
+}
+
+// Validate checks that fees are not negative and in the tournament's currency,
+// and that the early bird ends before the late fee starts. Fees given without
+// a currency take the tournament's.
+func (p *EntryPricing) Validate(currency string) error {
+	if p == nil {
+		return nil
+	}
+	fees := []*Money{p.MemberFee}
+	for _, window := range []*PriceWindow{p.EarlyBird, p.Late} {
+		if window != nil {
+			if window.Date.IsZero() {
+				return fmt.Errorf("pricing windows need a date")
+			}
+			fees = append(fees, &window.Fee, window.MemberFee)
+		}
+	}
+	for _, fee := range fees {
+		if fee == nil {
+			continue
+		}
+		if fee.IsNegative() {
+			return fmt.Errorf("fees cannot be negative")
+		}
+		if fee.Currency != "" && fee.Currency != currency {
+			return fmt.Errorf("fees must be in the tournament's currency, %s", currency)
+		}
+		fee.Currency = currency
+	}
+	if p.EarlyBird != nil && p.Late != nil && !p.EarlyBird.Date.Before(p.Late.Date) {
+		return fmt.Errorf("the early bird price must end before the late fee starts")
+	}
+	return nil
+}
+
+// Implement sql.Scanner and driver.Valuer for EntryPricing
+func (p *EntryPricing) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into EntryPricing", value)
+	}
+	return json.Unmarshal(bytes, p)
+}
+
+func (p EntryPricing) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// DiscountType is how a discount code reduces the price
+type DiscountType string
+
+const (
+	DiscountPercent DiscountType = "percent"
+	DiscountFixed   DiscountType = "fixed"
+)
+
+// TournamentMember is an account the organizer lists as a member of a
+// tournament. Members registering while signed in pay member prices.
+type TournamentMember struct {
+	TournamentID string    `json:"tournament_id" db:"tournament_id"`
+	UserID       string    `json:"user_id" db:"user_id"`
+	Email        string    `json:"email" db:"email"`
+	FullName     string    `json:"full_name" db:"full_name"`
+	AddedBy      string    `json:"added_by" db:"added_by"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// DiscountCode reduces the entry fee of a tournament. Value is a percentage for
+// percent codes and an amount in minor currency units for fixed codes. Codes are
+// stored upper case and matched case-insensitively.
+type DiscountCode struct {
+	ID           string       `json:"id" db:"id"`
+	TournamentID string       `json:"tournament_id" db:"tournament_id"`
+	Code         string       `json:"code" db:"code"`
+	Type         DiscountType `json:"type" db:"discount_type"`
+	Value        int64        `json:"value" db:"value"`
+	MaxUses      *int         `json:"max_uses,omitempty" db:"max_uses"`
+	UsedCount    int          `json:"used_count" db:"used_count"`
+	ExpiresAt    *time.Time   `json:"expires_at,omitempty" db:"expires_at"`
+	IsActive     bool         `json:"is_active" db:"is_active"`
+	CreatedBy    string       `json:"created_by" db:"created_by"`
+	CreatedAt    time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// Unavailable explains why a code can't be used at a time, or returns "" if it can
+func (d *DiscountCode) Unavailable(now time.Time) string {
+	switch {
+	case !d.IsActive:
+		return "discount code is no longer active"
+	case d.ExpiresAt != nil && !now.Before(*d.ExpiresAt):
+		return "discount code has expired"
+	case d.MaxUses != nil && d.UsedCount >= *d.MaxUses:
+		return "discount code has been used up"
+	}
+	return ""
+}
+
+// Discount returns how much the code takes off an amount, never more than the amount
+func (d *DiscountCode) Discount(amount Money) Money {
+	var discount Money
+	switch d.Type {
+	case DiscountPercent:
+		discount = amount.Percent(d.Value)
+	case DiscountFixed:
+		discount = NewMoney(d.Value, amount.Currency)
+	}
+	if discount.IsNegative() {
+		return NewMoney(0, amount.Currency)
+	}
+	return discount.Min(amount)
+}
+
+// PriceQuote is what a registration costs and how that was worked out. It is
+// stored with the registration so later price changes don't alter what is owed.
+type PriceQuote struct {
+	Tier           PriceTier `json:"tier"`
+	Member         bool      `json:"member"`
+	Base           Money     `json:"base_amount"`
+	DiscountCode   string    `json:"discount_code,omitempty"`
+	DiscountCodeID *string   `json:"-"`
+	Discount       Money     `json:"discount"`
+	AmountDue      Money     `json:"amount_due"`
+}
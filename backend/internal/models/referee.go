@@ -0,0 +1,18 @@
+// internal/models/referee.go
+// Referee model
+
+package models
+
+import "time"
+
+// Referee is an official an organizer can assign to matches
+type Referee struct {
+	ID                 string    `json:"id" db:"id"`
+	UserID             *string   `json:"user_id,omitempty" db:"user_id"`
+	OrganizerID        string    `json:"organizer_id" db:"organizer_id"`
+	Name               string    `json:"name" db:"name"`
+	CertificationLevel *string   `json:"certification_level,omitempty" db:"certification_level"`
+	ContactEmail       *string   `json:"contact_email,omitempty" db:"contact_email"`
+	ContactPhone       *string   `json:"contact_phone,omitempty" db:"contact_phone"`
+	CreatedAt          time.Time `json:"created_at" db:"created_at"`
+}
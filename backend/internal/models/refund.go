@@ -0,0 +1,176 @@
+// internal/models/refund.go
+// Refund policies for withdrawals and bulk refunds for cancelled tournaments
+
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// RefundPolicy sets how much of an entry fee comes back when a participant
+// withdraws or is removed. The tier with the most days before the start that
+// the withdrawal still meets sets the percentage; later withdrawals get nothing.
+// The processing fee is kept from every refund.
+type RefundPolicy struct {
+	Tiers         []RefundTier `json:"tiers"`
+	ProcessingFee Money        `json:"processing_fee"`
+}
+
+// RefundTier refunds a percentage of the fee to withdrawals made at least
+// DaysBefore days before the tournament starts
+type RefundTier struct {
+	DaysBefore int `json:"days_before"`
+	Percent    int `json:"percent"`
+}
+
+// RefundDecision is what a policy refunds of a payment
+type RefundDecision struct {
+	Paid          Money `json:"paid"`
+	Percent       int   `json:"percent"`
+	ProcessingFee Money `json:"processing_fee"`
+	Refund        Money `json:"refund"`
+}
+
+// Refund works out the refund of a paid amount for a withdrawal at a time.
+// Without a policy the full amount is refunded.
+func (p *RefundPolicy) Refund(paid Money, start, at time.Time) RefundDecision {
+	zero := NewMoney(0, paid.Currency)
+	decision := RefundDecision{Paid: paid, Percent: 100, ProcessingFee: zero, Refund: paid}
+	if p == nil {
+		return decision
+	}
+
+	decision.Percent = 0
+	decision.Refund = zero
+	daysBefore := start.Sub(at).Hours() / 24
+	for _, tier := range p.sortedTiers() {
+		if daysBefore >= float64(tier.DaysBefore) {
+			decision.Percent = tier.Percent
+			break
+		}
+	}
+	if decision.Percent == 0 {
+		return decision
+	}
+
+	refund := paid.Percent(int64(decision.Percent))
+	decision.ProcessingFee = refund.Min(p.ProcessingFee)
+	decision.Refund = refund.Sub(decision.ProcessingFee)
+	return decision
+}
+
+// sortedTiers returns the tiers from the most days before the start to the fewest
+func (p *RefundPolicy) sortedTiers() []RefundTier {
+	tiers := slices.Clone(p.Tiers)
+	slices.SortFunc(tiers, func(a, b RefundTier) int { return b.DaysBefore - a.DaysBefore })
+	return tiers
+}
+
+// Validate checks percentages and days, that earlier withdrawals never get back
+// less than later ones, and that the processing fee is in the tournament's
+// currency. A fee given without a currency takes the tournament's.
+func (p *RefundPolicy) Validate(currency string) error {
+	if p == nil {
+		return nil
+	}
+	if p.ProcessingFee.IsNegative() {
+		return fmt.Errorf("the processing fee cannot be negative")
+	}
+	if p.ProcessingFee.Currency != "" && p.ProcessingFee.Currency != currency {
+		return fmt.Errorf("the processing fee must be in %s", currency)
+	}
+	p.ProcessingFee.Currency = currency
+	tiers := p.sortedTiers()
+	for i, tier := range tiers {
+		if tier.DaysBefore < 0 || tier.Percent < 0 || tier.Percent > 100 {
+			return fmt.Errorf("tiers need zero or more days and a percentage from 0 to 100")
+		}
+		if i > 0 {
+			if tier.DaysBefore == tiers[i-1].DaysBefore {
+				return fmt.Errorf("two tiers start %d days before", tier.DaysBefore)
+			}
+			if tier.Percent > tiers[i-1].Percent {
+				return fmt.Errorf("later withdrawals cannot get back more than earlier ones")
+			}
+		}
+	}
+	return nil
+}
+
+// Implement sql.Scanner and driver.Valuer for RefundPolicy
+func (p *RefundPolicy) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into RefundPolicy", value)
+	}
+	return json.Unmarshal(bytes, p)
+}
+
+func (p RefundPolicy) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// RefundBatchKind is why a batch refunds
+type RefundBatchKind string
+
+const (
+	// RefundBatchCancellation refunds every settled payment of a cancelled tournament in full
+	RefundBatchCancellation RefundBatchKind = "cancellation"
+	// RefundBatchWithdrawal refunds a withdrawn participant under the refund policy
+	RefundBatchWithdrawal RefundBatchKind = "withdrawal"
+	// RefundBatchOverpayment refunds a payment from a participant who had already paid
+	RefundBatchOverpayment RefundBatchKind = "overpayment"
+)
+
+// RefundBatch is a set of refunds queued with the change that causes them.
+// Its items are worked off in the background. Overpayment batches are queued
+// by the system and have no creator.
+type RefundBatch struct {
+	ID           string          `json:"id" db:"id"`
+	TournamentID string          `json:"tournament_id" db:"tournament_id"`
+	Kind         RefundBatchKind `json:"kind" db:"kind"`
+	Reason       string          `json:"reason" db:"reason"`
+	CreatedBy    *string         `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+	CompletedAt  *time.Time      `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// RefundItemStatus tracks one refund of a batch
+type RefundItemStatus string
+
+const (
+	RefundItemPending   RefundItemStatus = "pending"
+	RefundItemRequested RefundItemStatus = "requested"
+	RefundItemFailed    RefundItemStatus = "failed"
+)
+
+// RefundBatchItem is one payment a batch refunds
+type RefundBatchItem struct {
+	ID            string           `json:"id" db:"id"`
+	BatchID       string           `json:"batch_id" db:"batch_id"`
+	ParticipantID string           `json:"participant_id" db:"participant_id"`
+	PaymentID     string           `json:"payment_id" db:"payment_id"`
+	Kind          RefundBatchKind  `json:"-" db:"kind"`
+	Amount        Money            `json:"amount" db:"amount_cents"`
+	Status        RefundItemStatus `json:"status" db:"status"`
+	Attempts      int              `json:"attempts" db:"attempts"`
+	LastError     *string          `json:"last_error,omitempty" db:"last_error"`
+	UpdatedAt     time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// RefundBatchProgress counts a batch's items by status
+type RefundBatchProgress struct {
+	Batch     *RefundBatch `json:"batch"`
+	Total     int          `json:"total"`
+	Pending   int          `json:"pending"`
+	Requested int          `json:"requested"`
+	Failed    int          `json:"failed"`
+	Amount    Money        `json:"amount"`
+}
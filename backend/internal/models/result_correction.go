@@ -0,0 +1,74 @@
+// internal/models/result_correction.go
+// Audit records for organizer corrections of reported match results
+
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ResultCorrection records one organizer correction of a reported result
+// along with every downstream match it touched
+type ResultCorrection struct {
+	ID               string            `json:"id" db:"id"`
+	MatchID          string            `json:"match_id" db:"match_id"`
+	TournamentID     string            `json:"tournament_id" db:"tournament_id"`
+	CorrectedBy      string            `json:"corrected_by" db:"corrected_by"`
+	Reason           string            `json:"reason" db:"reason"`
+	PreviousScore1   *int              `json:"previous_score1,omitempty" db:"previous_score1"`
+	PreviousScore2   *int              `json:"previous_score2,omitempty" db:"previous_score2"`
+	PreviousWinnerID *string           `json:"previous_winner_id,omitempty" db:"previous_winner_id"`
+	NewScore1        int               `json:"new_score1" db:"new_score1"`
+	NewScore2        int               `json:"new_score2" db:"new_score2"`
+	NewWinnerID      string            `json:"new_winner_id" db:"new_winner_id"`
+	AffectedMatches  CorrectionEffects `json:"affected_matches" db:"affected_matches"`
+	CreatedAt        time.Time         `json:"created_at" db:"created_at"`
+}
+
+// CorrectionAction describes what a correction did to a downstream match
+type CorrectionAction string
+
+const (
+	// CorrectionParticipantReplaced means the wrongly advanced participant was swapped for the corrected winner
+	CorrectionParticipantReplaced CorrectionAction = "participant_replaced"
+	// CorrectionParticipantRemoved means a participant who only advanced through a voided result was taken out
+	CorrectionParticipantRemoved CorrectionAction = "participant_removed"
+	// CorrectionResultVoided means a result played with the wrong participants was cleared and needs replaying
+	CorrectionResultVoided CorrectionAction = "result_voided"
+)
+
+// CorrectionEffect is one change made to a downstream match during a correction
+type CorrectionEffect struct {
+	MatchID          string           `json:"match_id"`
+	Action           CorrectionAction `json:"action"`
+	PreviousID       *string          `json:"previous_participant_id,omitempty"`
+	NewID            *string          `json:"new_participant_id,omitempty"`
+	PreviousScore1   *int             `json:"previous_score1,omitempty"`
+	PreviousScore2   *int             `json:"previous_score2,omitempty"`
+	PreviousWinnerID *string          `json:"previous_winner_id,omitempty"`
+}
+
+// CorrectionEffects is stored as a JSON column
+type CorrectionEffects []CorrectionEffect
+
+// Implement sql.Scanner and driver.Valuer for CorrectionEffects
+func (c *CorrectionEffects) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into CorrectionEffects", value)
+	}
+	return json.Unmarshal(bytes, c)
+}
+
+func (c CorrectionEffects) Value() (driver.Value, error) {
+	if c == nil {
+		return json.Marshal([]CorrectionEffect{})
+	}
+	return json.Marshal(c)
+}
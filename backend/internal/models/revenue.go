@@ -0,0 +1,60 @@
+// internal/models/revenue.go
+// Organizer revenue reports built from the payment ledger
+
+package models
+
+import "time"
+
+// RevenueMethod is how the money of a ledger entry changed hands
+type RevenueMethod string
+
+const (
+	RevenueOnline RevenueMethod = "online"
+	RevenueCash   RevenueMethod = "cash"
+	RevenueCard   RevenueMethod = "card"
+	RevenueManual RevenueMethod = "manual" // fees and adjustments recorded by hand
+)
+
+// PriceWithdrawn groups the revenue of participants who have since withdrawn,
+// whose pricing tier is no longer known
+const PriceWithdrawn PriceTier = "withdrawn"
+
+// RevenueEntry is the ledger total of one entry type for a tournament, pricing
+// tier and payment method
+type RevenueEntry struct {
+	TournamentID   string
+	TournamentName string
+	PriceTier      PriceTier
+	Method         RevenueMethod
+	Type           LedgerEntryType
+	Amount         Money
+}
+
+// RevenueGroup is the revenue of one tournament, pricing tier or payment
+// method. Charged is the gross, Fees the processing fees and Net what the
+// organizer keeps.
+type RevenueGroup struct {
+	Key  string `json:"key"`
+	Name string `json:"name,omitempty"`
+	LedgerBalance
+}
+
+// RevenueSummary is the revenue in one currency with its breakdowns.
+// ByTournament is only filled in organizer reports.
+type RevenueSummary struct {
+	Currency     string          `json:"currency"`
+	Totals       LedgerBalance   `json:"totals"`
+	ByTournament []*RevenueGroup `json:"by_tournament,omitempty"`
+	ByTier       []*RevenueGroup `json:"by_price_tier"`
+	ByMethod     []*RevenueGroup `json:"by_payment_method"`
+}
+
+// RevenueReport is what an organizer has earned from entry fees, over one
+// tournament or all of theirs. Amounts in different currencies are never
+// added up, so there is a summary per currency.
+type RevenueReport struct {
+	OrganizerID  string            `json:"organizer_id"`
+	TournamentID string            `json:"tournament_id,omitempty"`
+	Currencies   []*RevenueSummary `json:"currencies"`
+	GeneratedAt  time.Time         `json:"generated_at"`
+}
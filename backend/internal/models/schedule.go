@@ -0,0 +1,31 @@
+// internal/models/schedule.go
+// Schedule views grouping matches by local day and venue
+
+package models
+
+// Schedule is a tournament's matches grouped by local date and then by venue.
+// Participant and Venue are set when the schedule is filtered to one of them.
+type Schedule struct {
+	TournamentID   string         `json:"tournament_id"`
+	TournamentName string         `json:"tournament_name"`
+	Timezone       string         `json:"timezone"`
+	MatchDuration  int            `json:"match_duration"`
+	Participant    *Participant   `json:"participant,omitempty"`
+	Venue          *Venue         `json:"venue,omitempty"`
+	Days           []*ScheduleDay `json:"days"`
+	Unscheduled    []*Match       `json:"unscheduled"`
+}
+
+// ScheduleDay holds the matches played on one date in the tournament's timezone
+type ScheduleDay struct {
+	Date   string           `json:"date"`
+	Venues []*ScheduleVenue `json:"venues"`
+}
+
+// ScheduleVenue holds one venue's matches for a day, ordered by start time.
+// VenueID is nil for matches that have a time but no venue yet.
+type ScheduleVenue struct {
+	VenueID   *string  `json:"venue_id,omitempty"`
+	VenueName string   `json:"venue_name"`
+	Matches   []*Match `json:"matches"`
+}
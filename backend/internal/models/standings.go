@@ -0,0 +1,73 @@
+// internal/models/standings.go
+// League table and tiebreaker related models
+
+package models
+
+// Standing represents a single participant's row in a league table
+type Standing struct {
+	Position          int    `json:"position"`
+	ParticipantID     string `json:"participant_id"`
+	ParticipantName   string `json:"participant_name"`
+	Seed              *int   `json:"seed,omitempty"`
+	Played            int    `json:"played"`
+	Won               int    `json:"won"`
+	Drawn             int    `json:"drawn"`
+	Lost              int    `json:"lost"`
+	Points            int    `json:"points"`
+	ScoreFor          int    `json:"score_for"`
+	ScoreAgainst      int    `json:"score_against"`
+	ScoreDifference   int    `json:"score_difference"`
+	SetsWon           int    `json:"sets_won"`
+	SetsLost          int    `json:"sets_lost"`
+	TiebreakerApplied string `json:"tiebreaker_applied,omitempty"`
+}
+
+// StandingsTable is the ordered league table for one group or division
+type StandingsTable struct {
+	Group       string       `json:"group,omitempty"`
+	Division    string       `json:"division,omitempty"`
+	Tiebreakers []Tiebreaker `json:"tiebreakers"`
+	Standings   []*Standing  `json:"standings"`
+}
+
+// Tiebreaker identifies a rule used to order participants level on points
+type Tiebreaker string
+
+const (
+	TiebreakerHeadToHead      Tiebreaker = "head_to_head"
+	TiebreakerMiniLeague      Tiebreaker = "mini_league"
+	TiebreakerScoreDifference Tiebreaker = "score_difference"
+	TiebreakerScoreFor        Tiebreaker = "score_for"
+	TiebreakerSetsRatio       Tiebreaker = "sets_ratio"
+	TiebreakerSeed            Tiebreaker = "seed"
+	TiebreakerCoinToss        Tiebreaker = "coin_toss"
+)
+
+// DefaultTiebreakers is applied when a tournament doesn't configure its own order
+var DefaultTiebreakers = []Tiebreaker{
+	TiebreakerHeadToHead,
+	TiebreakerMiniLeague,
+	TiebreakerScoreDifference,
+	TiebreakerSetsRatio,
+	TiebreakerSeed,
+}
+
+// IsValid reports whether the tiebreaker is one the standings engine knows
+func (t Tiebreaker) IsValid() bool {
+	switch t {
+	case TiebreakerHeadToHead, TiebreakerMiniLeague, TiebreakerScoreDifference,
+		TiebreakerScoreFor, TiebreakerSetsRatio, TiebreakerSeed, TiebreakerCoinToss:
+		return true
+	}
+	return false
+}
+
+// PointsSystem defines league points awarded per result
+type PointsSystem struct {
+	Win  int `json:"win"`
+	Draw int `json:"draw"`
+	Loss int `json:"loss"`
+}
+
+// DefaultPointsSystem is the common 3-1-0 scheme
+var DefaultPointsSystem = PointsSystem{Win: 3, Draw: 1, Loss: 0}
@@ -12,30 +12,35 @@ import (
 
 // Tournament represents a tournament with all its configuration
 type Tournament struct {
-	ID                   string           `json:"id" db:"id"`
-	OrganizerID          string           `json:"organizer_id" db:"organizer_id"`
-	Name                 string           `json:"name" db:"name"`
-	Description          string           `json:"description" db:"description"`
-	SportID              *string          `json:"sport_id,omitempty" db:"sport_id"`
-	FormatType           TournamentFormat `json:"format_type" db:"format_type"`
-	FormatConfig         *FormatConfig    `json:"format_config,omitempty" db:"format_config"`
-	StartDate            time.Time        `json:"start_date" db:"start_date"`
-	EndDate              time.Time        `json:"end_date" db:"end_date"`
-	Timezone             string           `json:"timezone" db:"timezone"`
-	MaxMatchesPerDay     int              `json:"max_matches_per_day" db:"max_matches_per_day"`
-	OperationalHours     OperationalHours `json:"operational_hours" db:"operational_hours"`
-	AvgMatchDuration     int              `json:"avg_match_duration" db:"avg_match_duration"`
-	BufferTime           int              `json:"buffer_time" db:"buffer_time"`
-	RegistrationDeadline *time.Time       `json:"registration_deadline,omitempty" db:"registration_deadline"`
-	EntryFee             float64          `json:"entry_fee" db:"entry_fee"`
-	AllowOnsitePayment   bool             `json:"allow_onsite_payment" db:"allow_onsite_payment"`
-	CapacityLimit        int              `json:"capacity_limit" db:"capacity_limit"`
-	CurrentParticipants  int              `json:"current_participants" db:"current_participants"`
-	Status               TournamentStatus `json:"status" db:"status"`
-	IsPublic             bool             `json:"is_public" db:"is_public"`
-	CustomFields         []CustomField    `json:"custom_fields,omitempty" db:"custom_fields"`
-	CreatedAt            time.Time        `json:"created_at" db:"created_at"`
-	UpdatedAt            time.Time        `json:"updated_at" db:"updated_at"`
+	ID                    string           `json:"id" db:"id"`
+	OrganizerID           string           `json:"organizer_id" db:"organizer_id"`
+	Name                  string           `json:"name" db:"name"`
+	Description           string           `json:"description" db:"description"`
+	SportID               *string          `json:"sport_id,omitempty" db:"sport_id"`
+	FormatType            TournamentFormat `json:"format_type" db:"format_type"`
+	FormatConfig          *FormatConfig    `json:"format_config,omitempty" db:"format_config"`
+	StartDate             time.Time        `json:"start_date" db:"start_date"`
+	EndDate               time.Time        `json:"end_date" db:"end_date"`
+	Timezone              string           `json:"timezone" db:"timezone"`
+	MaxMatchesPerDay      int              `json:"max_matches_per_day" db:"max_matches_per_day"`
+	OperationalHours      OperationalHours `json:"operational_hours" db:"operational_hours"`
+	AvgMatchDuration      int              `json:"avg_match_duration" db:"avg_match_duration"`
+	BufferTime            int              `json:"buffer_time" db:"buffer_time"`
+	RegistrationDeadline  *time.Time       `json:"registration_deadline,omitempty" db:"registration_deadline"`
+	Currency              string           `json:"currency" db:"currency"`
+	EntryFee              Money            `json:"entry_fee" db:"entry_fee_cents"`
+	AllowOnsitePayment    bool             `json:"allow_onsite_payment" db:"allow_onsite_payment"`
+	RequirePaidCheckIn    bool             `json:"require_paid_checkin" db:"require_paid_checkin"`
+	CaptureEntryFeesLater bool             `json:"capture_entry_fees_later" db:"capture_entry_fees_later"`
+	Pricing               *EntryPricing    `json:"pricing,omitempty" db:"pricing"`
+	RefundPolicy          *RefundPolicy    `json:"refund_policy,omitempty" db:"refund_policy"`
+	CapacityLimit         int              `json:"capacity_limit" db:"capacity_limit"`
+	CurrentParticipants   int              `json:"current_participants" db:"current_participants"`
+	Status                TournamentStatus `json:"status" db:"status"`
+	IsPublic              bool             `json:"is_public" db:"is_public"`
+	CustomFields          []CustomField    `json:"custom_fields,omitempty" db:"custom_fields"`
+	CreatedAt             time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt             time.Time        `json:"updated_at" db:"updated_at"`
 }
 
 // TournamentFormat represents different tournament formats
@@ -70,6 +75,10 @@ type FormatConfig struct {
 	Consolation     bool   `json:"consolation,omitempty"`
 	ThirdPlaceMatch bool   `json:"third_place_match,omitempty"`
 	NumberOfRounds  int    `json:"number_of_rounds,omitempty"`
+
+	// League table configuration for round robin and group stages
+	Points      *PointsSystem `json:"points,omitempty"`
+	Tiebreakers []Tiebreaker  `json:"tiebreakers,omitempty"`
 }
 
 // OperationalHours defines when the tournament can run each day
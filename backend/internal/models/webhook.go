@@ -0,0 +1,78 @@
+// internal/models/webhook.go
+// Organizer webhooks and their delivery log
+
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Webhook is an endpoint an organizer registered to receive a tournament's events.
+// The secret signs every delivery and is only shown when the webhook is created.
+type Webhook struct {
+	ID           string            `json:"id" db:"id"`
+	TournamentID string            `json:"tournament_id" db:"tournament_id"`
+	URL          string            `json:"url" db:"url"`
+	Secret       string            `json:"-" db:"secret"`
+	EventTypes   WebhookEventTypes `json:"event_types" db:"event_types"`
+	IsActive     bool              `json:"is_active" db:"is_active"`
+	CreatedBy    string            `json:"created_by" db:"created_by"`
+	CreatedAt    time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// WebhookEventTypes filters the events a webhook receives. Empty means all events.
+type WebhookEventTypes []string
+
+// Implement sql.Scanner and driver.Valuer for WebhookEventTypes
+func (w *WebhookEventTypes) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into WebhookEventTypes", value)
+	}
+	return json.Unmarshal(bytes, w)
+}
+
+func (w WebhookEventTypes) Value() (driver.Value, error) {
+	if w == nil {
+		return json.Marshal([]string{})
+	}
+	return json.Marshal(w)
+}
+
+// Matches reports whether an event type passes the filter
+func (w WebhookEventTypes) Matches(eventType string) bool {
+	if len(w) == 0 {
+		return true
+	}
+	for _, t := range w {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one event sent, or to be sent, to a webhook. The payload is
+// stored exactly as it is signed, so every retry sends the same bytes and event ID.
+type WebhookDelivery struct {
+	ID             string          `json:"id" db:"id"`
+	WebhookID      string          `json:"webhook_id" db:"webhook_id"`
+	EventID        string          `json:"event_id" db:"event_id"`
+	EventType      string          `json:"event_type" db:"event_type"`
+	Payload        json.RawMessage `json:"payload" db:"payload"`
+	Status         OutboxStatus    `json:"status" db:"status"`
+	Attempts       int             `json:"attempts" db:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at" db:"next_attempt_at"`
+	ResponseStatus *int            `json:"response_status,omitempty" db:"response_status"`
+	DurationMs     *int            `json:"duration_ms,omitempty" db:"duration_ms"`
+	LastError      *string         `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty" db:"delivered_at"`
+}
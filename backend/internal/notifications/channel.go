@@ -0,0 +1,104 @@
+// internal/notifications/channel.go
+// Notification channels, messages and the router that delivers them
+
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Channel identifies how a notification reaches its recipient
+type Channel string
+
+const (
+	ChannelEmail Channel = "email"
+	ChannelSMS   Channel = "sms"
+	ChannelPush  Channel = "push"
+	ChannelInApp Channel = "in_app"
+)
+
+// ErrNoSender is returned when no sender is registered for a channel
+var ErrNoSender = errors.New("no sender registered for channel")
+
+// Recipient is a resolved notification target
+type Recipient struct {
+	UserID        string `json:"user_id,omitempty"`
+	ParticipantID string `json:"participant_id,omitempty"`
+	Name          string `json:"name"`
+	Email         string `json:"email,omitempty"`
+	Phone         string `json:"phone,omitempty"`
+	Locale        string `json:"locale"`
+}
+
+// Message is a rendered notification for one recipient on one channel.
+// Only email carries attachments.
+type Message struct {
+	Channel     Channel      `json:"channel"`
+	Template    string       `json:"template"`
+	To          Recipient    `json:"to"`
+	Subject     string       `json:"subject"`
+	Body        string       `json:"body"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+// Attachment is a file sent along with an email
+type Attachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Data        []byte `json:"-"`
+}
+
+// Sender delivers messages on a channel
+type Sender interface {
+	Send(ctx context.Context, message *Message) error
+}
+
+// Router delivers each message through the sender registered for its channel
+type Router struct {
+	senders map[Channel]Sender
+	mu      sync.RWMutex
+}
+
+// NewRouter creates a router with no senders
+func NewRouter() *Router {
+	return &Router{senders: make(map[Channel]Sender)}
+}
+
+// Register sets the sender for a channel, replacing any previous one
+func (r *Router) Register(channel Channel, sender Sender) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.senders[channel] = sender
+}
+
+// Has reports whether a channel can be delivered
+func (r *Router) Has(channel Channel) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.senders[channel]
+	return ok
+}
+
+// Send delivers a message through its channel's sender
+func (r *Router) Send(ctx context.Context, message *Message) error {
+	r.mu.RLock()
+	sender, ok := r.senders[message.Channel]
+	r.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNoSender, message.Channel)
+	}
+	return sender.Send(ctx, message)
+}
+
+// SenderFunc adapts a function to the Sender interface
+type SenderFunc func(ctx context.Context, message *Message) error
+
+// Send calls f(ctx, message)
+func (f SenderFunc) Send(ctx context.Context, message *Message) error {
+	return f(ctx, message)
+}
@@ -0,0 +1,198 @@
+// internal/notifications/preferences.go
+// Per-user notification preferences: channel toggles, quiet hours and daily digest
+
+package notifications
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TimeOfDay is a wall-clock time as minutes after midnight
+type TimeOfDay int
+
+// ParseTimeOfDay parses a 24-hour "HH:MM" time
+func ParseTimeOfDay(value string) (TimeOfDay, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", value)
+	}
+	return TimeOfDay(t.Hour()*60 + t.Minute()), nil
+}
+
+// String formats the time as "HH:MM"
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", int(t)/60, int(t)%60)
+}
+
+// QuietHours is a daily window in which only in-app notifications are delivered.
+// The window may span midnight, e.g. 22:00 to 07:00.
+type QuietHours struct {
+	Start TimeOfDay
+	End   TimeOfDay
+}
+
+// contains reports whether a time of day falls inside the window
+func (q QuietHours) contains(t TimeOfDay) bool {
+	if q.Start <= q.End {
+		return t >= q.Start && t < q.End
+	}
+	return t >= q.Start || t < q.End
+}
+
+// Preferences are a user's notification settings. They are read from the
+// "notifications" section and the "timezone" of the stored user preferences:
+//
+//	"timezone": "Europe/Madrid",
+//	"notifications": {
+//	    "email": true, "push": true, "sms": false, "in_app": true,
+//	    "quiet_hours": {"start": "22:00", "end": "07:00"},
+//	    "digest": {"enabled": true, "time": "18:00"}
+//	}
+type Preferences struct {
+	Channels   map[Channel]bool
+	Location   *time.Location
+	QuietHours *QuietHours
+	Digest     bool
+	DigestAt   TimeOfDay
+}
+
+// DefaultPreferences are used for recipients without an account or stored preferences
+func DefaultPreferences() Preferences {
+	return Preferences{
+		Channels: map[Channel]bool{
+			ChannelEmail: true,
+			ChannelPush:  true,
+			ChannelSMS:   false,
+			ChannelInApp: true,
+		},
+		Location: time.UTC,
+		DigestAt: 18 * 60,
+	}
+}
+
+// ParsePreferences reads notification settings from a stored preferences document.
+// Missing settings keep their defaults. Malformed settings also keep their defaults
+// and are reported together in the returned error, so callers delivering a
+// notification can use the result regardless while callers saving preferences
+// can reject them.
+func ParsePreferences(doc map[string]interface{}) (Preferences, error) {
+	prefs := DefaultPreferences()
+	var errs []error
+
+	if value, ok := doc["timezone"]; ok && value != nil {
+		name, _ := value.(string)
+		if location, err := time.LoadLocation(name); err == nil && name != "" {
+			prefs.Location = location
+		} else {
+			errs = append(errs, fmt.Errorf("unknown timezone %v", value))
+		}
+	}
+
+	section, ok := doc["notifications"].(map[string]interface{})
+	if !ok {
+		if doc["notifications"] != nil {
+			errs = append(errs, errors.New("notifications must be an object"))
+		}
+		return prefs, errors.Join(errs...)
+	}
+
+	for _, channel := range []Channel{ChannelEmail, ChannelPush, ChannelSMS, ChannelInApp} {
+		value, ok := section[string(channel)]
+		if !ok || value == nil {
+			continue
+		}
+		if enabled, ok := value.(bool); ok {
+			prefs.Channels[channel] = enabled
+		} else {
+			errs = append(errs, fmt.Errorf("notifications.%s must be true or false", channel))
+		}
+	}
+
+	if value := section["quiet_hours"]; value != nil {
+		window, ok := value.(map[string]interface{})
+		if !ok {
+			errs = append(errs, errors.New("notifications.quiet_hours must be an object with start and end"))
+		} else {
+			start, startErr := timeOfDayField(window, "start")
+			end, endErr := timeOfDayField(window, "end")
+			if startErr != nil || endErr != nil {
+				errs = append(errs, fmt.Errorf("notifications.quiet_hours: %w", errors.Join(startErr, endErr)))
+			} else {
+				prefs.QuietHours = &QuietHours{Start: start, End: end}
+			}
+		}
+	}
+
+	if value := section["digest"]; value != nil {
+		digest, ok := value.(map[string]interface{})
+		if !ok {
+			errs = append(errs, errors.New("notifications.digest must be an object"))
+		} else {
+			if enabled, ok := digest["enabled"].(bool); ok {
+				prefs.Digest = enabled
+			} else if digest["enabled"] != nil {
+				errs = append(errs, errors.New("notifications.digest.enabled must be true or false"))
+			}
+			if digest["time"] != nil {
+				if at, err := timeOfDayField(digest, "time"); err == nil {
+					prefs.DigestAt = at
+				} else {
+					errs = append(errs, fmt.Errorf("notifications.digest: %w", err))
+				}
+			}
+		}
+	}
+
+	return prefs, errors.Join(errs...)
+}
+
+// timeOfDayField reads an "HH:MM" string field
+func timeOfDayField(doc map[string]interface{}, field string) (TimeOfDay, error) {
+	value, ok := doc[field].(string)
+	if !ok {
+		return 0, fmt.Errorf("%s must be an HH:MM string", field)
+	}
+	return ParseTimeOfDay(value)
+}
+
+// Allows reports whether the user wants notifications on a channel
+func (p Preferences) Allows(channel Channel) bool {
+	return p.Channels[channel]
+}
+
+// DeliverAt returns the earliest time a message on a channel may be delivered.
+// In-app messages are never held. Others wait for the next digest when the
+// message can be batched and the user has a digest, and are moved past the
+// user's quiet hours.
+func (p Preferences) DeliverAt(now time.Time, channel Channel, digestible bool) time.Time {
+	if channel == ChannelInApp {
+		return now
+	}
+
+	at := now
+	if digestible && p.Digest {
+		at = p.next(now, p.DigestAt)
+	}
+	if p.QuietHours != nil && p.QuietHours.contains(p.timeOfDay(at)) {
+		at = p.next(at, p.QuietHours.End)
+	}
+	return at
+}
+
+// timeOfDay returns the user's wall-clock time at an instant
+func (p Preferences) timeOfDay(t time.Time) TimeOfDay {
+	local := t.In(p.Location)
+	return TimeOfDay(local.Hour()*60 + local.Minute())
+}
+
+// next returns the first instant at or after t when the user's clock reads at
+func (p Preferences) next(t time.Time, at TimeOfDay) time.Time {
+	local := t.In(p.Location)
+	candidate := time.Date(local.Year(), local.Month(), local.Day(), int(at)/60, int(at)%60, 0, 0, p.Location)
+	if candidate.Before(local) {
+		candidate = time.Date(local.Year(), local.Month(), local.Day()+1, int(at)/60, int(at)%60, 0, 0, p.Location)
+	}
+	return candidate
+}
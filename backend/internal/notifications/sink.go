@@ -0,0 +1,75 @@
+// internal/notifications/sink.go
+// File and in-memory senders for local development and tests
+
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// FileSink appends every message to a file as a JSON line instead of delivering it
+type FileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileSink creates a sink writing to the given path
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+// Send appends the message to the file
+func (s *FileSink) Send(ctx context.Context, message *Message) error {
+	line, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// MemorySink keeps every message in memory so tests can inspect them
+type MemorySink struct {
+	messages []*Message
+	mu       sync.Mutex
+}
+
+// NewMemorySink creates an empty sink
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+// Send records the message
+func (s *MemorySink) Send(ctx context.Context, message *Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, message)
+	return nil
+}
+
+// Messages returns a copy of the recorded messages in send order
+func (s *MemorySink) Messages() []*Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Message(nil), s.messages...)
+}
+
+// Reset discards the recorded messages
+func (s *MemorySink) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = nil
+}
@@ -0,0 +1,174 @@
+// internal/notifications/smtp.go
+// Email delivery over SMTP
+
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+
+	"tournament-planner/internal/utils"
+)
+
+// smtpTimeout bounds a whole SMTP exchange when the caller's context has no earlier deadline
+const smtpTimeout = 30 * time.Second
+
+// SMTPSender delivers email notifications through an SMTP server.
+// STARTTLS is used whenever the server offers it.
+type SMTPSender struct {
+	host string
+	addr string
+	auth smtp.Auth
+	from mail.Address
+}
+
+// NewSMTPSender creates an SMTP sender; credentials are optional
+func NewSMTPSender(host string, port int, username, password, fromAddress, fromName string) *SMTPSender {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPSender{
+		host: host,
+		addr: host + ":" + strconv.Itoa(port),
+		auth: auth,
+		from: mail.Address{Name: fromName, Address: fromAddress},
+	}
+}
+
+// Send delivers a plain text email, with any attachments, to the recipient's address
+func (s *SMTPSender) Send(ctx context.Context, message *Message) error {
+	if message.To.Email == "" {
+		return fmt.Errorf("recipient %q has no email address", message.To.Name)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	to := mail.Address{Name: message.To.Name, Address: message.To.Email}
+	body := s.compose(to, message)
+
+	return s.sendMail(ctx, to.Address, body)
+}
+
+// sendMail runs the SMTP exchange like smtp.SendMail, but dials with the context
+// and holds the connection to its deadline so a hung server cannot stall the sender
+func (s *SMTPSender) sendMail(ctx context.Context, to string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, smtpTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", s.addr, err)
+	}
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+
+	// Cancelling the context aborts whichever read or write is in progress
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if s.auth != nil {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return fmt.Errorf("smtp server %s does not support AUTH", s.addr)
+		}
+		if err := client.Auth(s.auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(s.from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// compose builds the RFC 5322 message. Messages with attachments are sent as
+// multipart/mixed with the text body first.
+func (s *SMTPSender) compose(to mail.Address, message *Message) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", s.from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", to.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: <%s@tournament-planner>\r\n", utils.GenerateUUID())
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	if len(message.Attachments) == 0 {
+		buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+		buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+		buf.WriteString("\r\n")
+		buf.WriteString(message.Body)
+		return buf.Bytes()
+	}
+
+	parts := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\n", parts.Boundary())
+	buf.WriteString("\r\n")
+
+	text, _ := parts.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=UTF-8"},
+		"Content-Transfer-Encoding": {"8bit"},
+	})
+	io.WriteString(text, message.Body)
+
+	for _, attachment := range message.Attachments {
+		part, _ := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(attachment.ContentType, map[string]string{"name": attachment.Filename})},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		writeBase64Lines(part, attachment.Data)
+	}
+	parts.Close()
+
+	return buf.Bytes()
+}
+
+// writeBase64Lines writes data base64 encoded in lines of 76 characters, as MIME requires
+func writeBase64Lines(w io.Writer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		io.WriteString(w, encoded[:76]+"\r\n")
+		encoded = encoded[76:]
+	}
+	io.WriteString(w, encoded+"\r\n")
+}
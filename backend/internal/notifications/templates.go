@@ -0,0 +1,310 @@
+// internal/notifications/templates.go
+// Locale-aware notification templates
+
+package notifications
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Template keys
+const (
+	TemplateTournamentPublished   = "tournament_published"
+	TemplateFixturesGenerated     = "fixtures_generated"
+	TemplateMatchScheduled        = "match_scheduled"
+	TemplateMatchResult           = "match_result"
+	TemplateMatchReminder         = "match_reminder"
+	TemplateReminderCorrection    = "match_reminder_correction"
+	TemplateParticipantRegistered = "participant_registered"
+	TemplatePaymentReceipt        = "payment_receipt"
+	TemplateDigest                = "digest"
+)
+
+// TemplateData holds the values a template may refer to
+type TemplateData struct {
+	RecipientName  string
+	TournamentName string
+	TournamentURL  string
+	StartDate      string
+	Round          int
+	Opponent       string
+	ScheduledAt    string
+	PreviousAt     string
+	Participants   string
+	Venue          string
+	Score          string
+	Winner         string
+	Won            bool
+	Amount         string
+	InvoiceNumber  string
+	Items          []string
+}
+
+// templateSource is the subject and body of one template in one locale.
+// Templates that may be batched into a digest also have a one-line summary.
+type templateSource struct {
+	subject string
+	body    string
+	summary string
+}
+
+// templateSources maps locale to template key to source
+var templateSources = map[string]map[string]templateSource{
+	"en": {
+		TemplateTournamentPublished: {
+			subject: "{{.TournamentName}} is open for registration",
+			body: "Hi {{.RecipientName}},\n\n{{.TournamentName}} has been published" +
+				"{{if .StartDate}} and starts on {{.StartDate}}{{end}}.\n\nDetails: {{.TournamentURL}}\n",
+		},
+		TemplateFixturesGenerated: {
+			subject: "Fixtures are out for {{.TournamentName}}",
+			body:    "Hi {{.RecipientName}},\n\nThe fixtures for {{.TournamentName}} have been generated.\n\nSee your matches: {{.TournamentURL}}\n",
+		},
+		TemplateMatchScheduled: {
+			subject: "Match scheduled: {{.TournamentName}}, round {{.Round}}",
+			body: "Hi {{.RecipientName}},\n\nYour round {{.Round}} match{{if .Opponent}} against {{.Opponent}}{{end}}" +
+				" is scheduled for {{.ScheduledAt}}{{if .Venue}} at {{.Venue}}{{end}}.\n\nDetails: {{.TournamentURL}}\n",
+		},
+		TemplateMatchResult: {
+			subject: "Result: {{.TournamentName}}, round {{.Round}}",
+			body: "Hi {{.RecipientName}},\n\nYour round {{.Round}} match{{if .Opponent}} against {{.Opponent}}{{end}} finished {{.Score}}.\n\n" +
+				"{{if .Won}}Congratulations on the win!{{else if .Winner}}Winner: {{.Winner}}.{{end}}\n\nDetails: {{.TournamentURL}}\n",
+			summary: "{{.TournamentName}}, round {{.Round}}{{if .Opponent}} against {{.Opponent}}{{end}}: {{.Score}}" +
+				"{{if .Won}}, won{{else if .Winner}}, winner {{.Winner}}{{end}}",
+		},
+		TemplateMatchReminder: {
+			subject: "Reminder: {{.TournamentName}}, round {{.Round}} on {{.ScheduledAt}}",
+			body: "Hi {{.RecipientName}},\n\nA reminder that your round {{.Round}} match" +
+				"{{if .Opponent}} against {{.Opponent}}{{else if .Participants}} ({{.Participants}}){{end}}" +
+				" is scheduled for {{.ScheduledAt}}{{if .Venue}} at {{.Venue}}{{end}}.\n\nDetails: {{.TournamentURL}}\n",
+		},
+		TemplateReminderCorrection: {
+			subject: "Schedule change: {{.TournamentName}}, round {{.Round}}",
+			body: "Hi {{.RecipientName}},\n\nYour round {{.Round}} match" +
+				"{{if .Opponent}} against {{.Opponent}}{{else if .Participants}} ({{.Participants}}){{end}}" +
+				" is no longer scheduled for {{.PreviousAt}}. " +
+				"{{if .ScheduledAt}}It is now scheduled for {{.ScheduledAt}}{{if .Venue}} at {{.Venue}}{{end}}.{{else}}A new time has not been set yet.{{end}}" +
+				"\n\nPlease disregard the earlier reminder.\n\nDetails: {{.TournamentURL}}\n",
+		},
+		TemplateParticipantRegistered: {
+			subject: "New registration: {{.TournamentName}}",
+			body:    "Hi {{.RecipientName}},\n\n{{.Participants}} registered for {{.TournamentName}}.\n\nParticipants: {{.TournamentURL}}\n",
+			summary: "{{.TournamentName}}: {{.Participants}} registered",
+		},
+		TemplatePaymentReceipt: {
+			subject: "Payment received: {{.TournamentName}}",
+			body: "Hi {{.RecipientName}},\n\nWe received your entry fee of {{.Amount}} for {{.TournamentName}}. " +
+				"Invoice {{.InvoiceNumber}} is attached and can be downloaded from your account.\n\nDetails: {{.TournamentURL}}\n",
+		},
+		TemplateDigest: {
+			subject: "Your tournament updates ({{len .Items}})",
+			body:    "Hi {{.RecipientName}},\n\nHere is what happened since your last update:\n\n{{range .Items}}- {{.}}\n{{end}}",
+		},
+	},
+	"es": {
+		TemplateTournamentPublished: {
+			subject: "{{.TournamentName}} ya admite inscripciones",
+			body: "Hola {{.RecipientName}}:\n\n{{.TournamentName}} se ha publicado" +
+				"{{if .StartDate}} y empieza el {{.StartDate}}{{end}}.\n\nDetalles: {{.TournamentURL}}\n",
+		},
+		TemplateFixturesGenerated: {
+			subject: "Ya están los cruces de {{.TournamentName}}",
+			body:    "Hola {{.RecipientName}}:\n\nSe han generado los cruces de {{.TournamentName}}.\n\nConsulta tus partidos: {{.TournamentURL}}\n",
+		},
+		TemplateMatchScheduled: {
+			subject: "Partido programado: {{.TournamentName}}, ronda {{.Round}}",
+			body: "Hola {{.RecipientName}}:\n\nTu partido de la ronda {{.Round}}{{if .Opponent}} contra {{.Opponent}}{{end}}" +
+				" está programado para el {{.ScheduledAt}}{{if .Venue}} en {{.Venue}}{{end}}.\n\nDetalles: {{.TournamentURL}}\n",
+		},
+		TemplateMatchResult: {
+			subject: "Resultado: {{.TournamentName}}, ronda {{.Round}}",
+			body: "Hola {{.RecipientName}}:\n\nTu partido de la ronda {{.Round}}{{if .Opponent}} contra {{.Opponent}}{{end}} terminó {{.Score}}.\n\n" +
+				"{{if .Won}}¡Enhorabuena por la victoria!{{else if .Winner}}Ganador: {{.Winner}}.{{end}}\n\nDetalles: {{.TournamentURL}}\n",
+			summary: "{{.TournamentName}}, ronda {{.Round}}{{if .Opponent}} contra {{.Opponent}}{{end}}: {{.Score}}" +
+				"{{if .Won}}, victoria{{else if .Winner}}, ganador {{.Winner}}{{end}}",
+		},
+		TemplateMatchReminder: {
+			subject: "Recordatorio: {{.TournamentName}}, ronda {{.Round}} el {{.ScheduledAt}}",
+			body: "Hola {{.RecipientName}}:\n\nTe recordamos que tu partido de la ronda {{.Round}}" +
+				"{{if .Opponent}} contra {{.Opponent}}{{else if .Participants}} ({{.Participants}}){{end}}" +
+				" está programado para el {{.ScheduledAt}}{{if .Venue}} en {{.Venue}}{{end}}.\n\nDetalles: {{.TournamentURL}}\n",
+		},
+		TemplateReminderCorrection: {
+			subject: "Cambio de horario: {{.TournamentName}}, ronda {{.Round}}",
+			body: "Hola {{.RecipientName}}:\n\nTu partido de la ronda {{.Round}}" +
+				"{{if .Opponent}} contra {{.Opponent}}{{else if .Participants}} ({{.Participants}}){{end}}" +
+				" ya no está programado para el {{.PreviousAt}}. " +
+				"{{if .ScheduledAt}}Ahora está programado para el {{.ScheduledAt}}{{if .Venue}} en {{.Venue}}{{end}}.{{else}}Todavía no se ha fijado una nueva hora.{{end}}" +
+				"\n\nPor favor, ignora el recordatorio anterior.\n\nDetalles: {{.TournamentURL}}\n",
+		},
+		TemplateParticipantRegistered: {
+			subject: "Nueva inscripción: {{.TournamentName}}",
+			body:    "Hola {{.RecipientName}}:\n\n{{.Participants}} se ha inscrito en {{.TournamentName}}.\n\nParticipantes: {{.TournamentURL}}\n",
+			summary: "{{.TournamentName}}: inscripción de {{.Participants}}",
+		},
+		TemplatePaymentReceipt: {
+			subject: "Pago recibido: {{.TournamentName}}",
+			body: "Hola {{.RecipientName}}:\n\nHemos recibido tu cuota de inscripción de {{.Amount}} para {{.TournamentName}}. " +
+				"Adjuntamos la factura {{.InvoiceNumber}}, que también puedes descargar desde tu cuenta.\n\nDetalles: {{.TournamentURL}}\n",
+		},
+		TemplateDigest: {
+			subject: "Tus novedades del torneo ({{len .Items}})",
+			body:    "Hola {{.RecipientName}}:\n\nEsto es lo que ha pasado desde tu último resumen:\n\n{{range .Items}}- {{.}}\n{{end}}",
+		},
+	},
+	"fr": {
+		TemplateTournamentPublished: {
+			subject: "Les inscriptions à {{.TournamentName}} sont ouvertes",
+			body: "Bonjour {{.RecipientName}},\n\n{{.TournamentName}} a été publié" +
+				"{{if .StartDate}} et commence le {{.StartDate}}{{end}}.\n\nDétails : {{.TournamentURL}}\n",
+		},
+		TemplateFixturesGenerated: {
+			subject: "Le tableau de {{.TournamentName}} est disponible",
+			body:    "Bonjour {{.RecipientName}},\n\nLes rencontres de {{.TournamentName}} ont été générées.\n\nVoir vos matchs : {{.TournamentURL}}\n",
+		},
+		TemplateMatchScheduled: {
+			subject: "Match programmé : {{.TournamentName}}, tour {{.Round}}",
+			body: "Bonjour {{.RecipientName}},\n\nVotre match du tour {{.Round}}{{if .Opponent}} contre {{.Opponent}}{{end}}" +
+				" est programmé le {{.ScheduledAt}}{{if .Venue}} à {{.Venue}}{{end}}.\n\nDétails : {{.TournamentURL}}\n",
+		},
+		TemplateMatchResult: {
+			subject: "Résultat : {{.TournamentName}}, tour {{.Round}}",
+			body: "Bonjour {{.RecipientName}},\n\nVotre match du tour {{.Round}}{{if .Opponent}} contre {{.Opponent}}{{end}} s'est terminé {{.Score}}.\n\n" +
+				"{{if .Won}}Félicitations pour la victoire !{{else if .Winner}}Vainqueur : {{.Winner}}.{{end}}\n\nDétails : {{.TournamentURL}}\n",
+			summary: "{{.TournamentName}}, tour {{.Round}}{{if .Opponent}} contre {{.Opponent}}{{end}} : {{.Score}}" +
+				"{{if .Won}}, victoire{{else if .Winner}}, vainqueur {{.Winner}}{{end}}",
+		},
+		TemplateMatchReminder: {
+			subject: "Rappel : {{.TournamentName}}, tour {{.Round}} le {{.ScheduledAt}}",
+			body: "Bonjour {{.RecipientName}},\n\nPour rappel, votre match du tour {{.Round}}" +
+				"{{if .Opponent}} contre {{.Opponent}}{{else if .Participants}} ({{.Participants}}){{end}}" +
+				" est programmé le {{.ScheduledAt}}{{if .Venue}} à {{.Venue}}{{end}}.\n\nDétails : {{.TournamentURL}}\n",
+		},
+		TemplateReminderCorrection: {
+			subject: "Changement d'horaire : {{.TournamentName}}, tour {{.Round}}",
+			body: "Bonjour {{.RecipientName}},\n\nVotre match du tour {{.Round}}" +
+				"{{if .Opponent}} contre {{.Opponent}}{{else if .Participants}} ({{.Participants}}){{end}}" +
+				" n'est plus programmé le {{.PreviousAt}}. " +
+				"{{if .ScheduledAt}}Il est désormais programmé le {{.ScheduledAt}}{{if .Venue}} à {{.Venue}}{{end}}.{{else}}Aucun nouvel horaire n'a encore été fixé.{{end}}" +
+				"\n\nMerci de ne pas tenir compte du rappel précédent.\n\nDétails : {{.TournamentURL}}\n",
+		},
+		TemplateParticipantRegistered: {
+			subject: "Nouvelle inscription : {{.TournamentName}}",
+			body:    "Bonjour {{.RecipientName}},\n\n{{.Participants}} s'est inscrit à {{.TournamentName}}.\n\nParticipants : {{.TournamentURL}}\n",
+			summary: "{{.TournamentName}} : inscription de {{.Participants}}",
+		},
+		TemplatePaymentReceipt: {
+			subject: "Paiement reçu : {{.TournamentName}}",
+			body: "Bonjour {{.RecipientName}},\n\nNous avons bien reçu vos frais d'inscription de {{.Amount}} pour {{.TournamentName}}. " +
+				"La facture {{.InvoiceNumber}} est jointe et peut aussi être téléchargée depuis votre compte.\n\nDétails : {{.TournamentURL}}\n",
+		},
+		TemplateDigest: {
+			subject: "Vos nouvelles du tournoi ({{len .Items}})",
+			body:    "Bonjour {{.RecipientName}},\n\nVoici ce qui s'est passé depuis votre dernier récapitulatif :\n\n{{range .Items}}- {{.}}\n{{end}}",
+		},
+	},
+}
+
+// compiledTemplate is a parsed subject, body and optional summary
+type compiledTemplate struct {
+	subject *template.Template
+	body    *template.Template
+	summary *template.Template
+}
+
+// Templates renders notifications in the recipient's locale,
+// falling back to the default locale when a translation is missing
+type Templates struct {
+	defaultLocale string
+	compiled      map[string]map[string]*compiledTemplate
+}
+
+// NewTemplates parses the built-in templates. They are fixed at compile time,
+// so a parse error is a programming error and panics.
+func NewTemplates(defaultLocale string) *Templates {
+	t := &Templates{
+		defaultLocale: normalizeLocale(defaultLocale),
+		compiled:      make(map[string]map[string]*compiledTemplate),
+	}
+	if _, ok := templateSources[t.defaultLocale]; !ok {
+		t.defaultLocale = "en"
+	}
+
+	for locale, sources := range templateSources {
+		t.compiled[locale] = make(map[string]*compiledTemplate)
+		for key, src := range sources {
+			compiled := &compiledTemplate{
+				subject: template.Must(template.New(locale + "/" + key + ".subject").Parse(src.subject)),
+				body:    template.Must(template.New(locale + "/" + key + ".body").Parse(src.body)),
+			}
+			if src.summary != "" {
+				compiled.summary = template.Must(template.New(locale + "/" + key + ".summary").Parse(src.summary))
+			}
+			t.compiled[locale][key] = compiled
+		}
+	}
+
+	return t
+}
+
+// lookup finds a template in a locale or the default locale
+func (t *Templates) lookup(key, locale string) (*compiledTemplate, error) {
+	tmpl, ok := t.compiled[normalizeLocale(locale)][key]
+	if !ok {
+		tmpl, ok = t.compiled[t.defaultLocale][key]
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown notification template %q", key)
+	}
+	return tmpl, nil
+}
+
+// Render produces the subject and body of a template for a locale
+func (t *Templates) Render(key, locale string, data *TemplateData) (string, string, error) {
+	tmpl, err := t.lookup(key, locale)
+	if err != nil {
+		return "", "", err
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.subject.Execute(&subject, data); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		return "", "", err
+	}
+
+	return strings.TrimSpace(subject.String()), body.String(), nil
+}
+
+// Summary produces the one-line digest entry of a template for a locale,
+// falling back to its subject when the template has no summary
+func (t *Templates) Summary(key, locale string, data *TemplateData) (string, error) {
+	tmpl, err := t.lookup(key, locale)
+	if err != nil {
+		return "", err
+	}
+
+	source := tmpl.subject
+	if tmpl.summary != nil {
+		source = tmpl.summary
+	}
+
+	var summary bytes.Buffer
+	if err := source.Execute(&summary, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(summary.String()), nil
+}
+
+// normalizeLocale reduces a locale such as "es-MX" or "fr_CA" to its language
+func normalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "-_"); i >= 0 {
+		locale = locale[:i]
+	}
+	return locale
+}
@@ -0,0 +1,187 @@
+// internal/payments/fake.go
+// In-memory provider for tests and local development
+
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"tournament-planner/internal/webhooks"
+)
+
+// FakeSignatureHeader carries the signature of fake provider webhooks
+const FakeSignatureHeader = "X-Fake-Signature"
+
+// FakeProvider keeps payments in memory and never moves money. Its webhooks are
+// signed like real ones, so the verified webhook path is exercised too: tests
+// and developers build events with SignedEvent and post them to the webhook
+// endpoint to simulate the provider confirming a payment.
+type FakeProvider struct {
+	webhookSecret string
+
+	mu       sync.Mutex
+	seq      int
+	payments map[string]*FakePayment
+	failNext error
+}
+
+// FakePayment is a payment held by the fake provider
+type FakePayment struct {
+	Request  PaymentRequest
+	Captured bool
+	Canceled bool
+	Refunds  []RefundRequest
+}
+
+// NewFakeProvider creates a fake provider that signs webhooks with the given secret
+func NewFakeProvider(webhookSecret string) *FakeProvider {
+	return &FakeProvider{
+		webhookSecret: webhookSecret,
+		payments:      make(map[string]*FakePayment),
+	}
+}
+
+// Name identifies the fake provider in stored payments
+func (p *FakeProvider) Name() string {
+	return ProviderFake
+}
+
+// CreatePayment records a payment
+func (p *FakeProvider) CreatePayment(ctx context.Context, req *PaymentRequest) (*Payment, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.failure(); err != nil {
+		return nil, err
+	}
+
+	p.seq++
+	id := fmt.Sprintf("fake_pi_%d", p.seq)
+	p.payments[id] = &FakePayment{Request: *req}
+
+	return &Payment{ProviderPaymentID: id, ClientSecret: id + "_secret"}, nil
+}
+
+// Capture marks a payment captured. Like a real provider, it only captures
+// payments that were started to be captured later.
+func (p *FakeProvider) Capture(ctx context.Context, providerPaymentID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.failure(); err != nil {
+		return err
+	}
+
+	payment, ok := p.payments[providerPaymentID]
+	if !ok {
+		return fmt.Errorf("fake: no payment %s", providerPaymentID)
+	}
+	if !payment.Request.CaptureLater {
+		return fmt.Errorf("fake: payment %s was not authorized for a later capture", providerPaymentID)
+	}
+	payment.Captured = true
+	return nil
+}
+
+// Cancel marks a payment canceled
+func (p *FakeProvider) Cancel(ctx context.Context, providerPaymentID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.failure(); err != nil {
+		return err
+	}
+
+	payment, ok := p.payments[providerPaymentID]
+	if !ok {
+		return fmt.Errorf("fake: no payment %s", providerPaymentID)
+	}
+	payment.Canceled = true
+	return nil
+}
+
+// Refund records a refund of a payment
+func (p *FakeProvider) Refund(ctx context.Context, req *RefundRequest) (*Refund, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.failure(); err != nil {
+		return nil, err
+	}
+
+	payment, ok := p.payments[req.ProviderPaymentID]
+	if !ok {
+		return nil, fmt.Errorf("fake: no payment %s", req.ProviderPaymentID)
+	}
+	payment.Refunds = append(payment.Refunds, *req)
+
+	return &Refund{
+		ProviderRefundID: fmt.Sprintf("fake_re_%s_%d", req.ProviderPaymentID, len(payment.Refunds)),
+		Status:           "pending",
+	}, nil
+}
+
+// PaymentFee is zero; fake webhook events carry their fee themselves
+func (p *FakeProvider) PaymentFee(ctx context.Context, providerPaymentID, currency string) (int64, error) {
+	return 0, nil
+}
+
+// Payment returns a copy of a payment the fake provider holds
+func (p *FakeProvider) Payment(providerPaymentID string) (FakePayment, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, ok := p.payments[providerPaymentID]
+	if !ok {
+		return FakePayment{}, false
+	}
+	return *payment, true
+}
+
+// ParseWebhook verifies a fake webhook and decodes its event. Without a secret
+// anyone could sign events, so every webhook is refused.
+func (p *FakeProvider) ParseWebhook(payload []byte, header http.Header) (*Event, error) {
+	if p.webhookSecret == "" {
+		return nil, fmt.Errorf("%w: fake provider has no webhook secret", ErrInvalidWebhook)
+	}
+	if err := webhooks.Verify(p.webhookSecret, header.Get(FakeSignatureHeader), payload, webhookTolerance, time.Now()); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil || event.ID == "" {
+		return nil, fmt.Errorf("%w: malformed event", ErrInvalidWebhook)
+	}
+	return &event, nil
+}
+
+// SignedEvent encodes and signs an event as the fake provider's webhook would
+func (p *FakeProvider) SignedEvent(event *Event) ([]byte, http.Header, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header := http.Header{}
+	header.Set(FakeSignatureHeader, webhooks.Sign(p.webhookSecret, time.Now(), payload))
+	return payload, header, nil
+}
+
+// FailNext makes the next API call fail with err
+func (p *FakeProvider) FailNext(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failNext = err
+}
+
+// failure returns and clears the error queued with FailNext
+func (p *FakeProvider) failure() error {
+	err := p.failNext
+	p.failNext = nil
+	return err
+}
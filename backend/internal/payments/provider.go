@@ -0,0 +1,111 @@
+// internal/payments/provider.go
+// Payment provider abstraction shared by the Stripe and fake implementations
+
+package payments
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Provider names
+const (
+	ProviderStripe = "stripe"
+	ProviderFake   = "fake"
+)
+
+// webhookTolerance is how old a signed webhook may be, matching Stripe's libraries.
+// Older deliveries are rejected so a captured request cannot be replayed later.
+const webhookTolerance = 5 * time.Minute
+
+// ErrInvalidWebhook is returned for webhook requests whose signature or body can't be trusted
+var ErrInvalidWebhook = errors.New("invalid payment webhook")
+
+// Provider creates, captures and refunds payments with a payment processor and
+// parses the events it sends back. Payment outcomes are only ever learnt from
+// those events, never from the client that paid.
+type Provider interface {
+	// Name identifies the provider in stored payments
+	Name() string
+
+	// CreatePayment starts a payment the client completes with the returned secret
+	CreatePayment(ctx context.Context, req *PaymentRequest) (*Payment, error)
+
+	// Capture collects a payment that was only authorized
+	Capture(ctx context.Context, providerPaymentID string) error
+
+	// Cancel abandons a payment that has not succeeded, releasing any authorization
+	Cancel(ctx context.Context, providerPaymentID string) error
+
+	// Refund returns all or part of a succeeded payment
+	Refund(ctx context.Context, req *RefundRequest) (*Refund, error)
+
+	// PaymentFee looks up the processing fee charged on a succeeded payment, in
+	// the payment's currency. It returns zero while the fee is not known.
+	PaymentFee(ctx context.Context, providerPaymentID, currency string) (int64, error)
+
+	// ParseWebhook verifies a webhook request and returns the event it carries
+	ParseWebhook(payload []byte, header http.Header) (*Event, error)
+}
+
+// PaymentRequest describes a payment to start. Amounts are in minor currency units.
+type PaymentRequest struct {
+	Reference     string // our payment ID, also used as idempotency key
+	Amount        int64
+	Currency      string
+	Description   string
+	CustomerEmail string
+	CaptureLater  bool // only authorize now and capture later
+	Metadata      map[string]string
+}
+
+// Payment is a payment started with the provider
+type Payment struct {
+	ProviderPaymentID string
+	ClientSecret      string
+}
+
+// RefundRequest describes a refund. An amount of zero refunds what is left of the payment.
+type RefundRequest struct {
+	Reference         string // idempotency key, so a retried request refunds once
+	ProviderPaymentID string
+	Amount            int64
+	Reason            string
+}
+
+// Refund is a refund requested from the provider
+type Refund struct {
+	ProviderRefundID string
+	Status           string
+}
+
+// EventType is a provider-neutral payment event
+type EventType string
+
+const (
+	EventPaymentAuthorized EventType = "payment_authorized"
+	EventPaymentSucceeded  EventType = "payment_succeeded"
+	EventPaymentFailed     EventType = "payment_failed"
+	EventPaymentCanceled   EventType = "payment_canceled"
+	EventPaymentRefunded   EventType = "payment_refunded"
+
+	// EventIgnored is any provider event payments don't act on
+	EventIgnored EventType = "ignored"
+)
+
+// Event is a verified payment event. AmountRefunded is the total refunded so far;
+// Fee is the provider's processing fee when the event reports it.
+type Event struct {
+	ID                string            `json:"id"`
+	Type              EventType         `json:"type"`
+	ProviderType      string            `json:"provider_type,omitempty"`
+	ProviderPaymentID string            `json:"provider_payment_id"`
+	Amount            int64             `json:"amount"`
+	AmountRefunded    int64             `json:"amount_refunded,omitempty"`
+	Fee               int64             `json:"fee,omitempty"`
+	Currency          string            `json:"currency"`
+	FailureMessage    string            `json:"failure_message,omitempty"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+}
@@ -0,0 +1,292 @@
+// internal/payments/stripe.go
+// Stripe provider using PaymentIntents over the Stripe REST API
+
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"tournament-planner/internal/webhooks"
+)
+
+const (
+	stripeAPIURL = "https://api.stripe.com/v1"
+
+	// StripeSignatureHeader carries the signature of Stripe webhook requests
+	StripeSignatureHeader = "Stripe-Signature"
+)
+
+// StripeProvider talks to Stripe. Stripe signs webhooks with the same
+// "t=...,v1=..." HMAC-SHA256 scheme as our own webhooks, so they are
+// verified with the webhooks package.
+type StripeProvider struct {
+	secretKey     string
+	webhookSecret string
+	baseURL       string
+	http          *http.Client
+}
+
+// NewStripeProvider creates a Stripe provider with API and webhook signing secrets
+func NewStripeProvider(secretKey, webhookSecret string, httpClient *http.Client) *StripeProvider {
+	return &StripeProvider{
+		secretKey:     secretKey,
+		webhookSecret: webhookSecret,
+		baseURL:       stripeAPIURL,
+		http:          httpClient,
+	}
+}
+
+// StripeError is an error response from the Stripe API
+type StripeError struct {
+	StatusCode int
+	Type       string `json:"type"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (e *StripeError) Error() string {
+	return fmt.Sprintf("stripe: %s (%s, status %d)", e.Message, e.Type, e.StatusCode)
+}
+
+// stripeIntent is the part of a PaymentIntent object payments use
+type stripeIntent struct {
+	ID               string            `json:"id"`
+	ClientSecret     string            `json:"client_secret"`
+	Amount           int64             `json:"amount"`
+	Currency         string            `json:"currency"`
+	Metadata         map[string]string `json:"metadata"`
+	LastPaymentError *struct {
+		Message string `json:"message"`
+	} `json:"last_payment_error"`
+}
+
+// stripeCharge is the part of a Charge object payments use
+type stripeCharge struct {
+	PaymentIntent  string            `json:"payment_intent"`
+	Amount         int64             `json:"amount"`
+	AmountRefunded int64             `json:"amount_refunded"`
+	Currency       string            `json:"currency"`
+	Metadata       map[string]string `json:"metadata"`
+}
+
+// Name identifies Stripe in stored payments
+func (p *StripeProvider) Name() string {
+	return ProviderStripe
+}
+
+// CreatePayment creates a PaymentIntent the client confirms with Stripe.js
+func (p *StripeProvider) CreatePayment(ctx context.Context, req *PaymentRequest) (*Payment, error) {
+	form := url.Values{}
+	form.Set("amount", strconv.FormatInt(req.Amount, 10))
+	form.Set("currency", strings.ToLower(req.Currency))
+	form.Set("automatic_payment_methods[enabled]", "true")
+	form.Set("metadata[payment_id]", req.Reference)
+	if req.Description != "" {
+		form.Set("description", req.Description)
+	}
+	if req.CustomerEmail != "" {
+		form.Set("receipt_email", req.CustomerEmail)
+	}
+	if req.CaptureLater {
+		form.Set("capture_method", "manual")
+	}
+	for key, value := range req.Metadata {
+		form.Set("metadata["+key+"]", value)
+	}
+
+	var intent stripeIntent
+	if err := p.post(ctx, "/payment_intents", form, "payment:"+req.Reference, &intent); err != nil {
+		return nil, err
+	}
+
+	return &Payment{ProviderPaymentID: intent.ID, ClientSecret: intent.ClientSecret}, nil
+}
+
+// Capture captures an authorized PaymentIntent in full
+func (p *StripeProvider) Capture(ctx context.Context, providerPaymentID string) error {
+	path := "/payment_intents/" + url.PathEscape(providerPaymentID) + "/capture"
+	return p.post(ctx, path, url.Values{}, "capture:"+providerPaymentID, nil)
+}
+
+// Cancel cancels a PaymentIntent that has not succeeded. Stripe refuses once
+// the payer has completed it, so a payment can't be both charged and canceled.
+func (p *StripeProvider) Cancel(ctx context.Context, providerPaymentID string) error {
+	path := "/payment_intents/" + url.PathEscape(providerPaymentID) + "/cancel"
+	return p.post(ctx, path, url.Values{}, "cancel:"+providerPaymentID, nil)
+}
+
+// PaymentFee looks up Stripe's fee on a PaymentIntent's charge through its balance
+// transaction. Webhook payloads don't expand it, so it takes an API call. Fees
+// settled in another currency are converted back with the transaction's rate.
+func (p *StripeProvider) PaymentFee(ctx context.Context, providerPaymentID, currency string) (int64, error) {
+	query := url.Values{}
+	query.Set("expand[]", "latest_charge.balance_transaction")
+
+	var intent struct {
+		LatestCharge *struct {
+			BalanceTransaction *struct {
+				Fee          int64   `json:"fee"`
+				Currency     string  `json:"currency"`
+				ExchangeRate float64 `json:"exchange_rate"`
+			} `json:"balance_transaction"`
+		} `json:"latest_charge"`
+	}
+	if err := p.get(ctx, "/payment_intents/"+url.PathEscape(providerPaymentID), query, &intent); err != nil {
+		return 0, err
+	}
+	if intent.LatestCharge == nil || intent.LatestCharge.BalanceTransaction == nil {
+		return 0, nil
+	}
+
+	txn := intent.LatestCharge.BalanceTransaction
+	if strings.EqualFold(txn.Currency, currency) {
+		return txn.Fee, nil
+	}
+	if txn.ExchangeRate <= 0 {
+		return 0, fmt.Errorf("stripe: fee in %s without an exchange rate to %s", txn.Currency, currency)
+	}
+	return int64(math.Round(float64(txn.Fee) / txn.ExchangeRate)), nil
+}
+
+// Refund refunds a PaymentIntent
+func (p *StripeProvider) Refund(ctx context.Context, req *RefundRequest) (*Refund, error) {
+	form := url.Values{}
+	form.Set("payment_intent", req.ProviderPaymentID)
+	if req.Amount > 0 {
+		form.Set("amount", strconv.FormatInt(req.Amount, 10))
+	}
+	if req.Reason != "" {
+		form.Set("metadata[reason]", req.Reason)
+	}
+
+	var refund struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	}
+	if err := p.post(ctx, "/refunds", form, "refund:"+req.Reference, &refund); err != nil {
+		return nil, err
+	}
+
+	return &Refund{ProviderRefundID: refund.ID, Status: refund.Status}, nil
+}
+
+// ParseWebhook verifies the Stripe-Signature header and maps the event
+func (p *StripeProvider) ParseWebhook(payload []byte, header http.Header) (*Event, error) {
+	if err := webhooks.Verify(p.webhookSecret, header.Get(StripeSignatureHeader), payload, webhookTolerance, time.Now()); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+
+	var envelope struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+		Data struct {
+			Object json.RawMessage `json:"object"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &envelope); err != nil || envelope.ID == "" {
+		return nil, fmt.Errorf("%w: malformed event", ErrInvalidWebhook)
+	}
+
+	event := &Event{ID: envelope.ID, Type: EventIgnored, ProviderType: envelope.Type}
+
+	switch envelope.Type {
+	case "payment_intent.amount_capturable_updated", "payment_intent.succeeded",
+		"payment_intent.payment_failed", "payment_intent.canceled":
+		var intent stripeIntent
+		if err := json.Unmarshal(envelope.Data.Object, &intent); err != nil {
+			return nil, fmt.Errorf("%w: malformed payment intent", ErrInvalidWebhook)
+		}
+		event.Type = stripeIntentEvents[envelope.Type]
+		event.ProviderPaymentID = intent.ID
+		event.Amount = intent.Amount
+		event.Currency = intent.Currency
+		event.Metadata = intent.Metadata
+		if intent.LastPaymentError != nil {
+			event.FailureMessage = intent.LastPaymentError.Message
+		}
+
+	case "charge.refunded":
+		var charge stripeCharge
+		if err := json.Unmarshal(envelope.Data.Object, &charge); err != nil {
+			return nil, fmt.Errorf("%w: malformed charge", ErrInvalidWebhook)
+		}
+		event.Type = EventPaymentRefunded
+		event.ProviderPaymentID = charge.PaymentIntent
+		event.Amount = charge.Amount
+		event.AmountRefunded = charge.AmountRefunded
+		event.Currency = charge.Currency
+		event.Metadata = charge.Metadata
+	}
+
+	return event, nil
+}
+
+// stripeIntentEvents maps PaymentIntent event types to payment events
+var stripeIntentEvents = map[string]EventType{
+	"payment_intent.amount_capturable_updated": EventPaymentAuthorized,
+	"payment_intent.succeeded":                 EventPaymentSucceeded,
+	"payment_intent.payment_failed":            EventPaymentFailed,
+	"payment_intent.canceled":                  EventPaymentCanceled,
+}
+
+// post sends a form-encoded API request and decodes the response into out.
+// The idempotency key makes a retried request return the original result.
+func (p *StripeProvider) post(ctx context.Context, path string, form url.Values, idempotencyKey string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+	return p.do(req, out)
+}
+
+// get sends an API request for an object and decodes the response into out
+func (p *StripeProvider) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	return p.do(req, out)
+}
+
+// do authenticates and sends an API request, turning error responses into a StripeError
+func (p *StripeProvider) do(req *http.Request, out interface{}) error {
+	req.SetBasicAuth(p.secretKey, "")
+
+	resp, err := p.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var failure struct {
+			Error StripeError `json:"error"`
+		}
+		if err := json.Unmarshal(body, &failure); err != nil || failure.Error.Message == "" {
+			failure.Error.Message = http.StatusText(resp.StatusCode)
+		}
+		failure.Error.StatusCode = resp.StatusCode
+		return &failure.Error
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(body, out)
+}
@@ -0,0 +1,259 @@
+// internal/render/bracket_layout.go
+// Computes page geometry for printable brackets, shared by the SVG and PDF renderers
+
+package render
+
+import (
+	"fmt"
+	"math"
+
+	"tournament-planner/internal/models"
+)
+
+// Layout dimensions in points (1/72 inch)
+const (
+	slotWidth    = 180.0
+	rowHeight    = 16.0
+	slotHeight   = rowHeight * 2
+	slotGap      = 14.0
+	columnGap    = 40.0
+	pageMargin   = 30.0
+	headerHeight = 44.0
+	fontSize     = 9.0
+	scoreWidth   = 26.0
+)
+
+// Point is a position on a page
+type Point struct {
+	X, Y float64
+}
+
+// SlotBox is a bracket slot placed on a page
+type SlotBox struct {
+	Slot *models.BracketSlot
+	X, Y float64
+}
+
+// Column is a round heading placed on a page
+type Column struct {
+	Name string
+	X    float64
+}
+
+// Page is one renderable page of a bracket
+type Page struct {
+	Title      string
+	Width      float64
+	Height     float64
+	Columns    []Column
+	Slots      []*SlotBox
+	Connectors [][]Point
+}
+
+// Layout is the full set of pages for a bracket
+type Layout struct {
+	Title string
+	Pages []*Page
+}
+
+// LayoutBracket positions every section of a bracket. When maxSlotsPerColumn is
+// positive, sections with larger rounds are split into sub-brackets across pages.
+func LayoutBracket(bracket *models.Bracket, maxSlotsPerColumn int) *Layout {
+	layout := &Layout{Title: bracket.Name}
+
+	for _, section := range bracket.Sections {
+		if len(section.Rounds) == 0 {
+			continue
+		}
+		title := section.Title
+		if len(bracket.Sections) == 1 {
+			title = bracket.Name
+		}
+		layout.Pages = append(layout.Pages, paginate(title, section.Rounds, maxSlotsPerColumn)...)
+	}
+
+	return layout
+}
+
+// paginate splits rounds into pages of at most limit slots per column
+func paginate(title string, rounds []*models.BracketRound, limit int) []*Page {
+	widest := 0
+	for _, r := range rounds {
+		widest = max(widest, len(r.Matches))
+	}
+	if limit <= 0 || widest <= limit {
+		return []*Page{layoutPage(title, rounds)}
+	}
+
+	first := rounds[0].Matches
+	assigned := make(map[string]bool)
+	pages := make([]*Page, 0)
+
+	// Each chunk of the first round takes the later matches fed only from within it
+	for start := 0; start < len(first); start += limit {
+		end := min(start+limit, len(first))
+		inPage := make(map[string]bool)
+		pageRounds := []*models.BracketRound{{Number: rounds[0].Number, Name: rounds[0].Name, Matches: first[start:end]}}
+		for _, slot := range first[start:end] {
+			inPage[slot.MatchID] = true
+		}
+
+		for _, r := range rounds[1:] {
+			matches := make([]*models.BracketSlot, 0)
+			for _, slot := range r.Matches {
+				if fedOnlyFrom(slot, inPage, rounds) {
+					matches = append(matches, slot)
+				}
+			}
+			if len(matches) == 0 {
+				break
+			}
+			for _, slot := range matches {
+				inPage[slot.MatchID] = true
+			}
+			pageRounds = append(pageRounds, &models.BracketRound{Number: r.Number, Name: r.Name, Matches: matches})
+		}
+
+		for id := range inPage {
+			assigned[id] = true
+		}
+		pages = append(pages, layoutPage(fmt.Sprintf("%s (part %d)", title, len(pages)+1), pageRounds))
+	}
+
+	// Whatever is left continues on its own pages
+	remaining := make([]*models.BracketRound, 0)
+	for _, r := range rounds {
+		matches := make([]*models.BracketSlot, 0)
+		for _, slot := range r.Matches {
+			if !assigned[slot.MatchID] {
+				matches = append(matches, slot)
+			}
+		}
+		if len(matches) > 0 {
+			remaining = append(remaining, &models.BracketRound{Number: r.Number, Name: r.Name, Matches: matches})
+		}
+	}
+	if len(remaining) > 0 {
+		pages = append(pages, paginate(title+" (continued)", remaining, limit)...)
+	}
+
+	return pages
+}
+
+// fedOnlyFrom reports whether every same-section feeder of a slot is on the page.
+// Feeders from other sections (e.g. winners bracket drop-downs) are ignored.
+func fedOnlyFrom(slot *models.BracketSlot, inPage map[string]bool, rounds []*models.BracketRound) bool {
+	found := false
+	for _, id := range slot.FeederMatchIDs {
+		if !inSection(id, rounds) {
+			continue
+		}
+		if !inPage[id] {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// inSection reports whether a match ID belongs to the given rounds
+func inSection(matchID string, rounds []*models.BracketRound) bool {
+	for _, r := range rounds {
+		for _, slot := range r.Matches {
+			if slot.MatchID == matchID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// layoutPage places rounds as columns, centring each slot between its feeders
+func layoutPage(title string, rounds []*models.BracketRound) *Page {
+	page := &Page{Title: title}
+	centres := make(map[string]float64)
+	boxes := make(map[string]*SlotBox)
+	top := pageMargin + headerHeight
+	bottom := top
+
+	for c, round := range rounds {
+		x := pageMargin + float64(c)*(slotWidth+columnGap)
+		page.Columns = append(page.Columns, Column{Name: round.Name, X: x})
+
+		nextY := top
+		for _, slot := range round.Matches {
+			y := nextY
+			if c > 0 {
+				sum, count := 0.0, 0
+				for _, id := range slot.FeederMatchIDs {
+					if centre, ok := centres[id]; ok {
+						sum += centre
+						count++
+					}
+				}
+				if count > 0 {
+					y = max(sum/float64(count)-slotHeight/2, nextY)
+				}
+			}
+
+			box := &SlotBox{Slot: slot, X: x, Y: y}
+			page.Slots = append(page.Slots, box)
+			boxes[slot.MatchID] = box
+			centres[slot.MatchID] = y + slotHeight/2
+			nextY = y + slotHeight + slotGap
+			bottom = max(bottom, y+slotHeight)
+		}
+	}
+
+	// Elbow connectors from each feeder to the slot it advances to
+	for _, box := range page.Slots {
+		for _, id := range box.Slot.FeederMatchIDs {
+			feeder, ok := boxes[id]
+			if !ok {
+				continue
+			}
+			from := Point{X: feeder.X + slotWidth, Y: feeder.Y + slotHeight/2}
+			to := Point{X: box.X, Y: box.Y + slotHeight/2}
+			midX := from.X + columnGap/2
+			page.Connectors = append(page.Connectors, []Point{from, {X: midX, Y: from.Y}, {X: midX, Y: to.Y}, to})
+		}
+	}
+
+	page.Width = pageMargin*2 + float64(len(rounds))*slotWidth + float64(max(len(rounds)-1, 0))*columnGap
+	page.Height = bottom + pageMargin
+
+	return page
+}
+
+// entrantLabel formats an entrant's name with its seed, truncated to fit the slot
+func entrantLabel(entrant *models.BracketEntrant) string {
+	label := entrant.Name
+	if entrant.Seed != nil {
+		label = fmt.Sprintf("(%d) %s", *entrant.Seed, label)
+	}
+
+	// Helvetica averages a little over half the font size per character
+	maxChars := int(math.Floor((slotWidth - scoreWidth - 8) / (fontSize * 0.55)))
+	runes := []rune(label)
+	if len(runes) > maxChars {
+		label = string(runes[:maxChars-1]) + "…"
+	}
+	return label
+}
+
+// entrantScore returns the score shown next to an entrant, if any
+func entrantScore(slot *models.BracketSlot, first bool) string {
+	score := slot.Score2
+	if first {
+		score = slot.Score1
+	}
+	if score == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d", *score)
+}
+
+// isWinner reports whether the entrant won the slot's match
+func isWinner(slot *models.BracketSlot, entrant *models.BracketEntrant) bool {
+	return slot.WinnerID != nil && entrant.ParticipantID != nil && *slot.WinnerID == *entrant.ParticipantID
+}
@@ -0,0 +1,107 @@
+// internal/render/bracket_pdf.go
+// Renders bracket layouts as a multi-page printable PDF
+
+package render
+
+import (
+	"fmt"
+	"io"
+
+	"tournament-planner/internal/models"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// A4 landscape in points
+const (
+	pdfPageWidth  = 841.89
+	pdfPageHeight = 595.28
+)
+
+// pdfSlotsPerColumn is how many slots fit vertically on one A4 landscape page.
+// A power of two keeps each page a complete sub-bracket.
+const pdfSlotsPerColumn = 8
+
+// BracketPDF writes the bracket as an A4 landscape PDF, splitting large rounds across pages
+func BracketPDF(w io.Writer, bracket *models.Bracket) error {
+	layout := LayoutBracket(bracket, pdfSlotsPerColumn)
+
+	pdf := fpdf.New("L", "pt", "A4", "")
+	pdf.SetTitle(bracket.Name, true)
+	pdf.SetAutoPageBreak(false, 0)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	if len(layout.Pages) == 0 {
+		pdf.AddPage()
+		pdf.SetFont("Helvetica", "B", 14)
+		pdf.Text(pageMargin, pageMargin+4, tr(bracket.Name))
+		pdf.SetFont("Helvetica", "", fontSize)
+		pdf.Text(pageMargin, pageMargin+24, "No matches have been generated yet.")
+	}
+
+	for i, page := range layout.Pages {
+		pdf.AddPage()
+
+		// Shrink pages with many rounds so they still fit the sheet width
+		scale := min(1, pdfPageWidth/page.Width, pdfPageHeight/page.Height)
+		sx := func(v float64) float64 { return v * scale }
+
+		pdf.SetFont("Helvetica", "B", 14)
+		pdf.SetTextColor(0, 0, 0)
+		pdf.Text(pageMargin, pageMargin+4, tr(page.Title))
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.SetTextColor(120, 120, 120)
+		pdf.Text(pdfPageWidth-pageMargin-60, pdfPageHeight-pageMargin/2, fmt.Sprintf("Page %d of %d", i+1, len(layout.Pages)))
+
+		pdf.SetFont("Helvetica", "B", fontSize*scale)
+		pdf.SetTextColor(85, 85, 85)
+		for _, col := range page.Columns {
+			pdf.Text(sx(col.X), sx(pageMargin+headerHeight-10), tr(col.Name))
+		}
+
+		pdf.SetDrawColor(153, 153, 153)
+		pdf.SetLineWidth(0.8)
+		for _, path := range page.Connectors {
+			for j := 1; j < len(path); j++ {
+				pdf.Line(sx(path[j-1].X), sx(path[j-1].Y), sx(path[j].X), sx(path[j].Y))
+			}
+		}
+
+		for _, box := range page.Slots {
+			pdf.SetDrawColor(51, 51, 51)
+			pdf.SetFillColor(247, 247, 247)
+			pdf.Rect(sx(box.X), sx(box.Y), sx(slotWidth), sx(slotHeight), "FD")
+			pdf.SetDrawColor(204, 204, 204)
+			pdf.Line(sx(box.X), sx(box.Y+rowHeight), sx(box.X+slotWidth), sx(box.Y+rowHeight))
+			pdf.Line(sx(box.X+slotWidth-scoreWidth), sx(box.Y), sx(box.X+slotWidth-scoreWidth), sx(box.Y+slotHeight))
+
+			for k, entrant := range []*models.BracketEntrant{box.Slot.Entrant1, box.Slot.Entrant2} {
+				if entrant == nil {
+					continue
+				}
+				style := ""
+				if isWinner(box.Slot, entrant) {
+					style = "B"
+				}
+				pdf.SetFont("Helvetica", style, fontSize*scale)
+				if entrant.ParticipantID == nil {
+					pdf.SetTextColor(136, 136, 136)
+				} else {
+					pdf.SetTextColor(17, 17, 17)
+				}
+
+				baseline := box.Y + float64(k)*rowHeight + rowHeight - 4.5
+				pdf.Text(sx(box.X+4), sx(baseline), tr(entrantLabel(entrant)))
+				if score := entrantScore(box.Slot, k == 0); score != "" {
+					width := pdf.GetStringWidth(score)
+					pdf.Text(sx(box.X+slotWidth-scoreWidth/2)-width/2, sx(baseline), score)
+				}
+			}
+		}
+	}
+
+	if err := pdf.Error(); err != nil {
+		return fmt.Errorf("failed to render bracket PDF: %w", err)
+	}
+	return pdf.Output(w)
+}
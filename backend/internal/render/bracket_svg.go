@@ -0,0 +1,91 @@
+// internal/render/bracket_svg.go
+// Renders bracket layouts as a single SVG image
+
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+
+	"tournament-planner/internal/models"
+)
+
+// BracketSVG writes the whole bracket as one SVG document, stacking sections vertically
+func BracketSVG(w io.Writer, bracket *models.Bracket) error {
+	layout := LayoutBracket(bracket, 0)
+
+	width, height := 400.0, pageMargin*2
+	for _, page := range layout.Pages {
+		width = max(width, page.Width)
+		height += page.Height
+	}
+
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" font-family="Helvetica, Arial, sans-serif" font-size="%.0f">`+"\n",
+		width, height, width, height, fontSize)
+	fmt.Fprintf(out, `<rect width="100%%" height="100%%" fill="#ffffff"/>`+"\n")
+
+	offset := 0.0
+	for _, page := range layout.Pages {
+		fmt.Fprintf(out, `<g transform="translate(0 %.1f)">`+"\n", offset)
+		writeSVGPage(out, page)
+		fmt.Fprintln(out, `</g>`)
+		offset += page.Height
+	}
+
+	fmt.Fprintln(out, `</svg>`)
+	return out.Flush()
+}
+
+// writeSVGPage draws one page's headings, connectors and slots
+func writeSVGPage(out *bufio.Writer, page *Page) {
+	fmt.Fprintf(out, `<text x="%.1f" y="%.1f" font-size="14" font-weight="bold">%s</text>`+"\n",
+		pageMargin, pageMargin+4, html.EscapeString(page.Title))
+
+	for _, col := range page.Columns {
+		fmt.Fprintf(out, `<text x="%.1f" y="%.1f" font-weight="bold" fill="#555555">%s</text>`+"\n",
+			col.X, pageMargin+headerHeight-10, html.EscapeString(col.Name))
+	}
+
+	for _, path := range page.Connectors {
+		fmt.Fprint(out, `<polyline fill="none" stroke="#999999" stroke-width="1" points="`)
+		for i, p := range path {
+			if i > 0 {
+				fmt.Fprint(out, " ")
+			}
+			fmt.Fprintf(out, "%.1f,%.1f", p.X, p.Y)
+		}
+		fmt.Fprintln(out, `"/>`)
+	}
+
+	for _, box := range page.Slots {
+		fmt.Fprintf(out, `<rect x="%.1f" y="%.1f" width="%.0f" height="%.0f" fill="#f7f7f7" stroke="#333333" stroke-width="1"/>`+"\n",
+			box.X, box.Y, slotWidth, slotHeight)
+		fmt.Fprintf(out, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#cccccc"/>`+"\n",
+			box.X, box.Y+rowHeight, box.X+slotWidth, box.Y+rowHeight)
+		fmt.Fprintf(out, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#cccccc"/>`+"\n",
+			box.X+slotWidth-scoreWidth, box.Y, box.X+slotWidth-scoreWidth, box.Y+slotHeight)
+
+		for i, entrant := range []*models.BracketEntrant{box.Slot.Entrant1, box.Slot.Entrant2} {
+			if entrant == nil {
+				continue
+			}
+			baseline := box.Y + float64(i)*rowHeight + rowHeight - 4.5
+			weight, fill := "normal", "#111111"
+			if isWinner(box.Slot, entrant) {
+				weight = "bold"
+			}
+			if entrant.ParticipantID == nil {
+				fill = "#888888"
+			}
+			fmt.Fprintf(out, `<text x="%.1f" y="%.1f" font-weight="%s" fill="%s">%s</text>`+"\n",
+				box.X+4, baseline, weight, fill, html.EscapeString(entrantLabel(entrant)))
+			if score := entrantScore(box.Slot, i == 0); score != "" {
+				fmt.Fprintf(out, `<text x="%.1f" y="%.1f" text-anchor="middle" font-weight="%s">%s</text>`+"\n",
+					box.X+slotWidth-scoreWidth/2, baseline, weight, score)
+			}
+		}
+	}
+}
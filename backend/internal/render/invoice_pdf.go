@@ -0,0 +1,140 @@
+// internal/render/invoice_pdf.go
+// Renders invoices and credit notes as printable PDFs
+
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"tournament-planner/internal/models"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Invoice page layout in points, A4 portrait
+const (
+	invoiceMargin      = 50.0
+	invoiceWidth       = 595.28 - 2*invoiceMargin
+	invoiceLineHeight  = 14.0
+	invoiceAmountWidth = 90.0
+	invoiceQtyWidth    = 40.0
+)
+
+// InvoicePDF writes an invoice or credit note as an A4 PDF. A settled invoice
+// says how it was paid, so it serves as the payer's receipt as well.
+func InvoicePDF(w io.Writer, invoice *models.Invoice) error {
+	title := "Invoice"
+	if invoice.Kind == models.InvoiceKindCreditNote {
+		title = "Credit note"
+	}
+
+	pdf := fpdf.New("P", "pt", "A4", "")
+	pdf.SetTitle(fmt.Sprintf("%s %s", title, invoice.Number), true)
+	pdf.SetMargins(invoiceMargin, invoiceMargin, invoiceMargin)
+	pdf.SetAutoPageBreak(true, invoiceMargin)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.AddPage()
+
+	// Heading with the document number and date on the right
+	pdf.SetFont("Helvetica", "B", 20)
+	pdf.CellFormat(invoiceWidth/2, 26, tr(title), "", 0, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(invoiceWidth/2, invoiceLineHeight, tr("No. "+invoice.Number), "", 2, "R", false, 0, "")
+	pdf.CellFormat(invoiceWidth/2, invoiceLineHeight, "Issued "+invoice.IssuedAt.Format("2 January 2006"), "", 2, "R", false, 0, "")
+	if invoice.CreditedNumber != "" {
+		pdf.CellFormat(invoiceWidth/2, invoiceLineHeight, tr("Credits invoice "+invoice.CreditedNumber), "", 2, "R", false, 0, "")
+	}
+	pdf.SetXY(invoiceMargin, pdf.GetY()+24)
+
+	// Seller and buyer side by side
+	top := pdf.GetY()
+	writeParty(pdf, tr, "From", invoice.Seller, invoiceMargin)
+	bottom := pdf.GetY()
+	pdf.SetY(top)
+	writeParty(pdf, tr, "Bill to", invoice.Buyer, invoiceMargin+invoiceWidth/2)
+	pdf.SetXY(invoiceMargin, max(bottom, pdf.GetY())+24)
+
+	// Line items
+	descriptionWidth := invoiceWidth - invoiceQtyWidth - 2*invoiceAmountWidth
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetFillColor(238, 238, 238)
+	pdf.CellFormat(descriptionWidth, 20, "Description", "B", 0, "L", true, 0, "")
+	pdf.CellFormat(invoiceQtyWidth, 20, "Qty", "B", 0, "R", true, 0, "")
+	pdf.CellFormat(invoiceAmountWidth, 20, "Unit price", "B", 0, "R", true, 0, "")
+	pdf.CellFormat(invoiceAmountWidth, 20, "Amount", "B", 1, "R", true, 0, "")
+
+	pdf.SetFont("Helvetica", "", 10)
+	for _, line := range invoice.Lines {
+		pdf.CellFormat(descriptionWidth, 18, tr(line.Description), "B", 0, "L", false, 0, "")
+		pdf.CellFormat(invoiceQtyWidth, 18, fmt.Sprint(line.Quantity), "B", 0, "R", false, 0, "")
+		pdf.CellFormat(invoiceAmountWidth, 18, tr(line.UnitAmount.String()), "B", 0, "R", false, 0, "")
+		pdf.CellFormat(invoiceAmountWidth, 18, tr(line.Amount.String()), "B", 1, "R", false, 0, "")
+	}
+	pdf.Ln(8)
+
+	// Totals. Prices include tax, so the tax is broken out of the total.
+	labelWidth := invoiceWidth - invoiceAmountWidth
+	total := func(label, amount string) {
+		pdf.CellFormat(labelWidth, 16, tr(label), "", 0, "R", false, 0, "")
+		pdf.CellFormat(invoiceAmountWidth, 16, amount, "", 1, "R", false, 0, "")
+	}
+	if invoice.TaxRateBasisPoints > 0 {
+		total("Subtotal excluding "+invoice.TaxLabel, tr(invoice.Subtotal.String()))
+		total(fmt.Sprintf("%s at %s%%", invoice.TaxLabel, formatBasisPoints(invoice.TaxRateBasisPoints)), tr(invoice.Tax.String()))
+	}
+	pdf.SetFont("Helvetica", "B", 11)
+	if invoice.Kind == models.InvoiceKindCreditNote {
+		total("Total credited", tr(invoice.Total.String()))
+	} else {
+		total("Total", tr(invoice.Total.String()))
+	}
+	pdf.Ln(16)
+
+	pdf.SetFont("Helvetica", "", 10)
+	if invoice.Kind == models.InvoiceKindCreditNote {
+		pdf.MultiCell(invoiceWidth, invoiceLineHeight, tr(fmt.Sprintf("Refunded to the original payment method (%s).", invoice.PaymentMethod)), "", "L", false)
+	} else {
+		pdf.MultiCell(invoiceWidth, invoiceLineHeight, tr(fmt.Sprintf("Paid in full on %s. Payment method: %s.",
+			invoice.IssuedAt.Format("2 January 2006"), invoice.PaymentMethod)), "", "L", false)
+	}
+	if invoice.TaxRateBasisPoints == 0 {
+		pdf.MultiCell(invoiceWidth, invoiceLineHeight, "No tax was charged.", "", "L", false)
+	}
+
+	if err := pdf.Error(); err != nil {
+		return fmt.Errorf("failed to render invoice PDF: %w", err)
+	}
+	return pdf.Output(w)
+}
+
+// writeParty writes a labelled name and address block at a horizontal position
+func writeParty(pdf *fpdf.Fpdf, tr func(string) string, label string, party models.InvoiceParty, x float64) {
+	width := invoiceWidth / 2
+	pdf.SetX(x)
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetTextColor(120, 120, 120)
+	pdf.CellFormat(width, invoiceLineHeight, strings.ToUpper(label), "", 2, "L", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(width, invoiceLineHeight, tr(party.Name), "", 2, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	if party.Address != "" {
+		pdf.MultiCell(width, invoiceLineHeight, tr(party.Address), "", "L", false)
+		pdf.SetX(x)
+	}
+	if party.TaxID != "" {
+		pdf.CellFormat(width, invoiceLineHeight, tr("Tax ID: "+party.TaxID), "", 2, "L", false, 0, "")
+	}
+	if party.Email != "" {
+		pdf.CellFormat(width, invoiceLineHeight, tr(party.Email), "", 2, "L", false, 0, "")
+	}
+}
+
+// formatBasisPoints writes a rate such as 2000 as "20" and 550 as "5.5"
+func formatBasisPoints(rate int) string {
+	whole := fmt.Sprintf("%d.%02d", rate/100, rate%100)
+	return strings.TrimSuffix(strings.TrimRight(whole, "0"), ".")
+}
@@ -0,0 +1,66 @@
+// internal/render/revenue_csv.go
+// Renders revenue reports as CSV for spreadsheets and bookkeeping
+
+package render
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"tournament-planner/internal/models"
+)
+
+// revenueCSVHeader names the columns. Amounts are plain decimals in the row's currency.
+var revenueCSVHeader = []string{
+	"currency", "breakdown", "key", "name",
+	"gross", "refunds", "fees", "adjustments", "net",
+}
+
+// RevenueCSV writes a revenue report with a row for the totals of each
+// currency followed by a row per tournament, pricing tier and payment method
+func RevenueCSV(w io.Writer, report *models.RevenueReport) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(revenueCSVHeader); err != nil {
+		return err
+	}
+
+	for _, summary := range report.Currencies {
+		rows := [][]string{revenueCSVRow(summary.Currency, "total", "", "", summary.Totals)}
+		for _, group := range summary.ByTournament {
+			rows = append(rows, revenueCSVRow(summary.Currency, "tournament", group.Key, group.Name, group.LedgerBalance))
+		}
+		for _, group := range summary.ByTier {
+			rows = append(rows, revenueCSVRow(summary.Currency, "price_tier", group.Key, group.Name, group.LedgerBalance))
+		}
+		for _, group := range summary.ByMethod {
+			rows = append(rows, revenueCSVRow(summary.Currency, "payment_method", group.Key, group.Name, group.LedgerBalance))
+		}
+		if err := out.WriteAll(rows); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// revenueCSVRow writes one total or breakdown group
+func revenueCSVRow(currency, breakdown, key, name string, balance models.LedgerBalance) []string {
+	return []string{
+		currency, breakdown, key, csvText(name),
+		balance.Charged.Decimal(),
+		balance.Refunded.Decimal(),
+		balance.Fees.Decimal(),
+		balance.Adjustments.Decimal(),
+		balance.Net.Decimal(),
+	}
+}
+
+// csvText keeps spreadsheets from running organizer-entered text as a formula
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
@@ -0,0 +1,143 @@
+// internal/render/schedule_ics.go
+// Renders schedules as RFC 5545 iCalendar feeds for calendar subscriptions
+
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"tournament-planner/internal/models"
+)
+
+// icsTimeFormat is the UTC date-time form used for all timestamps in the feed
+const icsTimeFormat = "20060102T150405Z"
+
+// ScheduleICS writes every timed match in the schedule as a VEVENT.
+// Events keep a stable UID per match so calendar apps update them in place
+// when a match is rescheduled or cancelled.
+func ScheduleICS(w io.Writer, calendarName string, schedule *models.Schedule) error {
+	out := bufio.NewWriter(w)
+
+	duration := time.Duration(schedule.MatchDuration) * time.Minute
+	if duration <= 0 {
+		duration = time.Hour
+	}
+
+	writeICSLine(out, "BEGIN:VCALENDAR")
+	writeICSLine(out, "VERSION:2.0")
+	writeICSLine(out, "PRODID:-//Tournament Planner//Schedule//EN")
+	writeICSLine(out, "CALSCALE:GREGORIAN")
+	writeICSLine(out, "METHOD:PUBLISH")
+	writeICSLine(out, "X-WR-CALNAME:"+escapeICSText(calendarName))
+	writeICSLine(out, "X-WR-TIMEZONE:"+schedule.Timezone)
+	writeICSLine(out, "REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+	writeICSLine(out, "X-PUBLISHED-TTL:PT1H")
+
+	for _, day := range schedule.Days {
+		for _, venue := range day.Venues {
+			for _, m := range venue.Matches {
+				writeICSEvent(out, schedule, venue, m, duration)
+			}
+		}
+	}
+
+	writeICSLine(out, "END:VCALENDAR")
+	return out.Flush()
+}
+
+// writeICSEvent writes a single match as a VEVENT
+func writeICSEvent(out *bufio.Writer, schedule *models.Schedule, venue *models.ScheduleVenue, m *models.Match, duration time.Duration) {
+	start := m.ScheduledDatetime.UTC()
+	end := start.Add(duration)
+	if m.ActualEndTime != nil && m.ActualEndTime.After(start) {
+		end = m.ActualEndTime.UTC()
+	}
+
+	// SEQUENCE must grow with every revision; seconds since creation does so
+	// without keeping a separate revision counter on the match
+	sequence := max(int64(m.UpdatedAt.Sub(m.CreatedAt)/time.Second), 0)
+
+	status := "CONFIRMED"
+	switch m.Status {
+	case models.MatchCancelled:
+		status = "CANCELLED"
+	case models.MatchPostponed, models.MatchPending:
+		status = "TENTATIVE"
+	}
+
+	description := fmt.Sprintf("%s, round %d, match %d", schedule.TournamentName, m.RoundNumber, m.MatchNumber)
+	if m.GroupName != nil {
+		description += fmt.Sprintf(", group %s", *m.GroupName)
+	}
+	if m.Score1 != nil && m.Score2 != nil {
+		description += fmt.Sprintf("\nResult: %d-%d", *m.Score1, *m.Score2)
+	}
+
+	writeICSLine(out, "BEGIN:VEVENT")
+	writeICSLine(out, fmt.Sprintf("UID:match-%s@tournament-planner", m.ID))
+	writeICSLine(out, "DTSTAMP:"+m.UpdatedAt.UTC().Format(icsTimeFormat))
+	writeICSLine(out, "LAST-MODIFIED:"+m.UpdatedAt.UTC().Format(icsTimeFormat))
+	writeICSLine(out, fmt.Sprintf("SEQUENCE:%d", sequence))
+	writeICSLine(out, "DTSTART:"+start.Format(icsTimeFormat))
+	writeICSLine(out, "DTEND:"+end.Format(icsTimeFormat))
+	writeICSLine(out, "SUMMARY:"+escapeICSText(matchSummary(m)))
+	if venue.VenueID != nil {
+		writeICSLine(out, "LOCATION:"+escapeICSText(venue.VenueName))
+	}
+	writeICSLine(out, "DESCRIPTION:"+escapeICSText(description))
+	writeICSLine(out, "STATUS:"+status)
+	writeICSLine(out, "END:VEVENT")
+}
+
+// matchSummary names the two sides of a match for the event title
+func matchSummary(m *models.Match) string {
+	side := func(p *models.Participant, id *string) string {
+		switch {
+		case p != nil:
+			return p.Name
+		case id != nil:
+			return "Unknown"
+		default:
+			return "TBD"
+		}
+	}
+	return side(m.Participant1, m.Participant1ID) + " vs " + side(m.Participant2, m.Participant2ID)
+}
+
+// escapeICSText escapes a TEXT value per RFC 5545 section 3.3.11
+func escapeICSText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// writeICSLine writes a content line, folding it at 75 octets without splitting UTF-8 sequences.
+// Continuation lines start with a space, which counts towards their 75 octets.
+func writeICSLine(out *bufio.Writer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isUTF8Start(line[cut]) {
+			cut--
+		}
+		out.WriteString(line[:cut])
+		out.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+	out.WriteString(line)
+	out.WriteString("\r\n")
+}
+
+// isUTF8Start reports whether b begins a UTF-8 sequence
+func isUTF8Start(b byte) bool {
+	return b&0xC0 != 0x80
+}
@@ -0,0 +1,101 @@
+// internal/repositories/connection_repository.go
+// Live websocket connection data access (MongoDB)
+
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"tournament-planner/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ConnectionRepository handles the websocket_connections collection in MongoDB
+type ConnectionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewConnectionRepository creates a new connection repository
+func NewConnectionRepository(db *mongo.Database) *ConnectionRepository {
+	return &ConnectionRepository{
+		collection: db.Collection("websocket_connections"),
+	}
+}
+
+// Create records a new connection
+func (r *ConnectionRepository) Create(ctx context.Context, conn *models.Connection) error {
+	_, err := r.collection.InsertOne(ctx, conn)
+	return err
+}
+
+// Delete removes a closed connection
+func (r *ConnectionRepository) Delete(ctx context.Context, connectionID string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"connection_id": connectionID})
+	return err
+}
+
+// AddSubscription records a tournament subscription on a connection
+func (r *ConnectionRepository) AddSubscription(ctx context.Context, connectionID, tournamentID string) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"connection_id": connectionID},
+		bson.M{"$addToSet": bson.M{"tournament_subscriptions": tournamentID}},
+	)
+	return err
+}
+
+// RemoveSubscription removes a tournament subscription from a connection
+func (r *ConnectionRepository) RemoveSubscription(ctx context.Context, connectionID, tournamentID string) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"connection_id": connectionID},
+		bson.M{"$pull": bson.M{"tournament_subscriptions": tournamentID}},
+	)
+	return err
+}
+
+// Touch records a heartbeat on a connection
+func (r *ConnectionRepository) Touch(ctx context.Context, connectionID string, at time.Time) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"connection_id": connectionID},
+		bson.M{"$set": bson.M{"last_ping": at}},
+	)
+	return err
+}
+
+// GetPresence summarizes the connections of the given users with a heartbeat since the cutoff.
+// Users without such a connection are left out.
+func (r *ConnectionRepository) GetPresence(ctx context.Context, userIDs []string, since time.Time) (map[string]*models.UserPresence, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"user_id":   bson.M{"$in": userIDs},
+			"last_ping": bson.M{"$gte": since},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":         "$user_id",
+			"connections": bson.M{"$sum": 1},
+			"last_seen":   bson.M{"$max": "$last_ping"},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	presence := make(map[string]*models.UserPresence)
+	for cursor.Next(ctx) {
+		var p models.UserPresence
+		if err := cursor.Decode(&p); err != nil {
+			return nil, err
+		}
+		presence[p.UserID] = &p
+	}
+
+	return presence, cursor.Err()
+}
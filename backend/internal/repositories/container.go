@@ -17,8 +17,22 @@ type Container struct {
 	Match                 *MatchRepository
 	Venue                 *VenueRepository
 	Payment               *PaymentRepository
+	Ledger                *LedgerRepository
+	DiscountCode          *DiscountCodeRepository
+	TournamentMember      *TournamentMemberRepository
+	RefundBatch           *RefundBatchRepository
+	OnsitePayment         *OnsitePaymentRepository
+	Invoice               *InvoiceRepository
 	UserPreferences       *UserPreferencesRepository
+	MatchUpdate           *MatchUpdateRepository
 	Participant           *ParticipantRepository
+	ResultCorrection      *ResultCorrectionRepository
+	Connection            *ConnectionRepository
+	Outbox                *OutboxRepository
+	HeldNotification      *HeldNotificationRepository
+	Referee               *RefereeRepository
+	MatchReminder         *MatchReminderRepository
+	Webhook               *WebhookRepository
 	db                    *sql.DB
 }
 
@@ -31,8 +45,22 @@ func NewContainer(conn *database.Connections) *Container {
 		Match:                 NewMatchRepository(conn.MySQL),
 		Venue:                 NewVenueRepository(conn.MySQL),
 		Payment:               NewPaymentRepository(conn.MySQL),
+		Ledger:                NewLedgerRepository(conn.MySQL),
+		DiscountCode:          NewDiscountCodeRepository(conn.MySQL),
+		TournamentMember:      NewTournamentMemberRepository(conn.MySQL),
+		RefundBatch:           NewRefundBatchRepository(conn.MySQL),
+		OnsitePayment:         NewOnsitePaymentRepository(conn.MySQL),
+		Invoice:               NewInvoiceRepository(conn.MySQL),
 		Participant:           NewParticipantRepository(conn.MySQL),
+		ResultCorrection:      NewResultCorrectionRepository(conn.MySQL),
+		Outbox:                NewOutboxRepository(conn.MySQL),
+		HeldNotification:      NewHeldNotificationRepository(conn.MySQL),
+		Referee:               NewRefereeRepository(conn.MySQL),
+		MatchReminder:         NewMatchReminderRepository(conn.MySQL),
+		Webhook:               NewWebhookRepository(conn.MySQL),
 		UserPreferences:       NewUserPreferencesRepository(conn.MongoDB),
+		MatchUpdate:           NewMatchUpdateRepository(conn.MongoDB),
+		Connection:            NewConnectionRepository(conn.MongoDB),
 		db:                    conn.MySQL,
 	}
 }
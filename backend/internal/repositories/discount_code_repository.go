@@ -0,0 +1,135 @@
+// internal/repositories/discount_code_repository.go
+// Entry fee discount code data access layer
+
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"tournament-planner/internal/models"
+)
+
+// DiscountCodeRepository handles discount codes
+type DiscountCodeRepository struct {
+	db *sql.DB
+}
+
+// NewDiscountCodeRepository creates a new discount code repository
+func NewDiscountCodeRepository(db *sql.DB) *DiscountCodeRepository {
+	return &DiscountCodeRepository{db: db}
+}
+
+// discountCodeColumns is the column list shared by discount code queries
+const discountCodeColumns = `
+	id, tournament_id, code, discount_type, value, max_uses, used_count,
+	expires_at, is_active, created_by, created_at, updated_at
+`
+
+// Create inserts a discount code
+func (r *DiscountCodeRepository) Create(ctx context.Context, code *models.DiscountCode) error {
+	query := `
+		INSERT INTO discount_codes (
+			id, tournament_id, code, discount_type, value, max_uses, used_count,
+			expires_at, is_active, created_by, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		code.ID,
+		code.TournamentID,
+		code.Code,
+		code.Type,
+		code.Value,
+		code.MaxUses,
+		code.UsedCount,
+		code.ExpiresAt,
+		code.IsActive,
+		code.CreatedBy,
+		code.CreatedAt,
+		code.UpdatedAt,
+	)
+	return err
+}
+
+// GetByID retrieves a discount code by ID
+func (r *DiscountCodeRepository) GetByID(ctx context.Context, id string) (*models.DiscountCode, error) {
+	query := `SELECT ` + discountCodeColumns + ` FROM discount_codes WHERE id = ?`
+	return r.queryOne(ctx, query, id)
+}
+
+// GetByCode retrieves a tournament's discount code by its upper-case code
+func (r *DiscountCodeRepository) GetByCode(ctx context.Context, tournamentID, code string) (*models.DiscountCode, error) {
+	query := `SELECT ` + discountCodeColumns + ` FROM discount_codes WHERE tournament_id = ? AND code = ?`
+	return r.queryOne(ctx, query, tournamentID, code)
+}
+
+// ListByTournament retrieves all discount codes of a tournament
+func (r *DiscountCodeRepository) ListByTournament(ctx context.Context, tournamentID string) ([]*models.DiscountCode, error) {
+	query := `SELECT ` + discountCodeColumns + ` FROM discount_codes WHERE tournament_id = ? ORDER BY created_at`
+	return r.query(ctx, query, tournamentID)
+}
+
+// Update saves a discount code's usage limit, expiry and active flag
+func (r *DiscountCodeRepository) Update(ctx context.Context, code *models.DiscountCode) error {
+	query := `
+		UPDATE discount_codes SET max_uses = ?, expires_at = ?, is_active = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query, code.MaxUses, code.ExpiresAt, code.IsActive, code.ID)
+	return err
+}
+
+// RedeemWithTx counts one use of a code within a transaction. It returns false
+// when the code became unusable since it was quoted, so concurrent registrations
+// can't exceed the usage limit.
+func (r *DiscountCodeRepository) RedeemWithTx(tx *sql.Tx, id string) (bool, error) {
+	query := `
+		UPDATE discount_codes SET used_count = used_count + 1
+		WHERE id = ?
+			AND is_active = TRUE
+			AND (max_uses IS NULL OR used_count < max_uses)
+			AND (expires_at IS NULL OR expires_at > NOW())
+	`
+
+	result, err := tx.ExecContext(context.Background(), query, id)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// queryOne runs a discount code select expected to match at most one row
+func (r *DiscountCodeRepository) queryOne(ctx context.Context, query string, args ...interface{}) (*models.DiscountCode, error) {
+	codes, err := r.query(ctx, query, args...)
+	if err != nil || len(codes) == 0 {
+		return nil, err
+	}
+	return codes[0], nil
+}
+
+// query runs a discount code select and scans the rows
+func (r *DiscountCodeRepository) query(ctx context.Context, query string, args ...interface{}) ([]*models.DiscountCode, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	codes := make([]*models.DiscountCode, 0)
+	for rows.Next() {
+		var d models.DiscountCode
+		err := rows.Scan(
+			&d.ID, &d.TournamentID, &d.Code, &d.Type, &d.Value, &d.MaxUses, &d.UsedCount,
+			&d.ExpiresAt, &d.IsActive, &d.CreatedBy, &d.CreatedAt, &d.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, &d)
+	}
+
+	return codes, rows.Err()
+}
@@ -0,0 +1,138 @@
+// internal/repositories/held_notification_repository.go
+// Data access for notifications held for quiet hours or a daily digest
+
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"tournament-planner/internal/models"
+)
+
+// HeldNotificationRepository handles held notifications
+type HeldNotificationRepository struct {
+	db *sql.DB
+}
+
+// NewHeldNotificationRepository creates a new held notification repository
+func NewHeldNotificationRepository(db *sql.DB) *HeldNotificationRepository {
+	return &HeldNotificationRepository{db: db}
+}
+
+// Hold stores a rendered message until its delivery time. A message with the
+// same source key already held is left as it is, so a retried outbox delivery
+// doesn't hold a message twice.
+func (r *HeldNotificationRepository) Hold(ctx context.Context, held *models.HeldNotification) error {
+	query := `
+		INSERT IGNORE INTO held_notifications (
+			id, source_key, user_id, participant_id, recipient_name, email, phone, locale,
+			channel, template, subject, body, summary, digest, deliver_after, status
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 'pending')
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		held.ID, held.SourceKey, held.UserID, held.ParticipantID, held.RecipientName,
+		held.Email, held.Phone, held.Locale, held.Channel, held.Template,
+		held.Subject, held.Body, held.Summary, held.Digest, held.DeliverAfter,
+	)
+	return err
+}
+
+// ClaimDue leases up to limit messages due by now to the caller's claim token and
+// returns them ordered by user, so one user's digest is usually claimed together.
+func (r *HeldNotificationRepository) ClaimDue(ctx context.Context, token string, now time.Time, limit int, lease time.Duration) ([]*models.HeldNotification, error) {
+	claim := `
+		UPDATE held_notifications
+		SET claim_token = ?, locked_until = DATE_ADD(NOW(), INTERVAL ? SECOND)
+		WHERE status = 'pending'
+			AND deliver_after <= ?
+			AND (locked_until IS NULL OR locked_until < NOW())
+		ORDER BY user_id, deliver_after
+		LIMIT ?
+	`
+	if _, err := r.db.ExecContext(ctx, claim, token, int(lease.Seconds()), now, limit); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT id, source_key, user_id, participant_id, recipient_name, email, phone, locale,
+			channel, template, subject, body, summary, digest, deliver_after, status,
+			attempts, last_error, created_at, sent_at
+		FROM held_notifications
+		WHERE claim_token = ? AND status = 'pending' AND locked_until >= NOW()
+		ORDER BY user_id, channel, created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, token)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	held := make([]*models.HeldNotification, 0)
+	for rows.Next() {
+		var h models.HeldNotification
+		err := rows.Scan(
+			&h.ID, &h.SourceKey, &h.UserID, &h.ParticipantID, &h.RecipientName,
+			&h.Email, &h.Phone, &h.Locale, &h.Channel, &h.Template,
+			&h.Subject, &h.Body, &h.Summary, &h.Digest, &h.DeliverAfter, &h.Status,
+			&h.Attempts, &h.LastError, &h.CreatedAt, &h.SentAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		held = append(held, &h)
+	}
+
+	return held, rows.Err()
+}
+
+// MarkSent settles messages that were delivered
+func (r *HeldNotificationRepository) MarkSent(ctx context.Context, ids []string) error {
+	query := `
+		UPDATE held_notifications
+		SET status = 'delivered', sent_at = NOW(), attempts = attempts + 1,
+			locked_until = NULL, last_error = NULL
+		WHERE id IN (` + placeholders(len(ids)) + `)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, stringArgs(ids)...)
+	return err
+}
+
+// MarkFailed records a failed attempt for messages. They are retried at retryAt,
+// or given up on when dead is set.
+func (r *HeldNotificationRepository) MarkFailed(ctx context.Context, ids []string, lastError string, retryAt time.Time, dead bool) error {
+	status := models.OutboxPending
+	if dead {
+		status = models.OutboxDead
+	}
+
+	query := `
+		UPDATE held_notifications
+		SET status = ?, last_error = ?, attempts = attempts + 1,
+			deliver_after = ?, locked_until = NULL
+		WHERE id IN (` + placeholders(len(ids)) + `)
+	`
+
+	args := append([]interface{}{status, lastError, retryAt}, stringArgs(ids)...)
+	_, err := r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// placeholders returns n comma-separated bind parameters
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// stringArgs converts strings to query arguments
+func stringArgs(values []string) []interface{} {
+	args := make([]interface{}, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return args
+}
@@ -0,0 +1,233 @@
+// internal/repositories/invoice_repository.go
+// Invoice, credit note and organizer billing profile data access layer
+
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"tournament-planner/internal/models"
+)
+
+// InvoiceRepository handles invoices, their number sequences and the billing
+// profiles printed on them
+type InvoiceRepository struct {
+	db *sql.DB
+}
+
+// NewInvoiceRepository creates a new invoice repository
+func NewInvoiceRepository(db *sql.DB) *InvoiceRepository {
+	return &InvoiceRepository{db: db}
+}
+
+// invoiceColumns selects an invoice together with the number of the invoice it credits
+const invoiceColumns = `
+	i.id, i.number, i.kind, i.organizer_id, i.tournament_id, i.participant_id,
+	i.credited_invoice_id, COALESCE(c.number, ''), i.source_reference, i.seller, i.buyer,
+	i.line_items, i.subtotal_cents, i.tax_label, i.tax_rate_basis_points, i.tax_cents,
+	i.total_cents, i.currency, i.payment_method, i.issued_at
+`
+
+// NextNumberWithTx takes the next number of an organizer's invoice or credit
+// note sequence. The sequence row stays locked until the transaction ends, so
+// numbers are issued in order and a rolled back invoice leaves no gap.
+func (r *InvoiceRepository) NextNumberWithTx(tx *sql.Tx, organizerID string, kind models.InvoiceKind) (int, error) {
+	query := `
+		INSERT INTO invoice_sequences (organizer_id, kind, last_number)
+		VALUES (?, ?, 1)
+		ON DUPLICATE KEY UPDATE last_number = last_number + 1
+	`
+	if _, err := tx.ExecContext(context.Background(), query, organizerID, kind); err != nil {
+		return 0, err
+	}
+
+	var number int
+	err := tx.QueryRowContext(context.Background(),
+		`SELECT last_number FROM invoice_sequences WHERE organizer_id = ? AND kind = ?`,
+		organizerID, kind,
+	).Scan(&number)
+	return number, err
+}
+
+// CreateWithTx inserts an invoice within a transaction
+func (r *InvoiceRepository) CreateWithTx(tx *sql.Tx, invoice *models.Invoice) error {
+	query := `
+		INSERT INTO invoices (
+			id, number, kind, organizer_id, tournament_id, participant_id,
+			credited_invoice_id, source_reference, seller, buyer, line_items,
+			subtotal_cents, tax_label, tax_rate_basis_points, tax_cents, total_cents,
+			currency, payment_method, issued_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := tx.ExecContext(context.Background(), query,
+		invoice.ID,
+		invoice.Number,
+		invoice.Kind,
+		invoice.OrganizerID,
+		invoice.TournamentID,
+		invoice.ParticipantID,
+		invoice.CreditedInvoiceID,
+		invoice.SourceReference,
+		invoice.Seller,
+		invoice.Buyer,
+		invoice.Lines,
+		invoice.Subtotal.Amount,
+		invoice.TaxLabel,
+		invoice.TaxRateBasisPoints,
+		invoice.Tax.Amount,
+		invoice.Total.Amount,
+		invoice.Total.Currency,
+		invoice.PaymentMethod,
+		invoice.IssuedAt,
+	)
+	return err
+}
+
+// GetByID retrieves an invoice, or nil if it does not exist
+func (r *InvoiceRepository) GetByID(ctx context.Context, id string) (*models.Invoice, error) {
+	query := `
+		SELECT ` + invoiceColumns + `
+		FROM invoices i
+		LEFT JOIN invoices c ON c.id = i.credited_invoice_id
+		WHERE i.id = ?
+	`
+
+	return r.queryInvoice(r.db.QueryRowContext(ctx, query, id))
+}
+
+// GetBySourceWithTx retrieves the document of a kind issued for a payment,
+// collection or refund event within a transaction, or nil if none was issued
+func (r *InvoiceRepository) GetBySourceWithTx(tx *sql.Tx, kind models.InvoiceKind, sourceReference string) (*models.Invoice, error) {
+	query := `
+		SELECT ` + invoiceColumns + `
+		FROM invoices i
+		LEFT JOIN invoices c ON c.id = i.credited_invoice_id
+		WHERE i.kind = ? AND i.source_reference = ?
+	`
+
+	return r.queryInvoice(tx.QueryRowContext(context.Background(), query, kind, sourceReference))
+}
+
+// ListByTournament retrieves a tournament's invoices and credit notes in the order issued
+func (r *InvoiceRepository) ListByTournament(ctx context.Context, tournamentID string) ([]*models.Invoice, error) {
+	query := `
+		SELECT ` + invoiceColumns + `
+		FROM invoices i
+		LEFT JOIN invoices c ON c.id = i.credited_invoice_id
+		WHERE i.tournament_id = ?
+		ORDER BY i.issued_at, i.number
+	`
+	return r.queryInvoices(ctx, query, tournamentID)
+}
+
+// ListByUser retrieves the invoices and credit notes issued to a user's participants, newest first
+func (r *InvoiceRepository) ListByUser(ctx context.Context, userID string) ([]*models.Invoice, error) {
+	query := `
+		SELECT ` + invoiceColumns + `
+		FROM invoices i
+		JOIN participants p ON p.id = i.participant_id
+		LEFT JOIN invoices c ON c.id = i.credited_invoice_id
+		WHERE p.user_id = ?
+		ORDER BY i.issued_at DESC
+	`
+	return r.queryInvoices(ctx, query, userID)
+}
+
+// queryInvoice scans a single invoice row, returning nil when there is none
+func (r *InvoiceRepository) queryInvoice(row *sql.Row) (*models.Invoice, error) {
+	var i models.Invoice
+	err := row.Scan(
+		&i.ID, &i.Number, &i.Kind, &i.OrganizerID, &i.TournamentID, &i.ParticipantID,
+		&i.CreditedInvoiceID, &i.CreditedNumber, &i.SourceReference, &i.Seller, &i.Buyer,
+		&i.Lines, &i.Subtotal.Amount, &i.TaxLabel, &i.TaxRateBasisPoints, &i.Tax.Amount,
+		&i.Total.Amount, &i.Total.Currency, &i.PaymentMethod, &i.IssuedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	i.Subtotal.Currency, i.Tax.Currency = i.Total.Currency, i.Total.Currency
+	return &i, nil
+}
+
+// queryInvoices runs an invoice select and scans the rows
+func (r *InvoiceRepository) queryInvoices(ctx context.Context, query string, args ...interface{}) ([]*models.Invoice, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invoices := make([]*models.Invoice, 0)
+	for rows.Next() {
+		var i models.Invoice
+		err := rows.Scan(
+			&i.ID, &i.Number, &i.Kind, &i.OrganizerID, &i.TournamentID, &i.ParticipantID,
+			&i.CreditedInvoiceID, &i.CreditedNumber, &i.SourceReference, &i.Seller, &i.Buyer,
+			&i.Lines, &i.Subtotal.Amount, &i.TaxLabel, &i.TaxRateBasisPoints, &i.Tax.Amount,
+			&i.Total.Amount, &i.Total.Currency, &i.PaymentMethod, &i.IssuedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		i.Subtotal.Currency, i.Tax.Currency = i.Total.Currency, i.Total.Currency
+		invoices = append(invoices, &i)
+	}
+
+	return invoices, rows.Err()
+}
+
+// GetBillingProfile retrieves an organizer's billing profile, or nil if they have not set one
+func (r *InvoiceRepository) GetBillingProfile(ctx context.Context, userID string) (*models.BillingProfile, error) {
+	query := `
+		SELECT user_id, legal_name, address, tax_id, email, tax_label, tax_rate_basis_points, updated_at
+		FROM billing_profiles
+		WHERE user_id = ?
+	`
+
+	var p models.BillingProfile
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&p.UserID, &p.LegalName, &p.Address, &p.TaxID, &p.Email,
+		&p.TaxLabel, &p.TaxRateBasisPoints, &p.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// UpsertBillingProfile creates or replaces an organizer's billing profile
+func (r *InvoiceRepository) UpsertBillingProfile(ctx context.Context, profile *models.BillingProfile) error {
+	query := `
+		INSERT INTO billing_profiles (
+			user_id, legal_name, address, tax_id, email, tax_label, tax_rate_basis_points, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			legal_name = VALUES(legal_name),
+			address = VALUES(address),
+			tax_id = VALUES(tax_id),
+			email = VALUES(email),
+			tax_label = VALUES(tax_label),
+			tax_rate_basis_points = VALUES(tax_rate_basis_points),
+			updated_at = VALUES(updated_at)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		profile.UserID,
+		profile.LegalName,
+		profile.Address,
+		profile.TaxID,
+		profile.Email,
+		profile.TaxLabel,
+		profile.TaxRateBasisPoints,
+		profile.UpdatedAt,
+	)
+	return err
+}
@@ -0,0 +1,192 @@
+// internal/repositories/ledger_repository.go
+// Payment ledger data access layer
+
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"tournament-planner/internal/models"
+)
+
+// LedgerRepository handles payment ledger entries
+type LedgerRepository struct {
+	db *sql.DB
+}
+
+// NewLedgerRepository creates a new ledger repository
+func NewLedgerRepository(db *sql.DB) *LedgerRepository {
+	return &LedgerRepository{db: db}
+}
+
+// insertLedgerEntry is shared by Create and CreateWithTx
+const insertLedgerEntry = `
+	INSERT INTO payment_ledger_entries (
+		id, tournament_id, participant_id, payment_id, entry_type, amount_cents,
+		currency, provider_reference, description, created_by, created_at
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+// Create inserts a ledger entry
+func (r *LedgerRepository) Create(ctx context.Context, entry *models.LedgerEntry) error {
+	_, err := r.db.ExecContext(ctx, insertLedgerEntry, ledgerEntryArgs(entry)...)
+	return err
+}
+
+// CreateWithTx inserts a ledger entry within a transaction
+func (r *LedgerRepository) CreateWithTx(tx *sql.Tx, entry *models.LedgerEntry) error {
+	_, err := tx.ExecContext(context.Background(), insertLedgerEntry, ledgerEntryArgs(entry)...)
+	return err
+}
+
+// ListByParticipant retrieves a participant's ledger entries in order
+func (r *LedgerRepository) ListByParticipant(ctx context.Context, tournamentID, participantID string) ([]*models.LedgerEntry, error) {
+	query := `
+		SELECT id, tournament_id, participant_id, payment_id, entry_type, amount_cents,
+			currency, provider_reference, description, created_by, created_at
+		FROM payment_ledger_entries
+		WHERE tournament_id = ? AND participant_id = ?
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tournamentID, participantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]*models.LedgerEntry, 0)
+	for rows.Next() {
+		var e models.LedgerEntry
+		err := rows.Scan(
+			&e.ID, &e.TournamentID, &e.ParticipantID, &e.PaymentID, &e.Type, &e.Amount.Amount,
+			&e.Amount.Currency, &e.ProviderReference, &e.Description, &e.CreatedBy, &e.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &e)
+	}
+
+	return entries, rows.Err()
+}
+
+// BalancesByTournament totals the ledger of every participant with entries in a tournament
+func (r *LedgerRepository) BalancesByTournament(ctx context.Context, tournamentID string) (map[string]*models.LedgerBalance, error) {
+	query := `
+		SELECT participant_id, entry_type, currency, SUM(amount_cents)
+		FROM payment_ledger_entries
+		WHERE tournament_id = ?
+		GROUP BY participant_id, entry_type, currency
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	balances := make(map[string]*models.LedgerBalance)
+	for rows.Next() {
+		var participantID string
+		var entryType models.LedgerEntryType
+		var amount models.Money
+		if err := rows.Scan(&participantID, &entryType, &amount.Currency, &amount.Amount); err != nil {
+			return nil, err
+		}
+
+		balance, ok := balances[participantID]
+		if !ok {
+			empty := models.NewLedgerBalance(amount.Currency)
+			balance = &empty
+			balances[participantID] = balance
+		}
+		balance.Add(entryType, amount)
+	}
+
+	return balances, rows.Err()
+}
+
+// revenueSelect totals ledger entries by tournament, pricing tier, payment method
+// and entry type. Provider entries belong to a payment; a collection at the
+// venue is referenced by its charge, and its method is read as text so every
+// branch of the method CASE has the same type. Entries of withdrawn
+// participants have no registration left to take the tier from.
+const revenueSelect = `
+	SELECT e.tournament_id, t.name,
+		CASE
+			WHEN tp.participant_id IS NULL THEN 'withdrawn'
+			ELSE COALESCE(tp.price_tier, 'standard')
+		END AS price_tier,
+		CASE
+			WHEN e.payment_id IS NOT NULL THEN 'online'
+			WHEN op.id IS NOT NULL THEN CAST(op.method AS CHAR)
+			ELSE 'manual'
+		END AS method,
+		e.entry_type, e.currency, SUM(e.amount_cents)
+	FROM payment_ledger_entries e
+	JOIN tournaments t ON t.id = e.tournament_id
+	LEFT JOIN tournament_participants tp
+		ON tp.tournament_id = e.tournament_id AND tp.participant_id = e.participant_id
+	LEFT JOIN onsite_payments op ON op.id = e.provider_reference AND e.payment_id IS NULL
+`
+
+// revenueGroupBy groups revenueSelect rows, earliest tournament first
+const revenueGroupBy = `
+	GROUP BY t.start_date, e.tournament_id, t.name, price_tier, method, e.entry_type, e.currency
+	ORDER BY t.start_date, e.tournament_id, price_tier, method
+`
+
+// RevenueByTournament totals a tournament's ledger for its revenue report
+func (r *LedgerRepository) RevenueByTournament(ctx context.Context, tournamentID string) ([]*models.RevenueEntry, error) {
+	query := revenueSelect + `WHERE e.tournament_id = ?` + revenueGroupBy
+	return r.queryRevenue(ctx, query, tournamentID)
+}
+
+// RevenueByOrganizer totals the ledgers of all of an organizer's tournaments
+func (r *LedgerRepository) RevenueByOrganizer(ctx context.Context, organizerID string) ([]*models.RevenueEntry, error) {
+	query := revenueSelect + `WHERE t.organizer_id = ?` + revenueGroupBy
+	return r.queryRevenue(ctx, query, organizerID)
+}
+
+// queryRevenue runs a revenue select and scans the rows
+func (r *LedgerRepository) queryRevenue(ctx context.Context, query string, args ...interface{}) ([]*models.RevenueEntry, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]*models.RevenueEntry, 0)
+	for rows.Next() {
+		var e models.RevenueEntry
+		err := rows.Scan(
+			&e.TournamentID, &e.TournamentName, &e.PriceTier, &e.Method,
+			&e.Type, &e.Amount.Currency, &e.Amount.Amount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &e)
+	}
+
+	return entries, rows.Err()
+}
+
+// ledgerEntryArgs returns an entry's values in insertLedgerEntry order
+func ledgerEntryArgs(entry *models.LedgerEntry) []interface{} {
+	return []interface{}{
+		entry.ID,
+		entry.TournamentID,
+		entry.ParticipantID,
+		entry.PaymentID,
+		entry.Type,
+		entry.Amount.Amount,
+		entry.Amount.Currency,
+		entry.ProviderReference,
+		entry.Description,
+		entry.CreatedBy,
+		entry.CreatedAt,
+	}
+}
@@ -0,0 +1,93 @@
+// internal/repositories/match_reminder_repository.go
+// Data access for the record of match reminders sent
+
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"tournament-planner/internal/models"
+)
+
+// MatchReminderRepository handles the record of sent match reminders
+type MatchReminderRepository struct {
+	db *sql.DB
+}
+
+// NewMatchReminderRepository creates a new match reminder repository
+func NewMatchReminderRepository(db *sql.DB) *MatchReminderRepository {
+	return &MatchReminderRepository{db: db}
+}
+
+// RecordWithTx records a reminder within the transaction that queues it. It
+// reports false when the reminder was already recorded, in which case it must
+// not be queued again.
+func (r *MatchReminderRepository) RecordWithTx(tx *sql.Tx, matchID string, offsetMins int, scheduledFor time.Time) (bool, error) {
+	query := `
+		INSERT IGNORE INTO match_reminders (match_id, offset_minutes, scheduled_for)
+		VALUES (?, ?, ?)
+	`
+
+	result, err := tx.ExecContext(context.Background(), query, matchID, offsetMins, scheduledFor)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// ListStale retrieves one uncorrected reminder per match and superseded time for
+// matches that were rescheduled, unscheduled or called off after the reminder
+// went out. Reminders for times more than a day past are ignored.
+func (r *MatchReminderRepository) ListStale(ctx context.Context) ([]*models.MatchReminder, error) {
+	query := `
+		SELECT mr.match_id, m.tournament_id, MIN(mr.offset_minutes), mr.scheduled_for,
+			MIN(mr.created_at)
+		FROM match_reminders mr
+		JOIN matches m ON m.id = mr.match_id
+		WHERE mr.corrected_at IS NULL
+			AND mr.scheduled_for > DATE_SUB(NOW(), INTERVAL 1 DAY)
+			AND (
+				m.scheduled_datetime IS NULL
+				OR m.scheduled_datetime <> mr.scheduled_for
+				OR m.status IN ('cancelled', 'postponed')
+			)
+		GROUP BY mr.match_id, m.tournament_id, mr.scheduled_for
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reminders := make([]*models.MatchReminder, 0)
+	for rows.Next() {
+		var mr models.MatchReminder
+		if err := rows.Scan(&mr.MatchID, &mr.TournamentID, &mr.OffsetMins, &mr.ScheduledFor, &mr.CreatedAt); err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, &mr)
+	}
+
+	return reminders, rows.Err()
+}
+
+// MarkCorrectedWithTx marks the reminders for a superseded match time as
+// corrected. It reports false when another instance already corrected them.
+func (r *MatchReminderRepository) MarkCorrectedWithTx(tx *sql.Tx, matchID string, scheduledFor time.Time) (bool, error) {
+	query := `
+		UPDATE match_reminders
+		SET corrected_at = NOW()
+		WHERE match_id = ? AND scheduled_for = ? AND corrected_at IS NULL
+	`
+
+	result, err := tx.ExecContext(context.Background(), query, matchID, scheduledFor)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
@@ -113,7 +113,7 @@ func (r *MatchRepository) GetByID(ctx context.Context, id string) (*models.Match
 			id, tournament_id, round_number, match_number, stage, group_name,
 			participant1_id, participant2_id, winner_id, score1, score2,
 			score_details, status, scheduled_datetime, actual_start_time,
-			actual_end_time, venue_id, referee_id, next_match_id, notes,
+			actual_end_time, venue_id, referee_id, next_match_id, notes, play_epoch,
 			created_at, updated_at
 		FROM matches
 		WHERE id = ?
@@ -141,6 +141,7 @@ func (r *MatchRepository) GetByID(ctx context.Context, id string) (*models.Match
 		&match.RefereeID,
 		&match.NextMatchID,
 		&match.Notes,
+		&match.PlayEpoch,
 		&match.CreatedAt,
 		&match.UpdatedAt,
 	)
@@ -159,7 +160,7 @@ func (r *MatchRepository) GetByTournamentID(ctx context.Context, tournamentID st
 			id, tournament_id, round_number, match_number, stage, group_name,
 			participant1_id, participant2_id, winner_id, score1, score2,
 			score_details, status, scheduled_datetime, actual_start_time,
-			actual_end_time, venue_id, referee_id, next_match_id, notes,
+			actual_end_time, venue_id, referee_id, next_match_id, notes, play_epoch,
 			created_at, updated_at
 		FROM matches
 		WHERE tournament_id = ?
@@ -181,7 +182,7 @@ func (r *MatchRepository) GetByTournamentID(ctx context.Context, tournamentID st
 			&m.WinnerID, &m.Score1, &m.Score2, &m.ScoreDetails,
 			&m.Status, &m.ScheduledDatetime, &m.ActualStartTime,
 			&m.ActualEndTime, &m.VenueID, &m.RefereeID, &m.NextMatchID,
-			&m.Notes, &m.CreatedAt, &m.UpdatedAt,
+			&m.Notes, &m.PlayEpoch, &m.CreatedAt, &m.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -242,6 +243,123 @@ func (r *MatchRepository) UpdateStatus(ctx context.Context, id string, status mo
 	return err
 }
 
+// UpdateStatusWithTx updates match status within a transaction
+func (r *MatchRepository) UpdateStatusWithTx(tx *sql.Tx, id string, status models.MatchStatus) error {
+	query := `UPDATE matches SET status = ?, updated_at = NOW() WHERE id = ?`
+
+	// Update actual start time if match is starting
+	if status == models.MatchInProgress {
+		query = `UPDATE matches SET status = ?, actual_start_time = NOW(), updated_at = NOW() WHERE id = ?`
+	}
+
+	_, err := tx.ExecContext(context.Background(), query, status, id)
+	return err
+}
+
+// GetByIDForUpdateWithTx retrieves a match and locks its row for the rest of the transaction
+func (r *MatchRepository) GetByIDForUpdateWithTx(tx *sql.Tx, id string) (*models.Match, error) {
+	query := `
+		SELECT 
+			id, tournament_id, round_number, match_number, stage, group_name,
+			participant1_id, participant2_id, winner_id, score1, score2,
+			score_details, status, scheduled_datetime, actual_start_time,
+			actual_end_time, venue_id, referee_id, next_match_id, notes, play_epoch,
+			created_at, updated_at
+		FROM matches
+		WHERE id = ?
+		FOR UPDATE
+	`
+
+	var match models.Match
+	err := tx.QueryRowContext(context.Background(), query, id).Scan(
+		&match.ID,
+		&match.TournamentID,
+		&match.RoundNumber,
+		&match.MatchNumber,
+		&match.Stage,
+		&match.GroupName,
+		&match.Participant1ID,
+		&match.Participant2ID,
+		&match.WinnerID,
+		&match.Score1,
+		&match.Score2,
+		&match.ScoreDetails,
+		&match.Status,
+		&match.ScheduledDatetime,
+		&match.ActualStartTime,
+		&match.ActualEndTime,
+		&match.VenueID,
+		&match.RefereeID,
+		&match.NextMatchID,
+		&match.Notes,
+		&match.PlayEpoch,
+		&match.CreatedAt,
+		&match.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("match not found")
+	}
+
+	return &match, err
+}
+
+// UpdateScoreWithTx updates match score and status within a transaction
+func (r *MatchRepository) UpdateScoreWithTx(tx *sql.Tx, id string, score1, score2 int, winnerID string, scoreDetails *models.ScoreDetails) error {
+	query := `
+		UPDATE matches SET
+			score1 = ?, score2 = ?, winner_id = ?, score_details = ?,
+			status = ?, actual_end_time = COALESCE(actual_end_time, NOW()), updated_at = NOW()
+		WHERE id = ?
+	`
+
+	_, err := tx.ExecContext(context.Background(), query,
+		score1, score2, winnerID, scoreDetails,
+		models.MatchCompleted, id,
+	)
+
+	return err
+}
+
+// UpdateScheduleWithTx sets when and where a match is played and marks it scheduled within a transaction
+func (r *MatchRepository) UpdateScheduleWithTx(tx *sql.Tx, id string, scheduledTime time.Time, venueID *string) error {
+	query := `
+		UPDATE matches SET
+			scheduled_datetime = ?, venue_id = ?, status = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+
+	_, err := tx.ExecContext(context.Background(), query, scheduledTime, venueID, models.MatchScheduled, id)
+	return err
+}
+
+// UpdateParticipantsWithTx sets both participant slots of a match within a transaction
+func (r *MatchRepository) UpdateParticipantsWithTx(tx *sql.Tx, id string, participant1ID, participant2ID *string) error {
+	query := `
+		UPDATE matches SET
+			participant1_id = ?, participant2_id = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+
+	_, err := tx.ExecContext(context.Background(), query, participant1ID, participant2ID, id)
+	return err
+}
+
+// ClearResultWithTx removes a recorded result and puts the match back to the given status.
+// It starts a new play epoch, so live score events of the voided play no longer count.
+func (r *MatchRepository) ClearResultWithTx(tx *sql.Tx, id string, status models.MatchStatus, notes *string) error {
+	query := `
+		UPDATE matches SET
+			score1 = NULL, score2 = NULL, winner_id = NULL, score_details = NULL,
+			status = ?, actual_start_time = NULL, actual_end_time = NULL,
+			notes = ?, play_epoch = play_epoch + 1, updated_at = NOW()
+		WHERE id = ?
+	`
+
+	_, err := tx.ExecContext(context.Background(), query, status, notes, id)
+	return err
+}
+
 // GetNextMatch retrieves the next match in the bracket
 func (r *MatchRepository) GetNextMatch(ctx context.Context, matchID string) (*models.Match, error) {
 	query := `
@@ -257,7 +375,7 @@ func (r *MatchRepository) GetNextMatch(ctx context.Context, matchID string) (*mo
 		&match.WinnerID, &match.Score1, &match.Score2, &match.ScoreDetails,
 		&match.Status, &match.ScheduledDatetime, &match.ActualStartTime,
 		&match.ActualEndTime, &match.VenueID, &match.RefereeID, &match.NextMatchID,
-		&match.Notes, &match.CreatedAt, &match.UpdatedAt,
+		&match.Notes, &match.PlayEpoch, &match.CreatedAt, &match.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -267,6 +385,46 @@ func (r *MatchRepository) GetNextMatch(ctx context.Context, matchID string) (*mo
 	return &match, err
 }
 
+// ListScheduledBetween retrieves scheduled matches starting in [from, to)
+func (r *MatchRepository) ListScheduledBetween(ctx context.Context, from, to time.Time) ([]*models.Match, error) {
+	query := `
+		SELECT 
+			id, tournament_id, round_number, match_number, stage, group_name,
+			participant1_id, participant2_id, winner_id, score1, score2,
+			score_details, status, scheduled_datetime, actual_start_time,
+			actual_end_time, venue_id, referee_id, next_match_id, notes, play_epoch,
+			created_at, updated_at
+		FROM matches
+		WHERE status = ? AND scheduled_datetime >= ? AND scheduled_datetime < ?
+		ORDER BY scheduled_datetime
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, models.MatchScheduled, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	matches := make([]*models.Match, 0)
+	for rows.Next() {
+		var m models.Match
+		err := rows.Scan(
+			&m.ID, &m.TournamentID, &m.RoundNumber, &m.MatchNumber,
+			&m.Stage, &m.GroupName, &m.Participant1ID, &m.Participant2ID,
+			&m.WinnerID, &m.Score1, &m.Score2, &m.ScoreDetails,
+			&m.Status, &m.ScheduledDatetime, &m.ActualStartTime,
+			&m.ActualEndTime, &m.VenueID, &m.RefereeID, &m.NextMatchID,
+			&m.Notes, &m.PlayEpoch, &m.CreatedAt, &m.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, &m)
+	}
+
+	return matches, rows.Err()
+}
+
 // ListByVenueAndDate retrieves matches for a specific venue and date
 func (r *MatchRepository) ListByVenueAndDate(ctx context.Context, venueID string, date time.Time) ([]*models.Match, error) {
 	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
@@ -277,7 +435,7 @@ func (r *MatchRepository) ListByVenueAndDate(ctx context.Context, venueID string
 			id, tournament_id, round_number, match_number, stage, group_name,
 			participant1_id, participant2_id, winner_id, score1, score2,
 			score_details, status, scheduled_datetime, actual_start_time,
-			actual_end_time, venue_id, referee_id, next_match_id, notes,
+			actual_end_time, venue_id, referee_id, next_match_id, notes, play_epoch,
 			created_at, updated_at
 		FROM matches
 		WHERE venue_id = ? AND scheduled_datetime >= ? AND scheduled_datetime < ?
@@ -299,7 +457,7 @@ func (r *MatchRepository) ListByVenueAndDate(ctx context.Context, venueID string
 			&m.WinnerID, &m.Score1, &m.Score2, &m.ScoreDetails,
 			&m.Status, &m.ScheduledDatetime, &m.ActualStartTime,
 			&m.ActualEndTime, &m.VenueID, &m.RefereeID, &m.NextMatchID,
-			&m.Notes, &m.CreatedAt, &m.UpdatedAt,
+			&m.Notes, &m.PlayEpoch, &m.CreatedAt, &m.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
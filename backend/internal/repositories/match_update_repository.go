@@ -0,0 +1,84 @@
+// internal/repositories/match_update_repository.go
+// Match update log data access (MongoDB)
+
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"tournament-planner/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrDuplicateSequence is returned when another writer already used a sequence number
+var ErrDuplicateSequence = errors.New("duplicate match update sequence")
+
+// MatchUpdateRepository handles the match_updates log in MongoDB
+type MatchUpdateRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMatchUpdateRepository creates a new match update repository
+func NewMatchUpdateRepository(db *mongo.Database) *MatchUpdateRepository {
+	return &MatchUpdateRepository{
+		collection: db.Collection("match_updates"),
+	}
+}
+
+// scoreUpdateDocument is how a score event is stored in match_updates
+type scoreUpdateDocument struct {
+	UpdateType string               `bson:"update_type"`
+	NewValue   *models.ScoreDetails `bson:"new_value,omitempty"`
+	Event      models.ScoreEvent    `bson:",inline"`
+	OldValue   *models.ScoreDetails `bson:"old_value,omitempty"`
+}
+
+// AppendScoreEvent appends a score event to the log along with the score before and after it.
+// The (match_id, play_epoch, sequence) unique index turns concurrent writers into ErrDuplicateSequence.
+func (r *MatchUpdateRepository) AppendScoreEvent(ctx context.Context, event *models.ScoreEvent, before, after *models.ScoreDetails) error {
+	doc := scoreUpdateDocument{
+		UpdateType: "score",
+		NewValue:   after,
+		Event:      *event,
+		OldValue:   before,
+	}
+
+	_, err := r.collection.InsertOne(ctx, doc)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrDuplicateSequence
+	}
+	return err
+}
+
+// GetScoreEvents retrieves the score events of one play of a match in the order they
+// were recorded. Events of plays voided by a result correction are left out.
+func (r *MatchUpdateRepository) GetScoreEvents(ctx context.Context, matchID string, playEpoch int) ([]*models.ScoreEvent, error) {
+	filter := bson.M{"match_id": matchID, "update_type": "score", "play_epoch": playEpoch}
+	if playEpoch == 0 {
+		// Events logged before plays were numbered belong to the first play
+		filter["play_epoch"] = bson.M{"$in": bson.A{0, nil}}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "sequence", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := make([]*models.ScoreEvent, 0)
+	for cursor.Next(ctx) {
+		var doc scoreUpdateDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		event := doc.Event
+		events = append(events, &event)
+	}
+
+	return events, cursor.Err()
+}
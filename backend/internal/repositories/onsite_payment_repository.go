@@ -0,0 +1,79 @@
+// internal/repositories/onsite_payment_repository.go
+// Onsite entry fee collection data access layer
+
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"tournament-planner/internal/models"
+)
+
+// OnsitePaymentRepository handles entry fees collected at the venue
+type OnsitePaymentRepository struct {
+	db *sql.DB
+}
+
+// NewOnsitePaymentRepository creates a new onsite payment repository
+func NewOnsitePaymentRepository(db *sql.DB) *OnsitePaymentRepository {
+	return &OnsitePaymentRepository{db: db}
+}
+
+// CreateWithTx records a collection within a transaction
+func (r *OnsitePaymentRepository) CreateWithTx(tx *sql.Tx, payment *models.OnsitePayment) error {
+	query := `
+		INSERT INTO onsite_payments (
+			id, tournament_id, participant_id, amount_cents, currency, method,
+			note, collected_by, collected_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := tx.ExecContext(context.Background(), query,
+		payment.ID,
+		payment.TournamentID,
+		payment.ParticipantID,
+		payment.Amount.Amount,
+		payment.Amount.Currency,
+		payment.Method,
+		payment.Note,
+		payment.CollectedBy,
+		payment.CollectedAt,
+	)
+	return err
+}
+
+// ListCollected retrieves a tournament's collections in a time range, with the
+// name of the staff member who took each one
+func (r *OnsitePaymentRepository) ListCollected(ctx context.Context, tournamentID string, from, to time.Time) ([]*models.OnsitePayment, error) {
+	query := `
+		SELECT op.id, op.tournament_id, op.participant_id, op.amount_cents, op.currency,
+			op.method, op.note, op.collected_by, u.full_name, op.collected_at
+		FROM onsite_payments op
+		JOIN users u ON u.id = op.collected_by
+		WHERE op.tournament_id = ? AND op.collected_at >= ? AND op.collected_at < ?
+		ORDER BY op.collected_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tournamentID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payments := make([]*models.OnsitePayment, 0)
+	for rows.Next() {
+		var p models.OnsitePayment
+		err := rows.Scan(
+			&p.ID, &p.TournamentID, &p.ParticipantID, &p.Amount.Amount, &p.Amount.Currency,
+			&p.Method, &p.Note, &p.CollectedBy, &p.CollectedByName, &p.CollectedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, &p)
+	}
+
+	return payments, rows.Err()
+}
@@ -0,0 +1,180 @@
+// internal/repositories/outbox_repository.go
+// Notification outbox data access layer
+
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"tournament-planner/internal/models"
+)
+
+// OutboxRepository handles the notification outbox
+type OutboxRepository struct {
+	db *sql.DB
+}
+
+// NewOutboxRepository creates a new outbox repository
+func NewOutboxRepository(db *sql.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// outboxColumns is the column list shared by outbox queries
+const outboxColumns = `
+	id, idempotency_key, kind, payload, status, attempts,
+	next_attempt_at, last_error, delivered_to, created_at, delivered_at
+`
+
+// EnqueueWithTx queues a notification within the transaction that made the change.
+// An entry with the same idempotency key already queued is left as it is.
+func (r *OutboxRepository) EnqueueWithTx(tx *sql.Tx, entry *models.OutboxEntry) error {
+	query := `
+		INSERT IGNORE INTO notification_outbox (
+			id, idempotency_key, kind, payload, status, delivered_to
+		) VALUES (?, ?, ?, ?, 'pending', ?)
+	`
+
+	_, err := tx.ExecContext(context.Background(), query,
+		entry.ID, entry.IdempotencyKey, entry.Kind, entry.Payload, entry.DeliveredTo,
+	)
+	return err
+}
+
+// Enqueue queues a notification outside a transaction
+func (r *OutboxRepository) Enqueue(ctx context.Context, entry *models.OutboxEntry) error {
+	query := `
+		INSERT IGNORE INTO notification_outbox (
+			id, idempotency_key, kind, payload, status, delivered_to
+		) VALUES (?, ?, ?, ?, 'pending', ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		entry.ID, entry.IdempotencyKey, entry.Kind, entry.Payload, entry.DeliveredTo,
+	)
+	return err
+}
+
+// ClaimDue leases up to limit due entries to the caller's claim token and returns them.
+// A lease that runs out before the entry is settled makes it due again, so entries
+// claimed by an instance that crashed are picked up by another.
+func (r *OutboxRepository) ClaimDue(ctx context.Context, token string, limit int, lease time.Duration) ([]*models.OutboxEntry, error) {
+	claim := `
+		UPDATE notification_outbox
+		SET claim_token = ?, locked_until = DATE_ADD(NOW(), INTERVAL ? SECOND)
+		WHERE status = 'pending'
+			AND next_attempt_at <= NOW()
+			AND (locked_until IS NULL OR locked_until < NOW())
+		ORDER BY next_attempt_at
+		LIMIT ?
+	`
+	if _, err := r.db.ExecContext(ctx, claim, token, int(lease.Seconds()), limit); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + outboxColumns + `
+		FROM notification_outbox
+		WHERE claim_token = ? AND status = 'pending' AND locked_until >= NOW()
+		ORDER BY next_attempt_at
+	`
+	return r.query(ctx, query, token)
+}
+
+// MarkDelivered settles an entry whose every delivery succeeded
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, id string, deliveredTo models.DeliveryKeys) error {
+	query := `
+		UPDATE notification_outbox
+		SET status = 'delivered', delivered_to = ?, delivered_at = NOW(),
+			attempts = attempts + 1, locked_until = NULL, last_error = NULL
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query, deliveredTo, id)
+	return err
+}
+
+// MarkFailed records a failed attempt. The entry is retried after the delay,
+// or dead-lettered when dead is set.
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id string, deliveredTo models.DeliveryKeys, lastError string, delay time.Duration, dead bool) error {
+	status := models.OutboxPending
+	if dead {
+		status = models.OutboxDead
+	}
+
+	query := `
+		UPDATE notification_outbox
+		SET status = ?, delivered_to = ?, last_error = ?, attempts = attempts + 1,
+			next_attempt_at = DATE_ADD(NOW(), INTERVAL ? SECOND), locked_until = NULL
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query, status, deliveredTo, lastError, int(delay.Seconds()), id)
+	return err
+}
+
+// GetByID retrieves an outbox entry
+func (r *OutboxRepository) GetByID(ctx context.Context, id string) (*models.OutboxEntry, error) {
+	query := `SELECT ` + outboxColumns + ` FROM notification_outbox WHERE id = ?`
+
+	entries, err := r.query(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return entries[0], nil
+}
+
+// List retrieves outbox entries in a status, newest first
+func (r *OutboxRepository) List(ctx context.Context, status models.OutboxStatus, limit, offset int) ([]*models.OutboxEntry, error) {
+	query := `SELECT ` + outboxColumns + `
+		FROM notification_outbox
+		WHERE status = ?
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?
+	`
+	return r.query(ctx, query, status, limit, offset)
+}
+
+// Replay makes a dead entry due again with a fresh set of attempts.
+// Deliveries that already succeeded are still skipped.
+func (r *OutboxRepository) Replay(ctx context.Context, id string) (bool, error) {
+	query := `
+		UPDATE notification_outbox
+		SET status = 'pending', attempts = 0, next_attempt_at = NOW(), locked_until = NULL
+		WHERE id = ? AND status = 'dead'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// query runs an outbox select and scans the rows
+func (r *OutboxRepository) query(ctx context.Context, query string, args ...interface{}) ([]*models.OutboxEntry, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]*models.OutboxEntry, 0)
+	for rows.Next() {
+		var e models.OutboxEntry
+		err := rows.Scan(
+			&e.ID, &e.IdempotencyKey, &e.Kind, &e.Payload, &e.Status, &e.Attempts,
+			&e.NextAttemptAt, &e.LastError, &e.DeliveredTo, &e.CreatedAt, &e.DeliveredAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &e)
+	}
+
+	return entries, rows.Err()
+}
@@ -46,6 +46,31 @@ func (r *ParticipantRepository) Create(ctx context.Context, participant *models.
 	return err
 }
 
+// CreateWithTx inserts a new participant within a transaction
+func (r *ParticipantRepository) CreateWithTx(tx *sql.Tx, participant *models.Participant) error {
+	query := `
+		INSERT INTO participants (
+			id, user_id, name, type, contact_email, contact_phone,
+			total_matches_played, total_matches_won, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := tx.ExecContext(context.Background(), query,
+		participant.ID,
+		participant.UserID,
+		participant.Name,
+		participant.Type,
+		participant.ContactEmail,
+		participant.ContactPhone,
+		participant.TotalMatchesPlayed,
+		participant.TotalMatchesWon,
+		participant.CreatedAt,
+		participant.UpdatedAt,
+	)
+
+	return err
+}
+
 // GetByID retrieves a participant by ID
 func (r *ParticipantRepository) GetByID(ctx context.Context, id string) (*models.Participant, error) {
 	query := `
@@ -121,3 +146,18 @@ func (r *ParticipantRepository) UpdateStats(ctx context.Context, id string, matc
 	_, err := r.db.ExecContext(ctx, query, matchesPlayed, matchesWon, id)
 	return err
 }
+
+// UpdateStatsWithTx adjusts participant statistics within a transaction.
+// Negative values revert counts from results that were corrected.
+func (r *ParticipantRepository) UpdateStatsWithTx(tx *sql.Tx, id string, matchesPlayed, matchesWon int) error {
+	query := `
+		UPDATE participants SET
+			total_matches_played = GREATEST(total_matches_played + ?, 0),
+			total_matches_won = GREATEST(total_matches_won + ?, 0),
+			updated_at = NOW()
+		WHERE id = ?
+	`
+
+	_, err := tx.ExecContext(context.Background(), query, matchesPlayed, matchesWon, id)
+	return err
+}
@@ -6,8 +6,20 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"errors"
+
+	"tournament-planner/internal/models"
+
+	"github.com/go-sql-driver/mysql"
 )
 
+// ErrOpenPaymentExists is returned when a participant already has a payment that
+// is waiting for the payer or authorized
+var ErrOpenPaymentExists = errors.New("participant already has an open payment")
+
+// mysqlDuplicateEntry is the MySQL error number for a unique key violation
+const mysqlDuplicateEntry = 1062
+
 // PaymentRepository handles payment data access
 type PaymentRepository struct {
 	db *sql.DB
@@ -18,15 +30,198 @@ func NewPaymentRepository(db *sql.DB) *PaymentRepository {
 	return &PaymentRepository{db: db}
 }
 
-// CreatePaymentRecord creates a payment record
-func (r *PaymentRepository) CreatePaymentRecord(ctx context.Context, record map[string]interface{}) error {
-	// TODO: Implement payment record creation
-	// This would store Stripe payment intents, charges, etc.
-	return nil
+// paymentColumns is the column list shared by payment queries
+const paymentColumns = `
+	id, tournament_id, participant_id, provider, provider_payment_id, amount_cents,
+	amount_refunded_cents, currency, status, failure_message, overpayment, created_by, created_at, updated_at
+`
+
+// Create inserts a payment before it is started with the provider. The
+// uk_open_payment index turns a second open payment for the same participant
+// into ErrOpenPaymentExists.
+func (r *PaymentRepository) Create(ctx context.Context, payment *models.Payment) error {
+	query := `
+		INSERT INTO payments (
+			id, tournament_id, participant_id, provider, amount_cents, currency,
+			status, created_by, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		payment.ID,
+		payment.TournamentID,
+		payment.ParticipantID,
+		payment.Provider,
+		payment.Amount.Amount,
+		payment.Amount.Currency,
+		payment.Status,
+		payment.CreatedBy,
+		payment.CreatedAt,
+		payment.UpdatedAt,
+	)
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+		return ErrOpenPaymentExists
+	}
+	return err
+}
+
+// SetProviderPaymentID links a payment to the provider's payment once it is started
+func (r *PaymentRepository) SetProviderPaymentID(ctx context.Context, id, providerPaymentID string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE payments SET provider_payment_id = ? WHERE id = ?`, providerPaymentID, id)
+	return err
+}
+
+// MarkFailed records that a payment could not be started with the provider
+func (r *PaymentRepository) MarkFailed(ctx context.Context, id, message string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE payments SET status = 'failed', failure_message = ? WHERE id = ?`, message, id)
+	return err
+}
+
+// Cancel marks an open payment canceled. It returns false when the payment was no
+// longer open, because a provider event settled it in the meantime.
+func (r *PaymentRepository) Cancel(ctx context.Context, id string) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE payments SET status = 'canceled' WHERE id = ? AND status IN ('requires_payment', 'authorized')`, id)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// GetByID retrieves a payment by ID
+func (r *PaymentRepository) GetByID(ctx context.Context, id string) (*models.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE id = ?`
+	return r.queryPayment(r.db.QueryRowContext(ctx, query, id))
+}
+
+// GetLatestByParticipant retrieves a participant's most recent payment in a
+// status, leaving out overpayments
+func (r *PaymentRepository) GetLatestByParticipant(ctx context.Context, tournamentID, participantID string, status models.ChargeStatus) (*models.Payment, error) {
+	query := `SELECT ` + paymentColumns + `
+		FROM payments
+		WHERE tournament_id = ? AND participant_id = ? AND status = ? AND NOT overpayment
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+	return r.queryPayment(r.db.QueryRowContext(ctx, query, tournamentID, participantID, status))
+}
+
+// GetOpenByParticipant retrieves the participant's payment that is waiting for
+// the payer or authorized; there is at most one
+func (r *PaymentRepository) GetOpenByParticipant(ctx context.Context, tournamentID, participantID string) (*models.Payment, error) {
+	query := `SELECT ` + paymentColumns + `
+		FROM payments
+		WHERE tournament_id = ? AND participant_id = ? AND status IN ('requires_payment', 'authorized')
+	`
+	return r.queryPayment(r.db.QueryRowContext(ctx, query, tournamentID, participantID))
+}
+
+// ListOpenByTournament retrieves a tournament's payments that are waiting for the
+// payer or authorized
+func (r *PaymentRepository) ListOpenByTournament(ctx context.Context, tournamentID string) ([]*models.Payment, error) {
+	query := `SELECT ` + paymentColumns + `
+		FROM payments
+		WHERE tournament_id = ? AND status IN ('requires_payment', 'authorized')
+		ORDER BY created_at
+	`
+	return r.queryPayments(ctx, query, tournamentID)
+}
+
+// ListRefundable retrieves the settled payments of a tournament's remaining
+// participants that are not fully refunded. Withdrawn participants were already
+// refunded under the refund policy, and overpayments are refunded on their own.
+func (r *PaymentRepository) ListRefundable(ctx context.Context, tournamentID string) ([]*models.Payment, error) {
+	query := `SELECT ` + paymentColumns + `
+		FROM payments
+		WHERE tournament_id = ? AND status = 'succeeded' AND amount_refunded_cents < amount_cents
+			AND NOT overpayment
+			AND participant_id IN (SELECT participant_id FROM tournament_participants WHERE tournament_id = ?)
+		ORDER BY created_at
+	`
+	return r.queryPayments(ctx, query, tournamentID, tournamentID)
 }
 
-// GetByParticipant retrieves payment records for a participant
-func (r *PaymentRepository) GetByParticipant(ctx context.Context, tournamentID, participantID string) ([]map[string]interface{}, error) {
-	// TODO: Implement payment retrieval
-	return []map[string]interface{}{}, nil
+// GetByProviderPaymentIDWithTx retrieves and locks the payment a provider event is about
+func (r *PaymentRepository) GetByProviderPaymentIDWithTx(tx *sql.Tx, provider, providerPaymentID string) (*models.Payment, error) {
+	query := `SELECT ` + paymentColumns + `
+		FROM payments
+		WHERE provider = ? AND provider_payment_id = ?
+		FOR UPDATE
+	`
+	return r.queryPayment(tx.QueryRowContext(context.Background(), query, provider, providerPaymentID))
+}
+
+// UpdateStatusWithTx saves a payment's status, refunded amount, failure message
+// and whether it is an overpayment
+func (r *PaymentRepository) UpdateStatusWithTx(tx *sql.Tx, payment *models.Payment) error {
+	query := `
+		UPDATE payments
+		SET status = ?, amount_refunded_cents = ?, failure_message = ?, overpayment = ?
+		WHERE id = ?
+	`
+
+	_, err := tx.ExecContext(context.Background(), query,
+		payment.Status, payment.AmountRefunded.Amount, payment.FailureMessage, payment.Overpayment, payment.ID)
+	return err
+}
+
+// RecordEventWithTx marks a provider event as applied. It returns false when the
+// event was applied before, so redelivered webhooks change nothing.
+func (r *PaymentRepository) RecordEventWithTx(tx *sql.Tx, provider, eventID, eventType string) (bool, error) {
+	query := `INSERT IGNORE INTO payment_events (provider, event_id, event_type) VALUES (?, ?, ?)`
+
+	result, err := tx.ExecContext(context.Background(), query, provider, eventID, eventType)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// queryPayment scans a single payment row, returning nil when there is none
+func (r *PaymentRepository) queryPayment(row *sql.Row) (*models.Payment, error) {
+	var p models.Payment
+	err := row.Scan(
+		&p.ID, &p.TournamentID, &p.ParticipantID, &p.Provider, &p.ProviderPaymentID,
+		&p.Amount.Amount, &p.AmountRefunded.Amount, &p.Amount.Currency, &p.Status,
+		&p.FailureMessage, &p.Overpayment, &p.CreatedBy, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	p.AmountRefunded.Currency = p.Amount.Currency
+	return &p, nil
+}
+
+// queryPayments runs a payment select and scans the rows
+func (r *PaymentRepository) queryPayments(ctx context.Context, query string, args ...interface{}) ([]*models.Payment, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payments := make([]*models.Payment, 0)
+	for rows.Next() {
+		var p models.Payment
+		err := rows.Scan(
+			&p.ID, &p.TournamentID, &p.ParticipantID, &p.Provider, &p.ProviderPaymentID,
+			&p.Amount.Amount, &p.AmountRefunded.Amount, &p.Amount.Currency, &p.Status,
+			&p.FailureMessage, &p.Overpayment, &p.CreatedBy, &p.CreatedAt, &p.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		p.AmountRefunded.Currency = p.Amount.Currency
+		payments = append(payments, &p)
+	}
+
+	return payments, rows.Err()
 }
@@ -0,0 +1,50 @@
+// internal/repositories/referee_repository.go
+// Referee data access layer
+
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"tournament-planner/internal/models"
+)
+
+// RefereeRepository handles referee data access
+type RefereeRepository struct {
+	db *sql.DB
+}
+
+// NewRefereeRepository creates a new referee repository
+func NewRefereeRepository(db *sql.DB) *RefereeRepository {
+	return &RefereeRepository{db: db}
+}
+
+// GetByID retrieves a referee by ID
+func (r *RefereeRepository) GetByID(ctx context.Context, id string) (*models.Referee, error) {
+	query := `
+		SELECT id, user_id, organizer_id, name, certification_level,
+			contact_email, contact_phone, created_at
+		FROM referees
+		WHERE id = ?
+	`
+
+	var referee models.Referee
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&referee.ID,
+		&referee.UserID,
+		&referee.OrganizerID,
+		&referee.Name,
+		&referee.CertificationLevel,
+		&referee.ContactEmail,
+		&referee.ContactPhone,
+		&referee.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("referee not found")
+	}
+
+	return &referee, err
+}
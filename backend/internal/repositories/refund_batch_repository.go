@@ -0,0 +1,213 @@
+// internal/repositories/refund_batch_repository.go
+// Bulk refund batch and item data access layer
+
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"tournament-planner/internal/models"
+)
+
+// RefundBatchRepository handles refund batches and their items
+type RefundBatchRepository struct {
+	db *sql.DB
+}
+
+// NewRefundBatchRepository creates a new refund batch repository
+func NewRefundBatchRepository(db *sql.DB) *RefundBatchRepository {
+	return &RefundBatchRepository{db: db}
+}
+
+// refundItemColumns is the column list shared by item queries, which join the
+// item's batch for its kind
+const refundItemColumns = `
+	i.id, i.batch_id, i.participant_id, i.payment_id, b.kind, i.amount_cents, i.currency,
+	i.status, i.attempts, i.last_error, i.updated_at
+`
+
+// refundItemTables joins items to their batch
+const refundItemTables = `refund_batch_items i JOIN refund_batches b ON b.id = i.batch_id`
+
+// CreateWithTx inserts a batch within a transaction
+func (r *RefundBatchRepository) CreateWithTx(tx *sql.Tx, batch *models.RefundBatch) error {
+	query := `
+		INSERT INTO refund_batches (id, tournament_id, kind, reason, created_by, created_at, completed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := tx.ExecContext(context.Background(), query,
+		batch.ID, batch.TournamentID, batch.Kind, batch.Reason, batch.CreatedBy, batch.CreatedAt, batch.CompletedAt,
+	)
+	return err
+}
+
+// CreateItemWithTx inserts a batch item within a transaction
+func (r *RefundBatchRepository) CreateItemWithTx(tx *sql.Tx, item *models.RefundBatchItem) error {
+	query := `
+		INSERT INTO refund_batch_items (id, batch_id, participant_id, payment_id, amount_cents, currency, status)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := tx.ExecContext(context.Background(), query,
+		item.ID, item.BatchID, item.ParticipantID, item.PaymentID, item.Amount.Amount, item.Amount.Currency, item.Status,
+	)
+	return err
+}
+
+// ReopenWithTx marks a completed batch in progress again after an item was added
+func (r *RefundBatchRepository) ReopenWithTx(tx *sql.Tx, batchID string) error {
+	query := `UPDATE refund_batches SET completed_at = NULL WHERE id = ?`
+	_, err := tx.ExecContext(context.Background(), query, batchID)
+	return err
+}
+
+// GetLatestByTournament retrieves a tournament's most recent batch of a kind,
+// or nil if it has none
+func (r *RefundBatchRepository) GetLatestByTournament(ctx context.Context, tournamentID string, kind models.RefundBatchKind) (*models.RefundBatch, error) {
+	query := `
+		SELECT id, tournament_id, kind, reason, created_by, created_at, completed_at
+		FROM refund_batches
+		WHERE tournament_id = ? AND kind = ?
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	var b models.RefundBatch
+	err := r.db.QueryRowContext(ctx, query, tournamentID, kind).Scan(
+		&b.ID, &b.TournamentID, &b.Kind, &b.Reason, &b.CreatedBy, &b.CreatedAt, &b.CompletedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// Progress counts a batch's items by status
+func (r *RefundBatchRepository) Progress(ctx context.Context, batch *models.RefundBatch) (*models.RefundBatchProgress, error) {
+	query := `
+		SELECT status, currency, COUNT(*), SUM(amount_cents)
+		FROM refund_batch_items
+		WHERE batch_id = ?
+		GROUP BY status, currency
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, batch.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	progress := &models.RefundBatchProgress{Batch: batch}
+	for rows.Next() {
+		var status models.RefundItemStatus
+		var count int
+		var amount models.Money
+		if err := rows.Scan(&status, &amount.Currency, &count, &amount.Amount); err != nil {
+			return nil, err
+		}
+
+		progress.Total += count
+		progress.Amount = progress.Amount.Add(amount)
+		switch status {
+		case models.RefundItemPending:
+			progress.Pending += count
+		case models.RefundItemRequested:
+			progress.Requested += count
+		case models.RefundItemFailed:
+			progress.Failed += count
+		}
+	}
+
+	return progress, rows.Err()
+}
+
+// ListItems retrieves a batch's items
+func (r *RefundBatchRepository) ListItems(ctx context.Context, batchID string) ([]*models.RefundBatchItem, error) {
+	query := `SELECT ` + refundItemColumns + ` FROM ` + refundItemTables + ` WHERE i.batch_id = ? ORDER BY i.participant_id`
+	return r.queryItems(ctx, query, batchID)
+}
+
+// ClaimDueItems leases up to limit due pending items to the caller's claim token
+// and returns them. A lease that runs out makes the item due again.
+func (r *RefundBatchRepository) ClaimDueItems(ctx context.Context, token string, limit int, lease time.Duration) ([]*models.RefundBatchItem, error) {
+	claim := `
+		UPDATE refund_batch_items
+		SET claim_token = ?, locked_until = DATE_ADD(NOW(), INTERVAL ? SECOND)
+		WHERE status = 'pending'
+			AND next_attempt_at <= NOW()
+			AND (locked_until IS NULL OR locked_until < NOW())
+		ORDER BY next_attempt_at
+		LIMIT ?
+	`
+	if _, err := r.db.ExecContext(ctx, claim, token, int(lease.Seconds()), limit); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + refundItemColumns + `
+		FROM ` + refundItemTables + `
+		WHERE i.claim_token = ? AND i.status = 'pending' AND i.locked_until >= NOW()
+		ORDER BY i.next_attempt_at
+	`
+	return r.queryItems(ctx, query, token)
+}
+
+// RecordAttempt stores the outcome of a refund attempt. A pending item is
+// retried after the delay.
+func (r *RefundBatchRepository) RecordAttempt(ctx context.Context, item *models.RefundBatchItem, delay time.Duration) error {
+	query := `
+		UPDATE refund_batch_items
+		SET status = ?, attempts = ?, last_error = ?,
+			next_attempt_at = DATE_ADD(NOW(), INTERVAL ? SECOND), locked_until = NULL
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		item.Status, item.Attempts, item.LastError, int(delay.Seconds()), item.ID,
+	)
+	return err
+}
+
+// CompleteIfDone marks a batch completed once none of its items are pending
+func (r *RefundBatchRepository) CompleteIfDone(ctx context.Context, batchID string) error {
+	query := `
+		UPDATE refund_batches
+		SET completed_at = NOW()
+		WHERE id = ? AND completed_at IS NULL
+			AND NOT EXISTS (
+				SELECT 1 FROM refund_batch_items WHERE batch_id = ? AND status = 'pending'
+			)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, batchID, batchID)
+	return err
+}
+
+// queryItems runs an item select and scans the rows
+func (r *RefundBatchRepository) queryItems(ctx context.Context, query string, args ...interface{}) ([]*models.RefundBatchItem, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*models.RefundBatchItem, 0)
+	for rows.Next() {
+		var i models.RefundBatchItem
+		err := rows.Scan(
+			&i.ID, &i.BatchID, &i.ParticipantID, &i.PaymentID, &i.Kind, &i.Amount.Amount,
+			&i.Amount.Currency, &i.Status, &i.Attempts, &i.LastError, &i.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+
+	return items, rows.Err()
+}
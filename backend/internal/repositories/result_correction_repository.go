@@ -0,0 +1,86 @@
+// internal/repositories/result_correction_repository.go
+// Result correction audit trail data access layer
+
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"tournament-planner/internal/models"
+)
+
+// ResultCorrectionRepository handles result correction audit records
+type ResultCorrectionRepository struct {
+	db *sql.DB
+}
+
+// NewResultCorrectionRepository creates a new result correction repository
+func NewResultCorrectionRepository(db *sql.DB) *ResultCorrectionRepository {
+	return &ResultCorrectionRepository{db: db}
+}
+
+// CreateWithTx records a correction within the transaction that applied it
+func (r *ResultCorrectionRepository) CreateWithTx(tx *sql.Tx, correction *models.ResultCorrection) error {
+	query := `
+		INSERT INTO match_result_corrections (
+			id, match_id, tournament_id, corrected_by, reason,
+			previous_score1, previous_score2, previous_winner_id,
+			new_score1, new_score2, new_winner_id, affected_matches, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := tx.ExecContext(context.Background(), query,
+		correction.ID,
+		correction.MatchID,
+		correction.TournamentID,
+		correction.CorrectedBy,
+		correction.Reason,
+		correction.PreviousScore1,
+		correction.PreviousScore2,
+		correction.PreviousWinnerID,
+		correction.NewScore1,
+		correction.NewScore2,
+		correction.NewWinnerID,
+		correction.AffectedMatches,
+		correction.CreatedAt,
+	)
+
+	return err
+}
+
+// GetByMatchID retrieves the corrections made to a match, newest first
+func (r *ResultCorrectionRepository) GetByMatchID(ctx context.Context, matchID string) ([]*models.ResultCorrection, error) {
+	query := `
+		SELECT 
+			id, match_id, tournament_id, corrected_by, reason,
+			previous_score1, previous_score2, previous_winner_id,
+			new_score1, new_score2, new_winner_id, affected_matches, created_at
+		FROM match_result_corrections
+		WHERE match_id = ?
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, matchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	corrections := make([]*models.ResultCorrection, 0)
+	for rows.Next() {
+		var c models.ResultCorrection
+		err := rows.Scan(
+			&c.ID, &c.MatchID, &c.TournamentID, &c.CorrectedBy, &c.Reason,
+			&c.PreviousScore1, &c.PreviousScore2, &c.PreviousWinnerID,
+			&c.NewScore1, &c.NewScore2, &c.NewWinnerID, &c.AffectedMatches,
+			&c.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		corrections = append(corrections, &c)
+	}
+
+	return corrections, rows.Err()
+}
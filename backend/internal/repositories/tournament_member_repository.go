@@ -0,0 +1,82 @@
+// internal/repositories/tournament_member_repository.go
+// Tournament member list data access layer
+
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"tournament-planner/internal/models"
+)
+
+// TournamentMemberRepository handles the accounts an organizer lists as members
+type TournamentMemberRepository struct {
+	db *sql.DB
+}
+
+// NewTournamentMemberRepository creates a new tournament member repository
+func NewTournamentMemberRepository(db *sql.DB) *TournamentMemberRepository {
+	return &TournamentMemberRepository{db: db}
+}
+
+// Add lists an account as a member of a tournament
+func (r *TournamentMemberRepository) Add(ctx context.Context, member *models.TournamentMember) error {
+	query := `
+		INSERT INTO tournament_members (tournament_id, user_id, added_by, created_at)
+		VALUES (?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, member.TournamentID, member.UserID, member.AddedBy, member.CreatedAt)
+	return err
+}
+
+// Remove takes an account off a tournament's member list. It returns false if
+// the account was not listed.
+func (r *TournamentMemberRepository) Remove(ctx context.Context, tournamentID, userID string) (bool, error) {
+	query := `DELETE FROM tournament_members WHERE tournament_id = ? AND user_id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, tournamentID, userID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// IsMember checks whether an account is on a tournament's member list
+func (r *TournamentMemberRepository) IsMember(ctx context.Context, tournamentID, userID string) (bool, error) {
+	query := `SELECT COUNT(*) FROM tournament_members WHERE tournament_id = ? AND user_id = ?`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, tournamentID, userID).Scan(&count)
+	return count > 0, err
+}
+
+// ListByTournament retrieves a tournament's members with their account details
+func (r *TournamentMemberRepository) ListByTournament(ctx context.Context, tournamentID string) ([]*models.TournamentMember, error) {
+	query := `
+		SELECT m.tournament_id, m.user_id, u.email, u.full_name, m.added_by, m.created_at
+		FROM tournament_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.tournament_id = ?
+		ORDER BY u.full_name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := make([]*models.TournamentMember, 0)
+	for rows.Next() {
+		var m models.TournamentMember
+		if err := rows.Scan(&m.TournamentID, &m.UserID, &m.Email, &m.FullName, &m.AddedBy, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, &m)
+	}
+
+	return members, rows.Err()
+}
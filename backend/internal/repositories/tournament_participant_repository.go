@@ -38,21 +38,88 @@ func (r *TournamentParticipantRepository) Create(ctx context.Context, tournament
 	return err
 }
 
+// CreateWithTx registers a participant in a tournament at a quoted price, with
+// the payment status, method and due date set on the participant
+func (r *TournamentParticipantRepository) CreateWithTx(tx *sql.Tx, tournamentID string, participant *models.Participant, quote *models.PriceQuote) error {
+	registrationDataJSON, err := json.Marshal(participant.RegistrationData)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO tournament_participants (
+			tournament_id, participant_id, payment_status, payment_method, payment_due_at,
+			price_tier, base_amount_cents, discount_code_id, discount_cents, amount_due_cents,
+			registration_data, registered_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW())
+	`
+
+	_, err = tx.ExecContext(context.Background(), query,
+		tournamentID, participant.ID, participant.PaymentStatus, participant.PaymentMethod,
+		participant.PaymentDueAt, quote.Tier, quote.Base.Amount, quote.DiscountCodeID,
+		quote.Discount.Amount, quote.AmountDue.Amount, registrationDataJSON,
+	)
+	return err
+}
+
+// registrationColumns selects a participant together with its registration
+const registrationColumns = `
+	p.id, p.user_id, p.name, p.type, p.contact_email, p.contact_phone,
+	p.total_matches_played, p.total_matches_won, p.created_at, p.updated_at,
+	tp.seed, tp.division, tp.group_name, tp.payment_status, tp.payment_method,
+	tp.payment_due_at, tp.price_tier, tp.base_amount_cents, tp.discount_cents,
+	tp.amount_due_cents, tp.checked_in, tp.registration_data
+`
+
 // GetByTournamentID retrieves all participants for a tournament
 func (r *TournamentParticipantRepository) GetByTournamentID(ctx context.Context, tournamentID string) ([]*models.Participant, error) {
 	query := `
-		SELECT 
-			p.id, p.user_id, p.name, p.type, p.contact_email, p.contact_phone,
-			p.total_matches_played, p.total_matches_won, p.created_at, p.updated_at,
-			tp.seed, tp.division, tp.group_name, tp.payment_status, tp.checked_in,
-			tp.registration_data
+		SELECT ` + registrationColumns + `
 		FROM participants p
 		JOIN tournament_participants tp ON p.id = tp.participant_id
 		WHERE tp.tournament_id = ?
 		ORDER BY tp.seed, p.name
 	`
+	return r.queryParticipants(ctx, query, tournamentID)
+}
 
-	rows, err := r.db.QueryContext(ctx, query, tournamentID)
+// Get retrieves one participant's registration in a tournament, or nil if not registered
+func (r *TournamentParticipantRepository) Get(ctx context.Context, tournamentID, participantID string) (*models.Participant, error) {
+	query := `
+		SELECT ` + registrationColumns + `
+		FROM participants p
+		JOIN tournament_participants tp ON p.id = tp.participant_id
+		WHERE tp.tournament_id = ? AND tp.participant_id = ?
+	`
+
+	participants, err := r.queryParticipants(ctx, query, tournamentID, participantID)
+	if err != nil || len(participants) == 0 {
+		return nil, err
+	}
+	return participants[0], nil
+}
+
+// GetByUser retrieves the registration a user holds in a tournament through one
+// of their participants, or nil if they are not registered
+func (r *TournamentParticipantRepository) GetByUser(ctx context.Context, tournamentID, userID string) (*models.Participant, error) {
+	query := `
+		SELECT ` + registrationColumns + `
+		FROM participants p
+		JOIN tournament_participants tp ON p.id = tp.participant_id
+		WHERE tp.tournament_id = ? AND p.user_id = ?
+		LIMIT 1
+	`
+
+	participants, err := r.queryParticipants(ctx, query, tournamentID, userID)
+	if err != nil || len(participants) == 0 {
+		return nil, err
+	}
+	return participants[0], nil
+}
+
+// queryParticipants runs a registration select and scans the rows
+func (r *TournamentParticipantRepository) queryParticipants(ctx context.Context, query string, args ...interface{}) ([]*models.Participant, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -67,8 +134,9 @@ func (r *TournamentParticipantRepository) GetByTournamentID(ctx context.Context,
 			&p.ID, &p.UserID, &p.Name, &p.Type, &p.ContactEmail,
 			&p.ContactPhone, &p.TotalMatchesPlayed, &p.TotalMatchesWon,
 			&p.CreatedAt, &p.UpdatedAt, &p.Seed, &p.Division,
-			&p.GroupName, &p.PaymentStatus, &p.CheckedIn,
-			&registrationDataJSON,
+			&p.GroupName, &p.PaymentStatus, &p.PaymentMethod,
+			&p.PaymentDueAt, &p.PriceTier, &p.BaseAmountCents, &p.DiscountCents,
+			&p.AmountDueCents, &p.CheckedIn, &registrationDataJSON,
 		)
 		if err != nil {
 			return nil, err
@@ -84,7 +152,7 @@ func (r *TournamentParticipantRepository) GetByTournamentID(ctx context.Context,
 		participants = append(participants, &p)
 	}
 
-	return participants, nil
+	return participants, rows.Err()
 }
 
 // UpdateSeed updates participant seeding
@@ -111,6 +179,36 @@ func (r *TournamentParticipantRepository) UpdatePaymentStatus(ctx context.Contex
 	return err
 }
 
+// UpdatePaymentStatusWithTx updates payment status within a transaction
+func (r *TournamentParticipantRepository) UpdatePaymentStatusWithTx(tx *sql.Tx, tournamentID, participantID string, status models.PaymentStatus) error {
+	query := `
+		UPDATE tournament_participants
+		SET payment_status = ?
+		WHERE tournament_id = ? AND participant_id = ?
+	`
+
+	_, err := tx.ExecContext(context.Background(), query, status, tournamentID, participantID)
+	return err
+}
+
+// UpdatePaymentStatusFromWithTx moves a participant's payment status from one
+// status to another within a transaction. It returns false when the status was
+// no longer the expected one.
+func (r *TournamentParticipantRepository) UpdatePaymentStatusFromWithTx(tx *sql.Tx, tournamentID, participantID string, from, to models.PaymentStatus) (bool, error) {
+	query := `
+		UPDATE tournament_participants
+		SET payment_status = ?
+		WHERE tournament_id = ? AND participant_id = ? AND payment_status = ?
+	`
+
+	result, err := tx.ExecContext(context.Background(), query, to, tournamentID, participantID, from)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
 // Delete removes a participant from a tournament
 func (r *TournamentParticipantRepository) Delete(ctx context.Context, tournamentID, participantID string) error {
 	query := `DELETE FROM tournament_participants WHERE tournament_id = ? AND participant_id = ?`
@@ -118,6 +216,18 @@ func (r *TournamentParticipantRepository) Delete(ctx context.Context, tournament
 	return err
 }
 
+// DeleteWithTx removes a participant from a tournament within a transaction.
+// It returns false when the participant was not registered.
+func (r *TournamentParticipantRepository) DeleteWithTx(tx *sql.Tx, tournamentID, participantID string) (bool, error) {
+	query := `DELETE FROM tournament_participants WHERE tournament_id = ? AND participant_id = ?`
+	result, err := tx.ExecContext(context.Background(), query, tournamentID, participantID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
 // CheckIn marks a participant as checked in
 func (r *TournamentParticipantRepository) CheckIn(ctx context.Context, tournamentID, participantID string) error {
 	query := `
@@ -129,3 +239,31 @@ func (r *TournamentParticipantRepository) CheckIn(ctx context.Context, tournamen
 	_, err := r.db.ExecContext(ctx, query, tournamentID, participantID)
 	return err
 }
+
+// CheckInWithTx marks a participant as checked in within a transaction
+func (r *TournamentParticipantRepository) CheckInWithTx(tx *sql.Tx, tournamentID, participantID string) error {
+	query := `
+		UPDATE tournament_participants 
+		SET checked_in = TRUE 
+		WHERE tournament_id = ? AND participant_id = ?
+	`
+
+	_, err := tx.ExecContext(context.Background(), query, tournamentID, participantID)
+	return err
+}
+
+// IsRegisteredUser checks if a user is registered in a tournament through one of their participants
+func (r *TournamentParticipantRepository) IsRegisteredUser(ctx context.Context, tournamentID, userID string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1
+			FROM tournament_participants tp
+			JOIN participants p ON p.id = tp.participant_id
+			WHERE tp.tournament_id = ? AND p.user_id = ?
+		)
+	`
+
+	var registered bool
+	err := r.db.QueryRowContext(ctx, query, tournamentID, userID).Scan(&registered)
+	return registered, err
+}
@@ -30,10 +30,11 @@ func (r *TournamentRepository) Create(ctx context.Context, tournament *models.To
 			id, organizer_id, name, description, sport_id, format_type,
 			format_config, start_date, end_date, timezone, max_matches_per_day,
 			operational_hours, avg_match_duration, buffer_time, registration_deadline,
-			entry_fee, allow_onsite_payment, capacity_limit, current_participants,
+			entry_fee_cents, currency, allow_onsite_payment, require_paid_checkin, capture_entry_fees_later, pricing, refund_policy,
+			capacity_limit, current_participants,
 			status, is_public, custom_fields, created_at, updated_at
 		) VALUES (
-			?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+			?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 		)
 	`
 
@@ -59,8 +60,13 @@ func (r *TournamentRepository) Create(ctx context.Context, tournament *models.To
 		tournament.AvgMatchDuration,
 		tournament.BufferTime,
 		tournament.RegistrationDeadline,
-		tournament.EntryFee,
+		tournament.EntryFee.Amount,
+		tournament.Currency,
 		tournament.AllowOnsitePayment,
+		tournament.RequirePaidCheckIn,
+		tournament.CaptureEntryFeesLater,
+		tournament.Pricing,
+		tournament.RefundPolicy,
 		tournament.CapacityLimit,
 		tournament.CurrentParticipants,
 		tournament.Status,
@@ -80,10 +86,11 @@ func (r *TournamentRepository) CreateWithTx(tx *sql.Tx, tournament *models.Tourn
 			id, organizer_id, name, description, sport_id, format_type,
 			format_config, start_date, end_date, timezone, max_matches_per_day,
 			operational_hours, avg_match_duration, buffer_time, registration_deadline,
-			entry_fee, allow_onsite_payment, capacity_limit, current_participants,
+			entry_fee_cents, currency, allow_onsite_payment, require_paid_checkin, capture_entry_fees_later, pricing, refund_policy,
+			capacity_limit, current_participants,
 			status, is_public, custom_fields, created_at, updated_at
 		) VALUES (
-			?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+			?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 		)
 	`
 
@@ -108,8 +115,13 @@ func (r *TournamentRepository) CreateWithTx(tx *sql.Tx, tournament *models.Tourn
 		tournament.AvgMatchDuration,
 		tournament.BufferTime,
 		tournament.RegistrationDeadline,
-		tournament.EntryFee,
+		tournament.EntryFee.Amount,
+		tournament.Currency,
 		tournament.AllowOnsitePayment,
+		tournament.RequirePaidCheckIn,
+		tournament.CaptureEntryFeesLater,
+		tournament.Pricing,
+		tournament.RefundPolicy,
 		tournament.CapacityLimit,
 		tournament.CurrentParticipants,
 		tournament.Status,
@@ -129,7 +141,8 @@ func (r *TournamentRepository) GetByID(ctx context.Context, id string) (*models.
 			id, organizer_id, name, description, sport_id, format_type,
 			format_config, start_date, end_date, timezone, max_matches_per_day,
 			operational_hours, avg_match_duration, buffer_time, registration_deadline,
-			entry_fee, allow_onsite_payment, capacity_limit, current_participants,
+			entry_fee_cents, currency, allow_onsite_payment, require_paid_checkin, capture_entry_fees_later, pricing, refund_policy,
+			capacity_limit, current_participants,
 			status, is_public, custom_fields, created_at, updated_at
 		FROM tournaments
 		WHERE id = ?
@@ -154,8 +167,13 @@ func (r *TournamentRepository) GetByID(ctx context.Context, id string) (*models.
 		&tournament.AvgMatchDuration,
 		&tournament.BufferTime,
 		&tournament.RegistrationDeadline,
-		&tournament.EntryFee,
+		&tournament.EntryFee.Amount,
+		&tournament.Currency,
 		&tournament.AllowOnsitePayment,
+		&tournament.RequirePaidCheckIn,
+		&tournament.CaptureEntryFeesLater,
+		&tournament.Pricing,
+		&tournament.RefundPolicy,
 		&tournament.CapacityLimit,
 		&tournament.CurrentParticipants,
 		&tournament.Status,
@@ -171,6 +189,7 @@ func (r *TournamentRepository) GetByID(ctx context.Context, id string) (*models.
 	if err != nil {
 		return nil, err
 	}
+	tournament.EntryFee.Currency = tournament.Currency
 
 	// Unmarshal custom fields
 	if len(customFieldsJSON) > 0 {
@@ -203,8 +222,9 @@ func (r *TournamentRepository) Update(ctx context.Context, tournament *models.To
 			name = ?, description = ?, sport_id = ?, format_type = ?,
 			format_config = ?, start_date = ?, end_date = ?, timezone = ?,
 			max_matches_per_day = ?, operational_hours = ?, avg_match_duration = ?,
-			buffer_time = ?, registration_deadline = ?, entry_fee = ?,
-			allow_onsite_payment = ?, capacity_limit = ?, status = ?,
+			buffer_time = ?, registration_deadline = ?, entry_fee_cents = ?, currency = ?,
+			allow_onsite_payment = ?, require_paid_checkin = ?, capture_entry_fees_later = ?, pricing = ?, refund_policy = ?,
+			capacity_limit = ?, status = ?,
 			is_public = ?, custom_fields = ?, updated_at = NOW()
 		WHERE id = ?
 	`
@@ -228,8 +248,13 @@ func (r *TournamentRepository) Update(ctx context.Context, tournament *models.To
 		tournament.AvgMatchDuration,
 		tournament.BufferTime,
 		tournament.RegistrationDeadline,
-		tournament.EntryFee,
+		tournament.EntryFee.Amount,
+		tournament.Currency,
 		tournament.AllowOnsitePayment,
+		tournament.RequirePaidCheckIn,
+		tournament.CaptureEntryFeesLater,
+		tournament.Pricing,
+		tournament.RefundPolicy,
 		tournament.CapacityLimit,
 		tournament.Status,
 		tournament.IsPublic,
@@ -286,7 +311,8 @@ func (r *TournamentRepository) List(ctx context.Context, filter ListFilter) ([]*
 			id, organizer_id, name, description, sport_id, format_type,
 			format_config, start_date, end_date, timezone, max_matches_per_day,
 			operational_hours, avg_match_duration, buffer_time, registration_deadline,
-			entry_fee, allow_onsite_payment, capacity_limit, current_participants,
+			entry_fee_cents, currency, allow_onsite_payment, require_paid_checkin, capture_entry_fees_later, pricing, refund_policy,
+			capacity_limit, current_participants,
 			status, is_public, custom_fields, created_at, updated_at
 		` + baseQuery + " ORDER BY created_at DESC LIMIT ? OFFSET ?"
 
@@ -311,13 +337,14 @@ func (r *TournamentRepository) List(ctx context.Context, filter ListFilter) ([]*
 			&t.FormatType, &t.FormatConfig, &t.StartDate, &t.EndDate,
 			&t.Timezone, &t.MaxMatchesPerDay, &t.OperationalHours,
 			&t.AvgMatchDuration, &t.BufferTime, &t.RegistrationDeadline,
-			&t.EntryFee, &t.AllowOnsitePayment, &t.CapacityLimit,
-			&t.CurrentParticipants, &t.Status, &t.IsPublic,
+			&t.EntryFee.Amount, &t.Currency, &t.AllowOnsitePayment, &t.RequirePaidCheckIn, &t.CaptureEntryFeesLater,
+			&t.Pricing, &t.RefundPolicy, &t.CapacityLimit, &t.CurrentParticipants, &t.Status, &t.IsPublic,
 			&customFieldsJSON, &t.CreatedAt, &t.UpdatedAt,
 		)
 		if err != nil {
 			return nil, 0, err
 		}
+		t.EntryFee.Currency = t.Currency
 
 		// Unmarshal custom fields
 		if len(customFieldsJSON) > 0 {
@@ -339,6 +366,23 @@ func (r *TournamentRepository) UpdateStatusWithTx(tx *sql.Tx, id string, status
 	return err
 }
 
+// GetStatusForUpdateWithTx retrieves and locks a tournament's status, so it can't
+// be cancelled until the transaction ends
+func (r *TournamentRepository) GetStatusForUpdateWithTx(tx *sql.Tx, id string) (models.TournamentStatus, error) {
+	query := `SELECT status FROM tournaments WHERE id = ? FOR UPDATE`
+
+	var status models.TournamentStatus
+	err := tx.QueryRowContext(context.Background(), query, id).Scan(&status)
+	return status, err
+}
+
+// PublishWithTx opens a tournament for registration and makes it public within a transaction
+func (r *TournamentRepository) PublishWithTx(tx *sql.Tx, id string) error {
+	query := `UPDATE tournaments SET status = ?, is_public = TRUE, updated_at = NOW() WHERE id = ?`
+	_, err := tx.ExecContext(context.Background(), query, models.StatusRegistrationOpen, id)
+	return err
+}
+
 // IncrementParticipants increments the participant count
 func (r *TournamentRepository) IncrementParticipants(ctx context.Context, id string) error {
 	query := `UPDATE tournaments SET current_participants = current_participants + 1 WHERE id = ?`
@@ -346,6 +390,21 @@ func (r *TournamentRepository) IncrementParticipants(ctx context.Context, id str
 	return err
 }
 
+// IncrementParticipantsWithTx takes a place in a tournament within a transaction.
+// It returns false when the tournament is already full.
+func (r *TournamentRepository) IncrementParticipantsWithTx(tx *sql.Tx, id string) (bool, error) {
+	query := `
+		UPDATE tournaments SET current_participants = current_participants + 1
+		WHERE id = ? AND current_participants < capacity_limit
+	`
+	result, err := tx.ExecContext(context.Background(), query, id)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
 // DecrementParticipants decrements the participant count
 func (r *TournamentRepository) DecrementParticipants(ctx context.Context, id string) error {
 	query := `UPDATE tournaments SET current_participants = current_participants - 1 WHERE id = ? AND current_participants > 0`
@@ -353,6 +412,13 @@ func (r *TournamentRepository) DecrementParticipants(ctx context.Context, id str
 	return err
 }
 
+// DecrementParticipantsWithTx frees a place in a tournament within a transaction
+func (r *TournamentRepository) DecrementParticipantsWithTx(tx *sql.Tx, id string) error {
+	query := `UPDATE tournaments SET current_participants = current_participants - 1 WHERE id = ? AND current_participants > 0`
+	_, err := tx.ExecContext(context.Background(), query, id)
+	return err
+}
+
 // ListFilter defines filtering options for tournament queries
 type ListFilter struct {
 	Page        int
@@ -362,3 +428,19 @@ type ListFilter struct {
 	Public      bool
 	Search      string
 }
+
+// IsStaff checks if a user is one of the tournament organizer's referees
+func (r *TournamentRepository) IsStaff(ctx context.Context, tournamentID, userID string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1
+			FROM referees r
+			JOIN tournaments t ON t.organizer_id = r.organizer_id
+			WHERE t.id = ? AND r.user_id = ?
+		)
+	`
+
+	var staff bool
+	err := r.db.QueryRowContext(ctx, query, tournamentID, userID).Scan(&staff)
+	return staff, err
+}
@@ -0,0 +1,241 @@
+// internal/repositories/webhook_repository.go
+// Organizer webhook and delivery log data access layer
+
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"tournament-planner/internal/models"
+)
+
+// WebhookRepository handles webhooks and their deliveries
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// webhookColumns is the column list shared by webhook queries
+const webhookColumns = `
+	id, tournament_id, url, secret, event_types, is_active, created_by, created_at, updated_at
+`
+
+// deliveryColumns is the column list shared by delivery queries
+const deliveryColumns = `
+	id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+	response_status, duration_ms, last_error, created_at, delivered_at
+`
+
+// Create inserts a new webhook
+func (r *WebhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	query := `
+		INSERT INTO webhooks (
+			id, tournament_id, url, secret, event_types, is_active, created_by, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		webhook.ID,
+		webhook.TournamentID,
+		webhook.URL,
+		webhook.Secret,
+		webhook.EventTypes,
+		webhook.IsActive,
+		webhook.CreatedBy,
+		webhook.CreatedAt,
+		webhook.UpdatedAt,
+	)
+	return err
+}
+
+// GetByID retrieves a webhook by ID
+func (r *WebhookRepository) GetByID(ctx context.Context, id string) (*models.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = ?`
+
+	webhooks, err := r.queryWebhooks(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(webhooks) == 0 {
+		return nil, nil
+	}
+	return webhooks[0], nil
+}
+
+// ListByTournament retrieves all webhooks of a tournament
+func (r *WebhookRepository) ListByTournament(ctx context.Context, tournamentID string) ([]*models.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE tournament_id = ? ORDER BY created_at`
+	return r.queryWebhooks(ctx, query, tournamentID)
+}
+
+// Update saves a webhook's URL, event filter and active flag
+func (r *WebhookRepository) Update(ctx context.Context, webhook *models.Webhook) error {
+	query := `
+		UPDATE webhooks SET url = ?, event_types = ?, is_active = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query, webhook.URL, webhook.EventTypes, webhook.IsActive, webhook.ID)
+	return err
+}
+
+// Delete removes a webhook and its delivery log
+func (r *WebhookRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = ?`, id)
+	return err
+}
+
+// eventDeliveriesInsert queues one delivery of an event for every active webhook
+// of the tournament whose filter lets the event type through
+const eventDeliveriesInsert = `
+	INSERT INTO webhook_deliveries (
+		id, webhook_id, event_id, event_type, payload, status, next_attempt_at
+	)
+	SELECT UUID(), id, ?, ?, ?, 'pending', NOW()
+	FROM webhooks
+	WHERE tournament_id = ? AND is_active = TRUE
+		AND (event_types IS NULL OR JSON_LENGTH(event_types) = 0
+			OR JSON_CONTAINS(event_types, JSON_QUOTE(?)))
+`
+
+// CreateEventDeliveriesWithTx queues deliveries of an event within the transaction
+// that made the change, so they exist if and only if the change commits
+func (r *WebhookRepository) CreateEventDeliveriesWithTx(tx *sql.Tx, tournamentID, eventID, eventType string, payload []byte) error {
+	_, err := tx.ExecContext(context.Background(), eventDeliveriesInsert,
+		eventID, eventType, payload, tournamentID, eventType,
+	)
+	return err
+}
+
+// CreateEventDeliveries queues deliveries of an event for changes made outside a transaction
+func (r *WebhookRepository) CreateEventDeliveries(ctx context.Context, tournamentID, eventID, eventType string, payload []byte) error {
+	_, err := r.db.ExecContext(ctx, eventDeliveriesInsert,
+		eventID, eventType, payload, tournamentID, eventType,
+	)
+	return err
+}
+
+// CreateDelivery queues a delivery
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (
+			id, webhook_id, event_id, event_type, payload, status, next_attempt_at
+		) VALUES (?, ?, ?, ?, ?, ?, NOW())
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		delivery.ID, delivery.WebhookID, delivery.EventID, delivery.EventType,
+		[]byte(delivery.Payload), delivery.Status,
+	)
+	return err
+}
+
+// ClaimDueDeliveries leases up to limit due deliveries to the caller's claim token
+// and returns them. A lease that runs out makes the delivery due again.
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, token string, limit int, lease time.Duration) ([]*models.WebhookDelivery, error) {
+	claim := `
+		UPDATE webhook_deliveries
+		SET claim_token = ?, locked_until = DATE_ADD(NOW(), INTERVAL ? SECOND)
+		WHERE status = 'pending'
+			AND next_attempt_at <= NOW()
+			AND (locked_until IS NULL OR locked_until < NOW())
+		ORDER BY next_attempt_at
+		LIMIT ?
+	`
+	if _, err := r.db.ExecContext(ctx, claim, token, int(lease.Seconds()), limit); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries
+		WHERE claim_token = ? AND status = 'pending' AND locked_until >= NOW()
+		ORDER BY next_attempt_at
+	`
+	return r.queryDeliveries(ctx, query, token)
+}
+
+// RecordAttempt stores the outcome of a delivery attempt. A failed delivery is
+// retried after the delay unless its status is dead.
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery, delay time.Duration) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = ?, response_status = ?, duration_ms = ?,
+			last_error = ?, next_attempt_at = DATE_ADD(NOW(), INTERVAL ? SECOND), locked_until = NULL,
+			delivered_at = IF(? = 'delivered', NOW(), delivered_at)
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		delivery.Status, delivery.Attempts, delivery.ResponseStatus,
+		delivery.DurationMs, delivery.LastError, int(delay.Seconds()), delivery.Status, delivery.ID,
+	)
+	return err
+}
+
+// ListDeliveries retrieves the delivery log of a webhook, newest first
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, webhookID string, limit, offset int) ([]*models.WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries
+		WHERE webhook_id = ?
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?
+	`
+	return r.queryDeliveries(ctx, query, webhookID, limit, offset)
+}
+
+// queryWebhooks runs a webhook select and scans the rows
+func (r *WebhookRepository) queryWebhooks(ctx context.Context, query string, args ...interface{}) ([]*models.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := make([]*models.Webhook, 0)
+	for rows.Next() {
+		var w models.Webhook
+		err := rows.Scan(
+			&w.ID, &w.TournamentID, &w.URL, &w.Secret, &w.EventTypes,
+			&w.IsActive, &w.CreatedBy, &w.CreatedAt, &w.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, &w)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// queryDeliveries runs a delivery select and scans the rows
+func (r *WebhookRepository) queryDeliveries(ctx context.Context, query string, args ...interface{}) ([]*models.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]*models.WebhookDelivery, 0)
+	for rows.Next() {
+		var d models.WebhookDelivery
+		var payload []byte
+		err := rows.Scan(
+			&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &payload, &d.Status,
+			&d.Attempts, &d.NextAttemptAt, &d.ResponseStatus,
+			&d.DurationMs, &d.LastError, &d.CreatedAt, &d.DeliveredAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		d.Payload = payload
+		deliveries = append(deliveries, &d)
+	}
+
+	return deliveries, rows.Err()
+}
@@ -175,6 +175,17 @@ func (s *AuthService) ValidateToken(token string) (string, string, error) {
 	return userID, role, nil
 }
 
+// ParseToken validates a JWT token and returns its claims, for connections
+// that need to know when the token expires
+func (s *AuthService) ParseToken(token string) (*utils.Claims, error) {
+	claims, err := utils.ParseJWT(token, s.config.JWTSecret)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
 // Logout invalidates a refresh token
 func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
 	if refreshToken != "" {
@@ -0,0 +1,318 @@
+// internal/services/bracket_service.go
+// Builds structured bracket documents from the next_match_id progression links
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/repositories"
+)
+
+// BracketService assembles format-aware bracket documents
+type BracketService struct {
+	repos     *repositories.Container
+	cache     *CacheService
+	standings *StandingsService
+	logger    *log.Logger
+}
+
+// NewBracketService creates a new bracket service
+func NewBracketService(
+	repos *repositories.Container,
+	cache *CacheService,
+	standings *StandingsService,
+	logger *log.Logger,
+) *BracketService {
+	return &BracketService{
+		repos:     repos,
+		cache:     cache,
+		standings: standings,
+		logger:    logger,
+	}
+}
+
+// GetBracket returns the structured bracket for a tournament
+func (s *BracketService) GetBracket(ctx context.Context, tournamentID string) (*models.Bracket, error) {
+	// Try cache first
+	cacheKey := fmt.Sprintf("tournament_bracket_%s", tournamentID)
+	var bracket models.Bracket
+	if err := s.cache.Get(cacheKey, &bracket); err == nil {
+		return &bracket, nil
+	}
+
+	tournament, err := s.repos.Tournament.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := s.repos.Match.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch matches: %w", err)
+	}
+
+	participants, err := s.repos.TournamentParticipant.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch participants: %w", err)
+	}
+
+	b := buildBracket(tournament, matches, participants)
+
+	// Group stages carry their league tables alongside the knockout bracket
+	switch tournament.FormatType {
+	case models.FormatRoundRobin, models.FormatGroupToKnockout, models.FormatSwiss:
+		groups, err := s.standings.GetStandings(ctx, tournamentID, StandingsFilter{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute group tables: %w", err)
+		}
+		b.Groups = groups
+	}
+
+	// Cache for 5 minutes; score reports and fixture generation invalidate it
+	s.cache.Set(cacheKey, b, 5*time.Minute)
+
+	return b, nil
+}
+
+// buildBracket arranges matches into sections and rounds
+func buildBracket(tournament *models.Tournament, matches []*models.Match, participants []*models.Participant) *models.Bracket {
+	byID := make(map[string]*models.Participant, len(participants))
+	for _, p := range participants {
+		byID[p.ID] = p
+	}
+
+	// Invert next_match_id links so every match knows what feeds it
+	feeders := make(map[string][]*models.Match)
+	for _, m := range matches {
+		if m.NextMatchID != nil {
+			feeders[*m.NextMatchID] = append(feeders[*m.NextMatchID], m)
+		}
+	}
+	for _, list := range feeders {
+		sort.Slice(list, func(i, j int) bool { return list[i].MatchNumber < list[j].MatchNumber })
+	}
+
+	sectionMatches := make(map[models.BracketSectionName][]*models.Match)
+	for _, m := range matches {
+		name, ok := bracketSectionFor(tournament.FormatType, m)
+		if !ok {
+			continue
+		}
+		sectionMatches[name] = append(sectionMatches[name], m)
+	}
+
+	bracket := &models.Bracket{
+		TournamentID: tournament.ID,
+		Name:         tournament.Name,
+		Format:       tournament.FormatType,
+		Sections:     make([]*models.BracketSection, 0),
+	}
+
+	for _, name := range []models.BracketSectionName{models.SectionMain, models.SectionWinners, models.SectionLosers, models.SectionFinals} {
+		list := sectionMatches[name]
+		if len(list) == 0 {
+			continue
+		}
+		bracket.Sections = append(bracket.Sections, buildSection(tournament.FormatType, name, list, feeders, byID))
+	}
+
+	return bracket
+}
+
+// bracketSectionFor decides which section a match belongs to.
+// Group stage matches are reported through league tables instead.
+func bracketSectionFor(format models.TournamentFormat, m *models.Match) (models.BracketSectionName, bool) {
+	if m.GroupName != nil || m.Stage == "group" {
+		return "", false
+	}
+
+	switch m.Stage {
+	case "losers":
+		return models.SectionLosers, true
+	case "grand_final", "final":
+		return models.SectionFinals, true
+	}
+
+	if format == models.FormatDoubleElimination {
+		return models.SectionWinners, true
+	}
+	return models.SectionMain, true
+}
+
+// buildSection groups a section's matches into named rounds
+func buildSection(
+	format models.TournamentFormat,
+	name models.BracketSectionName,
+	matches []*models.Match,
+	feeders map[string][]*models.Match,
+	participants map[string]*models.Participant,
+) *models.BracketSection {
+	byRound := make(map[int][]*models.Match)
+	rounds := make([]int, 0)
+	for _, m := range matches {
+		if _, exists := byRound[m.RoundNumber]; !exists {
+			rounds = append(rounds, m.RoundNumber)
+		}
+		byRound[m.RoundNumber] = append(byRound[m.RoundNumber], m)
+	}
+	sort.Ints(rounds)
+
+	section := &models.BracketSection{
+		Name:   name,
+		Title:  sectionTitle(name),
+		Rounds: make([]*models.BracketRound, 0, len(rounds)),
+	}
+
+	for i, number := range rounds {
+		list := byRound[number]
+		sort.Slice(list, func(a, b int) bool { return list[a].MatchNumber < list[b].MatchNumber })
+
+		round := &models.BracketRound{
+			Number:  number,
+			Name:    roundName(format, name, i+1, len(rounds), len(list)),
+			Matches: make([]*models.BracketSlot, 0, len(list)),
+		}
+		for _, m := range list {
+			round.Matches = append(round.Matches, buildSlot(m, i == 0, feeders[m.ID], participants))
+		}
+		section.Rounds = append(section.Rounds, round)
+	}
+
+	return section
+}
+
+// buildSlot converts a match into a bracket slot with entrants resolved
+func buildSlot(m *models.Match, firstRound bool, feeders []*models.Match, participants map[string]*models.Participant) *models.BracketSlot {
+	slot := &models.BracketSlot{
+		MatchID:           m.ID,
+		MatchNumber:       m.MatchNumber,
+		Status:            m.Status,
+		WinnerID:          m.WinnerID,
+		Score1:            m.Score1,
+		Score2:            m.Score2,
+		ScheduledDatetime: m.ScheduledDatetime,
+		VenueID:           m.VenueID,
+		NextMatchID:       m.NextMatchID,
+	}
+	for _, f := range feeders {
+		slot.FeederMatchIDs = append(slot.FeederMatchIDs, f.ID)
+	}
+
+	// Known participants are matched to the feeder they came from; the remaining
+	// feeders become placeholders for empty slots in match number order
+	used := make(map[string]bool)
+	sourceOf := func(participantID *string) *models.Match {
+		if participantID == nil {
+			return nil
+		}
+		for _, f := range feeders {
+			if !used[f.ID] && f.WinnerID != nil && *f.WinnerID == *participantID {
+				used[f.ID] = true
+				return f
+			}
+		}
+		return nil
+	}
+	source1 := sourceOf(m.Participant1ID)
+	source2 := sourceOf(m.Participant2ID)
+
+	nextFeeder := func() *models.Match {
+		for _, f := range feeders {
+			if !used[f.ID] {
+				used[f.ID] = true
+				return f
+			}
+		}
+		return nil
+	}
+	if m.Participant1ID == nil {
+		source1 = nextFeeder()
+	}
+	if m.Participant2ID == nil {
+		source2 = nextFeeder()
+	}
+
+	slot.Entrant1 = buildEntrant(m.Participant1ID, source1, firstRound && len(feeders) == 0, participants)
+	slot.Entrant2 = buildEntrant(m.Participant2ID, source2, firstRound && len(feeders) == 0, participants)
+
+	return slot
+}
+
+// buildEntrant describes one side of a slot
+func buildEntrant(participantID *string, source *models.Match, canBeBye bool, participants map[string]*models.Participant) *models.BracketEntrant {
+	entrant := &models.BracketEntrant{}
+	if source != nil {
+		entrant.FeederMatchID = &source.ID
+	}
+
+	switch {
+	case participantID != nil:
+		entrant.ParticipantID = participantID
+		if p, ok := participants[*participantID]; ok {
+			entrant.Name = p.Name
+			entrant.Seed = p.Seed
+		} else {
+			entrant.Name = "Unknown"
+		}
+	case source != nil:
+		entrant.Name = fmt.Sprintf("Winner of match %d", source.MatchNumber)
+	case canBeBye:
+		entrant.Name = "BYE"
+		entrant.IsBye = true
+	default:
+		entrant.Name = "TBD"
+	}
+
+	return entrant
+}
+
+// sectionTitle returns the display title of a bracket section
+func sectionTitle(name models.BracketSectionName) string {
+	switch name {
+	case models.SectionWinners:
+		return "Winners Bracket"
+	case models.SectionLosers:
+		return "Losers Bracket"
+	case models.SectionFinals:
+		return "Finals"
+	default:
+		return "Main Bracket"
+	}
+}
+
+// roundName returns the display name of a round given its position in the section
+func roundName(format models.TournamentFormat, section models.BracketSectionName, index, total, matchesInRound int) string {
+	switch format {
+	case models.FormatRoundRobin, models.FormatSwiss:
+		return fmt.Sprintf("Round %d", index)
+	}
+
+	switch section {
+	case models.SectionLosers:
+		if index == total {
+			return "Losers Final"
+		}
+		return fmt.Sprintf("Losers Round %d", index)
+	case models.SectionFinals:
+		if index == 1 {
+			return "Grand Final"
+		}
+		return "Grand Final Reset"
+	}
+
+	// Elimination rounds are named by how many participants remain
+	switch total - index {
+	case 0:
+		return "Final"
+	case 1:
+		return "Semifinal"
+	case 2:
+		return "Quarterfinal"
+	}
+	return fmt.Sprintf("Round of %d", matchesInRound*2)
+}
@@ -10,7 +10,10 @@ import (
 
 	"tournament-planner/internal/config"
 	"tournament-planner/internal/database"
+	"tournament-planner/internal/events"
+	"tournament-planner/internal/notifications"
 	"tournament-planner/internal/repositories"
+	"tournament-planner/internal/webhooks"
 )
 
 // Container holds all service instances and provides them to handlers
@@ -19,10 +22,19 @@ type Container struct {
 	User         *UserService
 	Tournament   *TournamentService
 	Match        *MatchService
+	LiveScoring  *LiveScoringService
+	Standings    *StandingsService
+	Bracket      *BracketService
+	Schedule     *ScheduleService
+	Presence     *PresenceService
 	Payment      *PaymentService
 	Notification *NotificationService
+	Dispatcher   *NotificationDispatcher
+	Reminders    *MatchReminderScheduler
+	Webhook      *WebhookService
 	Cache        *CacheService
 	Analytics    *AnalyticsService
+	Events       *events.Bus
 }
 
 // NewContainer creates a new service container with all dependencies
@@ -33,15 +45,30 @@ func NewContainer(db *database.Connections, cfg *config.Config, logger *log.Logg
 	// Initialize cache service
 	cache := NewCacheService(db.Redis, logger)
 
+	// Initialize event bus for real-time delivery
+	bus := events.NewBus(logger)
+
 	// Initialize notification service
-	notification := NewNotificationService(db, cfg, logger)
+	channels := newNotificationChannels(cfg.Notifications, bus)
+	templates := notifications.NewTemplates(cfg.Notifications.DefaultLocale)
+	notification := NewNotificationService(repos, channels, templates, cfg, logger)
+	dispatcher := NewNotificationDispatcher(repos, notification, logger)
+
+	// Initialize organizer webhooks; deliveries are queued alongside the changes they announce
+	webhook := NewWebhookService(repos, webhooks.NewClient(webhooks.NewHTTPClient(WebhookTimeout)), cfg, logger)
 
 	// Initialize services with their dependencies
 	auth := NewAuthService(repos.User, cfg.Auth, cache, logger)
 	user := NewUserService(repos.User, repos.UserPreferences, logger)
-	tournament := NewTournamentService(repos, cache, notification, logger)
-	match := NewMatchService(repos, cache, notification, logger)
-	payment := NewPaymentService(repos, cfg.External, logger)
+	payment := NewPaymentService(repos, newPaymentProvider(cfg.External), cfg.External, logger)
+	tournament := NewTournamentService(repos, cache, notification, payment, webhook, bus, logger)
+	match := NewMatchService(repos, cache, notification, webhook, bus, logger)
+	reminders := NewMatchReminderScheduler(repos, notification, cache, cfg.Notifications.ReminderOffsets, logger)
+	liveScoring := NewLiveScoringService(repos, cache, match, webhook, bus, logger)
+	standings := NewStandingsService(repos, cache, logger)
+	bracket := NewBracketService(repos, cache, standings, logger)
+	schedule := NewScheduleService(repos, logger)
+	presence := NewPresenceService(repos, logger)
 	analytics := NewAnalyticsService(db.MongoDB, cache, logger)
 
 	return &Container{
@@ -49,10 +76,19 @@ func NewContainer(db *database.Connections, cfg *config.Config, logger *log.Logg
 		User:         user,
 		Tournament:   tournament,
 		Match:        match,
+		LiveScoring:  liveScoring,
+		Standings:    standings,
+		Bracket:      bracket,
+		Schedule:     schedule,
+		Presence:     presence,
 		Payment:      payment,
 		Notification: notification,
+		Dispatcher:   dispatcher,
+		Reminders:    reminders,
+		Webhook:      webhook,
 		Cache:        cache,
 		Analytics:    analytics,
+		Events:       bus,
 	}
 }
 
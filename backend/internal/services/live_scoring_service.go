@@ -0,0 +1,320 @@
+// internal/services/live_scoring_service.go
+// Point-by-point live scoring backed by the match_updates event log
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"tournament-planner/internal/events"
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/repositories"
+	"tournament-planner/internal/utils"
+)
+
+// LiveScoringService records scoring events and derives the running score from them
+type LiveScoringService struct {
+	repos   *repositories.Container
+	cache   *CacheService
+	matches *MatchService
+	webhook *WebhookService
+	bus     *events.Bus
+	logger  *log.Logger
+}
+
+// NewLiveScoringService creates a new live scoring service
+func NewLiveScoringService(
+	repos *repositories.Container,
+	cache *CacheService,
+	matches *MatchService,
+	webhook *WebhookService,
+	bus *events.Bus,
+	logger *log.Logger,
+) *LiveScoringService {
+	return &LiveScoringService{
+		repos:   repos,
+		cache:   cache,
+		matches: matches,
+		webhook: webhook,
+		bus:     bus,
+		logger:  logger,
+	}
+}
+
+// ScoreEventInput is an incremental scoring event posted by a scorer
+type ScoreEventInput struct {
+	Type   models.ScoreEventType
+	Side   int
+	Value  int
+	Detail string
+}
+
+// RecordEvent appends a scoring event to a match's log and returns the updated live score.
+// A scheduled match is started by its first event.
+func (s *LiveScoringService) RecordEvent(ctx context.Context, matchID, userID string, input ScoreEventInput) (*models.ScoreEvent, *models.LiveScore, error) {
+	if !input.Type.IsValid() {
+		return nil, nil, fmt.Errorf("%w: unknown score event type %q", ErrInvalidInput, input.Type)
+	}
+	if input.Side != 1 && input.Side != 2 {
+		return nil, nil, fmt.Errorf("%w: side must be 1 or 2", ErrInvalidInput)
+	}
+	if input.Value < 0 {
+		return nil, nil, fmt.Errorf("%w: value cannot be negative", ErrInvalidInput)
+	}
+	if input.Value == 0 {
+		input.Value = 1
+	}
+
+	match, err := s.repos.Match.GetByID(ctx, matchID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch match.Status {
+	case models.MatchInProgress:
+	case models.MatchScheduled:
+		match.Status = models.MatchInProgress
+		started, err := s.matches.startWithTx(ctx, match)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to start match: %w", err)
+		}
+		s.cache.Delete(fmt.Sprintf("tournament_matches_%s", match.TournamentID))
+		s.bus.Publish(events.MatchStarted, match.TournamentID, started)
+	default:
+		return nil, nil, fmt.Errorf("%w: match is not in a state where score can be recorded", ErrInvalidInput)
+	}
+
+	// Sequence numbers come from the log itself; a concurrent scorer loses the
+	// unique index race and retries against the newer log
+	for attempt := 0; attempt < 3; attempt++ {
+		logged, err := s.repos.MatchUpdate.GetScoreEvents(ctx, matchID, match.PlayEpoch)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load score events: %w", err)
+		}
+		before := replayScore(matchID, logged)
+
+		event := &models.ScoreEvent{
+			ID:           utils.GenerateUUID(),
+			MatchID:      matchID,
+			TournamentID: match.TournamentID,
+			PlayEpoch:    match.PlayEpoch,
+			Sequence:     before.LastSequence + 1,
+			Type:         input.Type,
+			Side:         input.Side,
+			Value:        input.Value,
+			Detail:       input.Detail,
+			RecordedBy:   userID,
+			Timestamp:    time.Now(),
+		}
+		after := replayScore(matchID, append(logged, event))
+
+		err = s.repos.MatchUpdate.AppendScoreEvent(ctx, event, &before.Details, &after.Details)
+		if errors.Is(err, repositories.ErrDuplicateSequence) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to record score event: %w", err)
+		}
+
+		// Cache for 1 hour; every event overwrites it
+		s.cache.Set(fmt.Sprintf("match_live_score_%s", matchID), after, 1*time.Hour)
+
+		// The log lives in MongoDB, so deliveries are queued right after the append
+		// rather than in a shared transaction
+		update := &events.ScorePayload{
+			MatchID: matchID,
+			Event:   event,
+			Score:   after,
+		}
+		if err := s.webhook.Enqueue(ctx, events.MatchScoreUpdated, match.TournamentID, update); err != nil {
+			s.logger.Printf("Failed to queue score webhooks for match %s: %v", matchID, err)
+		}
+		s.bus.Publish(events.MatchScoreUpdated, match.TournamentID, update)
+
+		return event, after, nil
+	}
+
+	return nil, nil, fmt.Errorf("failed to record score event: too many concurrent updates")
+}
+
+// GetEvents returns the scoring log of a match's current play in order
+func (s *LiveScoringService) GetEvents(ctx context.Context, matchID string) ([]*models.ScoreEvent, error) {
+	match, err := s.repos.Match.GetByID(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repos.MatchUpdate.GetScoreEvents(ctx, matchID, match.PlayEpoch)
+}
+
+// GetLiveScore returns the running score of a match
+func (s *LiveScoringService) GetLiveScore(ctx context.Context, matchID string) (*models.LiveScore, error) {
+	// Try cache first
+	var score models.LiveScore
+	if err := s.cache.Get(fmt.Sprintf("match_live_score_%s", matchID), &score); err == nil {
+		return &score, nil
+	}
+
+	match, err := s.repos.Match.GetByID(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	logged, err := s.repos.MatchUpdate.GetScoreEvents(ctx, matchID, match.PlayEpoch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load score events: %w", err)
+	}
+
+	return replayScore(matchID, logged), nil
+}
+
+// Complete replays the log and reports the resulting score as the final result
+func (s *LiveScoringService) Complete(ctx context.Context, matchID string) (*models.LiveScore, error) {
+	match, err := s.repos.Match.GetByID(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	logged, err := s.repos.MatchUpdate.GetScoreEvents(ctx, matchID, match.PlayEpoch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load score events: %w", err)
+	}
+	if len(logged) == 0 {
+		return nil, fmt.Errorf("%w: no score events recorded for this match", ErrInvalidInput)
+	}
+
+	score := replayScore(matchID, logged)
+	if score.Score1 == score.Score2 {
+		return nil, fmt.Errorf("%w: tie score not allowed - must have a winner", ErrInvalidInput)
+	}
+
+	if err := s.matches.ReportScore(ctx, matchID, score.Score1, score.Score2, &score.Details); err != nil {
+		return nil, err
+	}
+
+	s.cache.Delete(fmt.Sprintf("match_live_score_%s", matchID))
+
+	return score, nil
+}
+
+// scoreReplay accumulates a live score while replaying score events.
+// Points and goals build games, games build sets; a set recorded without
+// games takes its points from the open game instead (e.g. volleyball).
+type scoreReplay struct {
+	details   models.ScoreDetails
+	points    [2]int
+	games     [2]int
+	sets      [2]int
+	usesGames bool
+	usesSets  bool
+	gameOpen  bool
+	setOpen   bool
+	timeouts  [2]int
+	cards     []map[string]interface{}
+}
+
+// replayScore derives the live score of a match from its ordered event log
+func replayScore(matchID string, logged []*models.ScoreEvent) *models.LiveScore {
+	r := &scoreReplay{}
+	score := &models.LiveScore{MatchID: matchID}
+
+	for _, e := range logged {
+		r.apply(e)
+		score.LastSequence = e.Sequence
+		timestamp := e.Timestamp
+		score.UpdatedAt = &timestamp
+	}
+
+	totals := r.points
+	switch {
+	case r.usesSets:
+		totals = r.sets
+	case r.usesGames:
+		totals = r.games
+	}
+	score.Score1, score.Score2 = totals[0], totals[1]
+
+	score.Details = r.details
+	if r.timeouts != [2]int{} || len(r.cards) > 0 {
+		score.Details.Custom = map[string]interface{}{}
+		if r.timeouts != [2]int{} {
+			score.Details.Custom["timeouts"] = map[string]int{"1": r.timeouts[0], "2": r.timeouts[1]}
+		}
+		if len(r.cards) > 0 {
+			score.Details.Custom["cards"] = r.cards
+		}
+	}
+
+	return score
+}
+
+// apply folds a single event into the replay state
+func (r *scoreReplay) apply(e *models.ScoreEvent) {
+	side := e.Side - 1
+	if side != 0 && side != 1 {
+		return
+	}
+
+	switch e.Type {
+	case models.ScoreEventPoint:
+		if !r.gameOpen {
+			r.details.Games = append(r.details.Games, models.GameScore{})
+			r.gameOpen = true
+		}
+		addToSide(&r.details.Games[len(r.details.Games)-1].Player1Score, &r.details.Games[len(r.details.Games)-1].Player2Score, side, e.Value)
+		r.points[side] += e.Value
+
+	case models.ScoreEventGoal:
+		r.points[side] += e.Value
+
+	case models.ScoreEventGame:
+		if !r.setOpen {
+			r.details.Sets = append(r.details.Sets, models.SetScore{})
+			r.setOpen = true
+		}
+		addToSide(&r.details.Sets[len(r.details.Sets)-1].Player1Score, &r.details.Sets[len(r.details.Sets)-1].Player2Score, side, 1)
+		r.games[side]++
+		r.usesGames = true
+		r.gameOpen = false
+
+	case models.ScoreEventSet:
+		if !r.setOpen {
+			// No games in this set, so its score is the points of the open game
+			set := models.SetScore{}
+			if r.gameOpen {
+				game := r.details.Games[len(r.details.Games)-1]
+				r.details.Games = r.details.Games[:len(r.details.Games)-1]
+				set = models.SetScore{Player1Score: game.Player1Score, Player2Score: game.Player2Score}
+			} else {
+				addToSide(&set.Player1Score, &set.Player2Score, side, 1)
+			}
+			r.details.Sets = append(r.details.Sets, set)
+		}
+		r.sets[side]++
+		r.usesSets = true
+		r.setOpen = false
+		r.gameOpen = false
+
+	case models.ScoreEventTimeout:
+		r.timeouts[side]++
+
+	case models.ScoreEventCard:
+		r.cards = append(r.cards, map[string]interface{}{
+			"side":     e.Side,
+			"detail":   e.Detail,
+			"sequence": e.Sequence,
+		})
+	}
+}
+
+// addToSide adds n to the score of the given zero-based side
+func addToSide(player1, player2 *int, side, n int) {
+	if side == 0 {
+		*player1 += n
+	} else {
+		*player2 += n
+	}
+}
@@ -0,0 +1,108 @@
+// internal/services/match_correction_test.go
+// Result corrections cascading through a single-elimination bracket
+
+package services
+
+import (
+	"errors"
+	"io"
+	"log"
+	"testing"
+
+	"tournament-planner/internal/config"
+	"tournament-planner/internal/events"
+	"tournament-planner/internal/models"
+)
+
+// newCorrectionFixture seeds a four-player bracket: p1 beat p2 and p3 beat p4 in
+// the semi-finals, and the final between p1 and p3 has been played without a time
+func newCorrectionFixture(t *testing.T, finalStatus models.MatchStatus) (*revenueFixture, *MatchService) {
+	t.Helper()
+
+	f := newRevenueFixture(t)
+	for _, id := range []string{"p1", "p2", "p3", "p4"} {
+		f.register(t, id, models.PriceStandard, 5000)
+	}
+	f.exec(t, `INSERT INTO webhooks (id, tournament_id, url, secret, created_by) VALUES ('wh-1', ?, 'https://example.com/hook', 'whsec', ?)`,
+		testTournamentID, testOrganizerID)
+
+	insert := `
+		INSERT INTO matches (id, tournament_id, round_number, match_number, participant1_id, participant2_id,
+			winner_id, score1, score2, status, next_match_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	final := []interface{}{"m3", testTournamentID, 2, 3, "p1", "p3", nil, nil, nil, finalStatus, nil}
+	if finalStatus == models.MatchCompleted {
+		final[6], final[7], final[8] = "p1", 2, 1
+	}
+	f.exec(t, insert, final...)
+	f.exec(t, insert, "m1", testTournamentID, 1, 1, "p1", "p2", "p1", 2, 0, models.MatchCompleted, "m3")
+	f.exec(t, insert, "m2", testTournamentID, 1, 2, "p3", "p4", "p3", 2, 0, models.MatchCompleted, "m3")
+
+	logger := log.New(io.Discard, "", 0)
+	cfg := &config.Config{}
+	match := NewMatchService(f.repos, newTestCache(t),
+		NewNotificationService(f.repos, nil, nil, cfg, logger),
+		NewWebhookService(f.repos, nil, cfg, logger),
+		events.NewBus(logger), logger)
+	return f, match
+}
+
+func TestCorrectResultReschedulesVoidedDownstreamMatch(t *testing.T) {
+	f, match := newCorrectionFixture(t, models.MatchCompleted)
+
+	if _, err := match.CorrectResult(f.ctx, "m1", testOrganizerID, 0, 2, nil, "scores swapped"); err != nil {
+		t.Fatalf("correct result: %v", err)
+	}
+
+	final, err := f.repos.Match.GetByID(f.ctx, "m3")
+	if err != nil {
+		t.Fatalf("get final: %v", err)
+	}
+	if final.Participant1ID == nil || *final.Participant1ID != "p2" {
+		t.Fatalf("final participant1 = %v, want p2", final.Participant1ID)
+	}
+	if final.Status != models.MatchScheduled || final.WinnerID != nil {
+		t.Fatalf("final status %s winner %v, want a scheduled match without a result", final.Status, final.WinnerID)
+	}
+	if final.PlayEpoch != 1 {
+		t.Fatalf("final play epoch = %d, want 1 so the voided play's score events are dropped", final.PlayEpoch)
+	}
+
+	// The replay has to be reportable
+	if err := match.ReportScore(f.ctx, "m3", 1, 2, nil); err != nil {
+		t.Fatalf("report replayed final: %v", err)
+	}
+
+	var notices, deliveries int
+	if err := f.db.QueryRow(`SELECT COUNT(*) FROM notification_outbox WHERE kind = ? AND idempotency_key LIKE 'match_scheduled:m3:p2:p3:%'`,
+		models.NotificationMatchScheduled).Scan(&notices); err != nil {
+		t.Fatalf("count notifications: %v", err)
+	}
+	if notices != 1 {
+		t.Errorf("match_scheduled notifications for the new final = %d, want 1", notices)
+	}
+	if err := f.db.QueryRow(`SELECT COUNT(*) FROM webhook_deliveries WHERE event_type = ?`, events.MatchScheduled).Scan(&deliveries); err != nil {
+		t.Fatalf("count deliveries: %v", err)
+	}
+	if deliveries != 1 {
+		t.Errorf("match_scheduled webhook deliveries = %d, want 1", deliveries)
+	}
+}
+
+func TestCorrectResultRefusesSwapInMatchInProgress(t *testing.T) {
+	f, match := newCorrectionFixture(t, models.MatchInProgress)
+
+	_, err := match.CorrectResult(f.ctx, "m1", testOrganizerID, 0, 2, nil, "scores swapped")
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("correct result = %v, want ErrInvalidInput", err)
+	}
+
+	source, err := f.repos.Match.GetByID(f.ctx, "m1")
+	if err != nil {
+		t.Fatalf("get match: %v", err)
+	}
+	if *source.WinnerID != "p1" {
+		t.Fatalf("winner = %s, want the correction rolled back", *source.WinnerID)
+	}
+}
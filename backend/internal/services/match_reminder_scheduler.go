@@ -0,0 +1,189 @@
+// internal/services/match_reminder_scheduler.go
+// Background job that queues match reminders and corrects them after reschedules
+
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/repositories"
+)
+
+const (
+	// reminderScanInterval is how often upcoming matches are scanned
+	reminderScanInterval = time.Minute
+
+	// reminderLockGrace keeps a reminder lock a while after the match started,
+	// so a late scan on another instance cannot take it again
+	reminderLockGrace = time.Hour
+)
+
+// MatchReminderScheduler queues reminders for upcoming matches at configured
+// offsets before their scheduled time, for both participants and the referee.
+// Every instance may run it: a cache lock keeps instances from queueing the same
+// reminder concurrently, and the reminder record and outbox idempotency key make
+// it durable across cache restarts. Reminders recorded for a time the match has
+// since moved from are followed by a correction.
+type MatchReminderScheduler struct {
+	repos        *repositories.Container
+	notification *NotificationService
+	cache        *CacheService
+	offsets      []time.Duration
+	logger       *log.Logger
+}
+
+// NewMatchReminderScheduler creates a new match reminder scheduler
+func NewMatchReminderScheduler(
+	repos *repositories.Container,
+	notification *NotificationService,
+	cache *CacheService,
+	offsets []time.Duration,
+	logger *log.Logger,
+) *MatchReminderScheduler {
+	// Scans look for the nearest offset first
+	sorted := slices.Clone(offsets)
+	slices.Sort(sorted)
+
+	return &MatchReminderScheduler{
+		repos:        repos,
+		notification: notification,
+		cache:        cache,
+		offsets:      sorted,
+		logger:       logger,
+	}
+}
+
+// Run scans for due reminders until the context is cancelled
+func (s *MatchReminderScheduler) Run(ctx context.Context) {
+	if len(s.offsets) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(reminderScanInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.scan(ctx, time.Now())
+		}
+	}
+}
+
+// scan queues the reminders that are due and the corrections for stale ones
+func (s *MatchReminderScheduler) scan(ctx context.Context, now time.Time) {
+	horizon := s.offsets[len(s.offsets)-1]
+	matches, err := s.repos.Match.ListScheduledBetween(ctx, now, now.Add(horizon))
+	if err != nil {
+		s.logger.Printf("Failed to list upcoming matches for reminders: %v", err)
+		return
+	}
+
+	for _, match := range matches {
+		if offset, ok := s.dueOffset(match.ScheduledDatetime.Sub(now)); ok {
+			if err := s.remind(ctx, match, offset); err != nil {
+				s.logger.Printf("Failed to queue %s reminder for match %s: %v", offset, match.ID, err)
+			}
+		}
+	}
+
+	stale, err := s.repos.MatchReminder.ListStale(ctx)
+	if err != nil {
+		s.logger.Printf("Failed to list stale match reminders: %v", err)
+		return
+	}
+	for _, reminder := range stale {
+		if err := s.correct(ctx, reminder); err != nil {
+			s.logger.Printf("Failed to queue reminder correction for match %s: %v", reminder.MatchID, err)
+		}
+	}
+}
+
+// dueOffset picks the nearest offset a match is already within. Only that
+// reminder is sent, so a match scheduled at short notice gets one reminder
+// instead of every reminder it missed at once.
+func (s *MatchReminderScheduler) dueOffset(untilStart time.Duration) (time.Duration, bool) {
+	for _, offset := range s.offsets {
+		if untilStart <= offset {
+			return offset, true
+		}
+	}
+	return 0, false
+}
+
+// remind queues one reminder for a match unless it was already queued
+func (s *MatchReminderScheduler) remind(ctx context.Context, match *models.Match, offset time.Duration) error {
+	scheduledFor := *match.ScheduledDatetime
+	offsetMins := int(offset.Minutes())
+	key := fmt.Sprintf("match_reminder:%s:%d:%d", match.ID, offsetMins, scheduledFor.Unix())
+
+	locked, err := s.cache.SetNX(key, time.Now(), time.Until(scheduledFor)+reminderLockGrace)
+	if err != nil || !locked {
+		return err
+	}
+
+	err = s.inTx(ctx, func(tx *sql.Tx) error {
+		recorded, err := s.repos.MatchReminder.RecordWithTx(tx, match.ID, offsetMins, scheduledFor)
+		if err != nil || !recorded {
+			return err
+		}
+		return s.notification.EnqueueWithTx(tx, models.NotificationMatchReminder, key, models.NotificationPayload{
+			TournamentID: match.TournamentID,
+			MatchID:      match.ID,
+			ScheduledFor: &scheduledFor,
+		})
+	})
+	if err != nil {
+		// Let the next scan try again
+		s.cache.Delete(key)
+	}
+	return err
+}
+
+// correct queues a correction for reminders sent for a time a match moved from
+func (s *MatchReminderScheduler) correct(ctx context.Context, reminder *models.MatchReminder) error {
+	key := fmt.Sprintf("match_reminder_correction:%s:%d", reminder.MatchID, reminder.ScheduledFor.Unix())
+
+	locked, err := s.cache.SetNX(key, time.Now(), reminderScanInterval*5)
+	if err != nil || !locked {
+		return err
+	}
+
+	err = s.inTx(ctx, func(tx *sql.Tx) error {
+		corrected, err := s.repos.MatchReminder.MarkCorrectedWithTx(tx, reminder.MatchID, reminder.ScheduledFor)
+		if err != nil || !corrected {
+			return err
+		}
+		return s.notification.EnqueueWithTx(tx, models.NotificationReminderCorrection, key, models.NotificationPayload{
+			TournamentID: reminder.TournamentID,
+			MatchID:      reminder.MatchID,
+			ScheduledFor: &reminder.ScheduledFor,
+		})
+	})
+	if err != nil {
+		s.cache.Delete(key)
+	}
+	return err
+}
+
+// inTx runs fn in a transaction that is committed if fn succeeds
+func (s *MatchReminderScheduler) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.repos.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -5,12 +5,15 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log"
 	"time"
 
+	"tournament-planner/internal/events"
 	"tournament-planner/internal/models"
 	"tournament-planner/internal/repositories"
+	"tournament-planner/internal/utils"
 )
 
 // MatchService handles match-related business logic
@@ -18,6 +21,8 @@ type MatchService struct {
 	repos        *repositories.Container
 	cache        *CacheService
 	notification *NotificationService
+	webhook      *WebhookService
+	bus          *events.Bus
 	logger       *log.Logger
 }
 
@@ -26,12 +31,16 @@ func NewMatchService(
 	repos *repositories.Container,
 	cache *CacheService,
 	notification *NotificationService,
+	webhook *WebhookService,
+	bus *events.Bus,
 	logger *log.Logger,
 ) *MatchService {
 	return &MatchService{
 		repos:        repos,
 		cache:        cache,
 		notification: notification,
+		webhook:      webhook,
+		bus:          bus,
 		logger:       logger,
 	}
 }
@@ -86,18 +95,38 @@ func (s *MatchService) UpdateSchedule(ctx context.Context, matchID string, sched
 	match.VenueID = &venueID
 	match.Status = models.MatchScheduled
 
-	if err := s.repos.Match.Update(ctx, match); err != nil {
+	tx, err := s.repos.BeginTx(ctx)
+	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	// Clear cache
-	s.cache.Delete(fmt.Sprintf("tournament_matches_%s", match.TournamentID))
+	if err := s.repos.Match.UpdateScheduleWithTx(tx, matchID, scheduledTime, match.VenueID); err != nil {
+		return err
+	}
 
-	// Send notifications
+	// Queue notifications with the change so they survive a crash after commit
 	if match.Participant1ID != nil && match.Participant2ID != nil {
-		go s.notification.NotifyMatchScheduled(match, []string{*match.Participant1ID, *match.Participant2ID})
+		if err := s.enqueueMatchNotification(tx, models.NotificationMatchScheduled, matchScheduledKey(match), match); err != nil {
+			return err
+		}
+	}
+
+	event := events.NewMatchPayload(match)
+	if err := s.webhook.EnqueueWithTx(tx, events.MatchScheduled, match.TournamentID, event); err != nil {
+		return fmt.Errorf("failed to queue webhook deliveries: %w", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	// Clear cache
+	s.cache.Delete(fmt.Sprintf("tournament_matches_%s", match.TournamentID))
+	s.cache.Delete(fmt.Sprintf("tournament_bracket_%s", match.TournamentID))
+
+	s.bus.Publish(events.MatchScheduled, match.TournamentID, event)
+
 	return nil
 }
 
@@ -132,13 +161,13 @@ func (s *MatchService) ReportScore(ctx context.Context, matchID string, score1,
 	defer tx.Rollback()
 
 	// Update match score
-	if err := s.repos.Match.UpdateScore(ctx, matchID, score1, score2, winnerID, scoreDetails); err != nil {
+	if err := s.repos.Match.UpdateScoreWithTx(tx, matchID, score1, score2, winnerID, scoreDetails); err != nil {
 		return fmt.Errorf("failed to update score: %w", err)
 	}
 
 	// Handle bracket progression
 	if match.NextMatchID != nil {
-		nextMatch, err := s.repos.Match.GetByID(ctx, *match.NextMatchID)
+		nextMatch, err := s.repos.Match.GetByIDForUpdateWithTx(tx, *match.NextMatchID)
 		if err != nil {
 			return fmt.Errorf("failed to get next match: %w", err)
 		}
@@ -153,13 +182,15 @@ func (s *MatchService) ReportScore(ctx context.Context, matchID string, score1,
 		}
 
 		// Update next match
-		if err := s.repos.Match.Update(ctx, nextMatch); err != nil {
+		if err := s.repos.Match.UpdateParticipantsWithTx(tx, nextMatch.ID, nextMatch.Participant1ID, nextMatch.Participant2ID); err != nil {
 			return fmt.Errorf("failed to update next match: %w", err)
 		}
 
 		// If next match now has both participants, notify them
 		if nextMatch.Participant1ID != nil && nextMatch.Participant2ID != nil {
-			go s.notification.NotifyMatchScheduled(nextMatch, []string{*nextMatch.Participant1ID, *nextMatch.Participant2ID})
+			if err := s.enqueueMatchNotification(tx, models.NotificationMatchScheduled, matchScheduledKey(nextMatch), nextMatch); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -169,7 +200,9 @@ func (s *MatchService) ReportScore(ctx context.Context, matchID string, score1,
 		if winnerID == *match.Participant1ID {
 			matchesWon = 1
 		}
-		s.repos.Participant.UpdateStats(ctx, *match.Participant1ID, 1, matchesWon)
+		if err := s.repos.Participant.UpdateStatsWithTx(tx, *match.Participant1ID, 1, matchesWon); err != nil {
+			return fmt.Errorf("failed to update participant statistics: %w", err)
+		}
 	}
 
 	if match.Participant2ID != nil {
@@ -177,7 +210,28 @@ func (s *MatchService) ReportScore(ctx context.Context, matchID string, score1,
 		if winnerID == *match.Participant2ID {
 			matchesWon = 1
 		}
-		s.repos.Participant.UpdateStats(ctx, *match.Participant2ID, 1, matchesWon)
+		if err := s.repos.Participant.UpdateStatsWithTx(tx, *match.Participant2ID, 1, matchesWon); err != nil {
+			return fmt.Errorf("failed to update participant statistics: %w", err)
+		}
+	}
+
+	// Queue result notifications with the result itself
+	if match.Participant1ID != nil && match.Participant2ID != nil {
+		key := fmt.Sprintf("match_result:%s:%s:%d-%d", matchID, winnerID, score1, score2)
+		if err := s.enqueueMatchNotification(tx, models.NotificationMatchResult, key, match); err != nil {
+			return err
+		}
+	}
+
+	match.Score1, match.Score2, match.WinnerID = &score1, &score2, &winnerID
+	match.Status = models.MatchCompleted
+	changed := []string{match.ID}
+	if match.NextMatchID != nil {
+		changed = append(changed, *match.NextMatchID)
+	}
+	completed, bracket, err := s.enqueueResultWebhooks(tx, match, changed)
+	if err != nil {
+		return err
 	}
 
 	// Commit transaction
@@ -188,18 +242,328 @@ func (s *MatchService) ReportScore(ctx context.Context, matchID string, score1,
 	// Clear caches
 	s.cache.Delete(fmt.Sprintf("tournament_matches_%s", match.TournamentID))
 	s.cache.Delete(fmt.Sprintf("tournament_bracket_%s", match.TournamentID))
+	s.cache.Delete(fmt.Sprintf("tournament_standings_%s", match.TournamentID))
+
+	s.bus.Publish(events.MatchCompleted, match.TournamentID, completed)
+	s.bus.Publish(events.BracketUpdated, match.TournamentID, bracket)
+
+	return nil
+}
 
-	// Send result notifications
+// enqueueResultWebhooks queues the match_completed and bracket_updated webhook
+// deliveries for a result within its transaction, and returns their payloads
+// for publishing once it commits
+func (s *MatchService) enqueueResultWebhooks(tx *sql.Tx, match *models.Match, changed []string) (*events.MatchPayload, *events.BracketPayload, error) {
+	completed := events.NewMatchPayload(match)
+	bracket := &events.BracketPayload{TournamentID: match.TournamentID, MatchIDs: changed}
+
+	if err := s.webhook.EnqueueWithTx(tx, events.MatchCompleted, match.TournamentID, completed); err != nil {
+		return nil, nil, fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+	if err := s.webhook.EnqueueWithTx(tx, events.BracketUpdated, match.TournamentID, bracket); err != nil {
+		return nil, nil, fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+	return completed, bracket, nil
+}
+
+// CorrectResult replaces a reported result. When the winner changes, the correction
+// follows next_match_id links: the wrongly advanced participant is replaced, results
+// they already played downstream are voided, and participant statistics are reverted.
+// Everything is applied in one transaction and recorded in the correction audit trail.
+func (s *MatchService) CorrectResult(ctx context.Context, matchID, correctedBy string, score1, score2 int, scoreDetails *models.ScoreDetails, reason string) (*models.ResultCorrection, error) {
+	// Begin transaction
+	tx, err := s.repos.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Lock the match so a concurrent report or correction can't interleave
+	match, err := s.repos.Match.GetByIDForUpdateWithTx(tx, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	if match.Status != models.MatchCompleted || match.WinnerID == nil {
+		return nil, fmt.Errorf("%w: only completed matches can be corrected", ErrInvalidInput)
+	}
+
+	// Determine winner
+	var winnerID string
+	if score1 > score2 && match.Participant1ID != nil {
+		winnerID = *match.Participant1ID
+	} else if score2 > score1 && match.Participant2ID != nil {
+		winnerID = *match.Participant2ID
+	} else {
+		return nil, fmt.Errorf("%w: tie score not allowed - must have a winner", ErrInvalidInput)
+	}
+
+	correction := &models.ResultCorrection{
+		ID:               utils.GenerateUUID(),
+		MatchID:          match.ID,
+		TournamentID:     match.TournamentID,
+		CorrectedBy:      correctedBy,
+		Reason:           reason,
+		PreviousScore1:   match.Score1,
+		PreviousScore2:   match.Score2,
+		PreviousWinnerID: match.WinnerID,
+		NewScore1:        score1,
+		NewScore2:        score2,
+		NewWinnerID:      winnerID,
+		AffectedMatches:  make(models.CorrectionEffects, 0),
+		CreatedAt:        time.Now(),
+	}
+
+	if err := s.repos.Match.UpdateScoreWithTx(tx, matchID, score1, score2, winnerID, scoreDetails); err != nil {
+		return nil, fmt.Errorf("failed to update score: %w", err)
+	}
+
+	if previousWinner := *match.WinnerID; previousWinner != winnerID {
+		// Move the win from the old winner to the new one; matches played are unchanged
+		if err := s.repos.Participant.UpdateStatsWithTx(tx, previousWinner, 0, -1); err != nil {
+			return nil, fmt.Errorf("failed to revert participant stats: %w", err)
+		}
+		if err := s.repos.Participant.UpdateStatsWithTx(tx, winnerID, 0, 1); err != nil {
+			return nil, fmt.Errorf("failed to update participant stats: %w", err)
+		}
+
+		if match.NextMatchID != nil {
+			effects, err := s.cascadeCorrection(tx, match, *match.NextMatchID, previousWinner, &winnerID)
+			if err != nil {
+				return nil, err
+			}
+			correction.AffectedMatches = effects
+		}
+	}
+
+	// Announce every downstream match whose line-up or result changed
+	var rescheduled []*events.MatchPayload
+	for _, effect := range correction.AffectedMatches {
+		if effect.Action == models.CorrectionResultVoided {
+			continue
+		}
+		payload, err := s.announceCorrectedMatch(tx, effect.MatchID)
+		if err != nil {
+			return nil, err
+		}
+		rescheduled = append(rescheduled, payload)
+	}
+
+	if err := s.repos.ResultCorrection.CreateWithTx(tx, correction); err != nil {
+		return nil, fmt.Errorf("failed to record correction: %w", err)
+	}
+
+	// Queue corrected result notifications with the correction
 	if match.Participant1ID != nil && match.Participant2ID != nil {
-		go s.notification.NotifyMatchResult(match, []string{*match.Participant1ID, *match.Participant2ID})
+		key := fmt.Sprintf("match_result:%s:correction:%s", matchID, correction.ID)
+		if err := s.enqueueMatchNotification(tx, models.NotificationMatchResult, key, match); err != nil {
+			return nil, err
+		}
 	}
 
-	return nil
+	match.Score1, match.Score2, match.WinnerID = &score1, &score2, &winnerID
+	changed := []string{match.ID}
+	for _, effect := range correction.AffectedMatches {
+		changed = append(changed, effect.MatchID)
+	}
+	completed, bracket, err := s.enqueueResultWebhooks(tx, match, changed)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	s.logger.Printf("Result of match %s corrected by %s (%d downstream matches affected)", matchID, correctedBy, len(correction.AffectedMatches))
+
+	// Clear caches
+	s.cache.Delete(fmt.Sprintf("tournament_matches_%s", match.TournamentID))
+	s.cache.Delete(fmt.Sprintf("tournament_bracket_%s", match.TournamentID))
+	s.cache.Delete(fmt.Sprintf("tournament_standings_%s", match.TournamentID))
+	for _, effect := range correction.AffectedMatches {
+		if effect.Action == models.CorrectionResultVoided {
+			s.cache.Delete(fmt.Sprintf("match_live_score_%s", effect.MatchID))
+		}
+	}
+
+	s.bus.Publish(events.MatchCompleted, match.TournamentID, completed)
+	for _, payload := range rescheduled {
+		s.bus.Publish(events.MatchScheduled, match.TournamentID, payload)
+	}
+	s.bus.Publish(events.BracketUpdated, match.TournamentID, bracket)
+
+	return correction, nil
+}
+
+// announceCorrectedMatch queues the match_scheduled notification and webhook for
+// a downstream match changed by a correction, and returns the payload to publish
+// once the transaction commits
+func (s *MatchService) announceCorrectedMatch(tx *sql.Tx, matchID string) (*events.MatchPayload, error) {
+	m, err := s.repos.Match.GetByIDForUpdateWithTx(tx, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get downstream match: %w", err)
+	}
+
+	if m.Participant1ID != nil && m.Participant2ID != nil {
+		if err := s.enqueueMatchNotification(tx, models.NotificationMatchScheduled, matchScheduledKey(m), m); err != nil {
+			return nil, err
+		}
+	}
+
+	payload := events.NewMatchPayload(m)
+	if err := s.webhook.EnqueueWithTx(tx, events.MatchScheduled, m.TournamentID, payload); err != nil {
+		return nil, fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+	return payload, nil
+}
+
+// cascadeCorrection replaces previousID with replacementID in a downstream match, or
+// empties the slot when replacementID is nil. A result already played there is voided,
+// and the cascade continues by removing whoever advanced from it.
+// Only next_match_id links are followed; losers bracket drops are not tracked by matches.
+func (s *MatchService) cascadeCorrection(tx *sql.Tx, source *models.Match, matchID, previousID string, replacementID *string) (models.CorrectionEffects, error) {
+	effects := make(models.CorrectionEffects, 0)
+
+	for {
+		m, err := s.repos.Match.GetByIDForUpdateWithTx(tx, matchID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get downstream match: %w", err)
+		}
+
+		participant1, participant2 := m.Participant1ID, m.Participant2ID
+		switch {
+		case participant1 != nil && *participant1 == previousID:
+			participant1 = replacementID
+		case participant2 != nil && *participant2 == previousID:
+			participant2 = replacementID
+		default:
+			// The participant never reached this match, so nothing further depends on it
+			return effects, nil
+		}
+
+		// Swapping a participant out of a match being played would leave its live score
+		// with the wrong side; the organizer has to settle that match first
+		if m.Status == models.MatchInProgress {
+			return nil, fmt.Errorf("%w: match %d is in progress with the participant being replaced", ErrInvalidInput, m.MatchNumber)
+		}
+
+		if err := s.repos.Match.UpdateParticipantsWithTx(tx, m.ID, participant1, participant2); err != nil {
+			return nil, fmt.Errorf("failed to update downstream match: %w", err)
+		}
+
+		action := models.CorrectionParticipantReplaced
+		if replacementID == nil {
+			action = models.CorrectionParticipantRemoved
+		}
+		previous := previousID
+		effects = append(effects, models.CorrectionEffect{
+			MatchID:    m.ID,
+			Action:     action,
+			PreviousID: &previous,
+			NewID:      replacementID,
+		})
+
+		if m.WinnerID == nil {
+			return effects, nil
+		}
+
+		// The match was played with the wrong participant: void it and revert its stats
+		for _, id := range []*string{m.Participant1ID, m.Participant2ID} {
+			if id == nil {
+				continue
+			}
+			won := 0
+			if *id == *m.WinnerID {
+				won = -1
+			}
+			if err := s.repos.Participant.UpdateStatsWithTx(tx, *id, -1, won); err != nil {
+				return nil, fmt.Errorf("failed to revert participant stats: %w", err)
+			}
+		}
+
+		// Scheduled even without a time, so the replay can be reported
+		status := models.MatchScheduled
+		note := fmt.Sprintf("Result voided after correction of match %d; needs to be replayed", source.MatchNumber)
+		if err := s.repos.Match.ClearResultWithTx(tx, m.ID, status, &note); err != nil {
+			return nil, fmt.Errorf("failed to void downstream result: %w", err)
+		}
+
+		effects = append(effects, models.CorrectionEffect{
+			MatchID:          m.ID,
+			Action:           models.CorrectionResultVoided,
+			PreviousScore1:   m.Score1,
+			PreviousScore2:   m.Score2,
+			PreviousWinnerID: m.WinnerID,
+		})
+
+		if m.NextMatchID == nil {
+			return effects, nil
+		}
+		matchID, previousID, replacementID = *m.NextMatchID, *m.WinnerID, nil
+	}
+}
+
+// GetCorrections retrieves the correction audit trail of a match
+func (s *MatchService) GetCorrections(ctx context.Context, matchID string) ([]*models.ResultCorrection, error) {
+	return s.repos.ResultCorrection.GetByMatchID(ctx, matchID)
+}
+
+// IsOrganizer checks if a user organizes the tournament a match belongs to
+func (s *MatchService) IsOrganizer(ctx context.Context, matchID, userID string) (bool, error) {
+	match, err := s.repos.Match.GetByID(ctx, matchID)
+	if err != nil {
+		return false, err
+	}
+
+	tournament, err := s.repos.Tournament.GetByID(ctx, match.TournamentID)
+	if err != nil {
+		return false, err
+	}
+
+	return tournament.OrganizerID == userID, nil
 }
 
 // StartMatch marks a match as in progress
 func (s *MatchService) StartMatch(ctx context.Context, matchID string) error {
-	return s.repos.Match.UpdateStatus(ctx, matchID, models.MatchInProgress)
+	match, err := s.repos.Match.GetByID(ctx, matchID)
+	if err != nil {
+		return err
+	}
+
+	match.Status = models.MatchInProgress
+	event, err := s.startWithTx(ctx, match)
+	if err != nil {
+		return err
+	}
+
+	s.cache.Delete(fmt.Sprintf("tournament_matches_%s", match.TournamentID))
+
+	s.bus.Publish(events.MatchStarted, match.TournamentID, event)
+
+	return nil
+}
+
+// startWithTx marks a match in progress and queues its match_started webhook
+// deliveries in one transaction. It returns the event payload to publish.
+func (s *MatchService) startWithTx(ctx context.Context, match *models.Match) (*events.MatchPayload, error) {
+	tx, err := s.repos.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := s.repos.Match.UpdateStatusWithTx(tx, match.ID, models.MatchInProgress); err != nil {
+		return nil, err
+	}
+
+	event := events.NewMatchPayload(match)
+	if err := s.webhook.EnqueueWithTx(tx, events.MatchStarted, match.TournamentID, event); err != nil {
+		return nil, fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+
+	return event, tx.Commit()
 }
 
 // CancelMatch cancels a match
@@ -258,3 +622,32 @@ func (s *MatchService) HasAccess(ctx context.Context, matchID, userID string) (b
 func (s *MatchService) GetScheduleByVenueAndDate(ctx context.Context, venueID string, date time.Time) ([]*models.Match, error) {
 	return s.repos.Match.ListByVenueAndDate(ctx, venueID, date)
 }
+
+// enqueueMatchNotification queues a notification about a match within the transaction that changed it
+func (s *MatchService) enqueueMatchNotification(tx *sql.Tx, kind models.NotificationKind, idempotencyKey string, match *models.Match) error {
+	payload := models.NotificationPayload{
+		TournamentID: match.TournamentID,
+		MatchID:      match.ID,
+	}
+	if err := s.notification.EnqueueWithTx(tx, kind, idempotencyKey, payload); err != nil {
+		return fmt.Errorf("failed to queue %s notification: %w", kind, err)
+	}
+	return nil
+}
+
+// matchScheduledKey identifies one scheduling of a match. A new time, venue or
+// participant yields a new key, so each of those is announced again.
+func matchScheduledKey(match *models.Match) string {
+	key := "match_scheduled:" + match.ID
+	for _, part := range []*string{match.Participant1ID, match.Participant2ID, match.VenueID} {
+		if part != nil {
+			key += ":" + *part
+		} else {
+			key += ":-"
+		}
+	}
+	if match.ScheduledDatetime != nil {
+		key += fmt.Sprintf(":%d", match.ScheduledDatetime.Unix())
+	}
+	return key
+}
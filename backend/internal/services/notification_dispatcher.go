@@ -0,0 +1,164 @@
+// internal/services/notification_dispatcher.go
+// Background delivery of the notification outbox with retries and dead-lettering,
+// and release of messages held for quiet hours or a daily digest
+
+package services
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"time"
+
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/repositories"
+	"tournament-planner/internal/utils"
+)
+
+const (
+	// dispatchInterval is how often the outbox is polled for due entries
+	dispatchInterval = 5 * time.Second
+
+	// dispatchBatchSize caps the entries claimed per poll
+	dispatchBatchSize = 50
+
+	// heldBatchSize caps the held messages claimed per poll. It is larger than the
+	// outbox batch so a busy user's digest is rarely split across two claims.
+	heldBatchSize = 500
+
+	// dispatchLease is how long a claimed entry is reserved for this instance
+	dispatchLease = 2 * time.Minute
+
+	// maxDeliveryAttempts is how many attempts an entry gets before it is dead-lettered
+	maxDeliveryAttempts = 8
+
+	// retryBaseDelay and retryMaxDelay bound the exponential backoff between attempts
+	retryBaseDelay = 30 * time.Second
+	retryMaxDelay  = time.Hour
+)
+
+// NotificationDispatcher delivers queued notifications. Entries are claimed with a
+// lease, so several instances can run dispatchers against the same outbox.
+type NotificationDispatcher struct {
+	repos        *repositories.Container
+	notification *NotificationService
+	logger       *log.Logger
+}
+
+// NewNotificationDispatcher creates a new notification dispatcher
+func NewNotificationDispatcher(repos *repositories.Container, notification *NotificationService, logger *log.Logger) *NotificationDispatcher {
+	return &NotificationDispatcher{
+		repos:        repos,
+		notification: notification,
+		logger:       logger,
+	}
+}
+
+// Run polls the outbox until the context is cancelled
+func (d *NotificationDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(dispatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.dispatchDue(ctx)
+			d.releaseHeld(ctx)
+		}
+	}
+}
+
+// dispatchDue claims and delivers one batch of due entries
+func (d *NotificationDispatcher) dispatchDue(ctx context.Context) {
+	entries, err := d.repos.Outbox.ClaimDue(ctx, utils.GenerateUUID(), dispatchBatchSize, dispatchLease)
+	if err != nil {
+		d.logger.Printf("Failed to claim notification outbox entries: %v", err)
+		return
+	}
+
+	for _, entry := range entries {
+		deliverCtx, cancel := context.WithTimeout(ctx, dispatchLease/2)
+		delivered, err := d.notification.Deliver(deliverCtx, entry)
+		cancel()
+
+		if err == nil {
+			if err := d.repos.Outbox.MarkDelivered(ctx, entry.ID, delivered); err != nil {
+				d.logger.Printf("Failed to mark notification %s delivered: %v", entry.ID, err)
+			}
+			continue
+		}
+
+		attempts := entry.Attempts + 1
+		dead := attempts >= maxDeliveryAttempts
+		if dead {
+			d.logger.Printf("Notification %s (%s) dead-lettered after %d attempts: %v", entry.ID, entry.Kind, attempts, err)
+		} else {
+			d.logger.Printf("Notification %s (%s) attempt %d failed: %v", entry.ID, entry.Kind, attempts, err)
+		}
+
+		if err := d.repos.Outbox.MarkFailed(ctx, entry.ID, delivered, err.Error(), retryDelay(attempts), dead); err != nil {
+			d.logger.Printf("Failed to record notification %s failure: %v", entry.ID, err)
+		}
+	}
+}
+
+// retryDelay doubles the delay with every attempt up to the maximum,
+// with up to 10% jitter so failed batches don't retry in lockstep
+func retryDelay(attempt int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempt && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, retryMaxDelay)
+	return delay + time.Duration(rand.Int63n(int64(delay/10)+1))
+}
+
+// releaseHeld claims held messages that came due and sends them, one message per
+// user and channel
+func (d *NotificationDispatcher) releaseHeld(ctx context.Context) {
+	now := time.Now()
+	held, err := d.repos.HeldNotification.ClaimDue(ctx, utils.GenerateUUID(), now, heldBatchSize, dispatchLease)
+	if err != nil {
+		d.logger.Printf("Failed to claim held notifications: %v", err)
+		return
+	}
+
+	groups := make(map[string][]*models.HeldNotification)
+	order := make([]string, 0)
+	for _, h := range held {
+		key := h.UserID + ":" + h.Channel
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], h)
+	}
+
+	for _, key := range order {
+		group := groups[key]
+		ids := make([]string, 0, len(group))
+		attempts := 0
+		for _, h := range group {
+			ids = append(ids, h.ID)
+			attempts = max(attempts, h.Attempts+1)
+		}
+
+		sendCtx, cancel := context.WithTimeout(ctx, dispatchLease/2)
+		err := d.notification.SendHeld(sendCtx, group)
+		cancel()
+
+		if err == nil {
+			if err := d.repos.HeldNotification.MarkSent(ctx, ids); err != nil {
+				d.logger.Printf("Failed to mark held notifications for %s sent: %v", key, err)
+			}
+			continue
+		}
+
+		dead := attempts >= maxDeliveryAttempts
+		d.logger.Printf("Sending %d held notifications for %s failed (attempt %d): %v", len(group), key, attempts, err)
+		if err := d.repos.HeldNotification.MarkFailed(ctx, ids, err.Error(), now.Add(retryDelay(attempts)), dead); err != nil {
+			d.logger.Printf("Failed to record held notification failure for %s: %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,650 @@
+// internal/services/notification_service.go
+// Notification rendering, recipient resolution and delivery across channels
+// according to each recipient's preferences
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"tournament-planner/internal/config"
+	"tournament-planner/internal/events"
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/notifications"
+	"tournament-planner/internal/render"
+	"tournament-planner/internal/repositories"
+	"tournament-planner/internal/utils"
+)
+
+// NotificationService handles all notification operations
+type NotificationService struct {
+	repos     *repositories.Container
+	channels  *notifications.Router
+	templates *notifications.Templates
+	config    *config.Config
+	logger    *log.Logger
+}
+
+// NewNotificationService creates a new notification service
+func NewNotificationService(
+	repos *repositories.Container,
+	channels *notifications.Router,
+	templates *notifications.Templates,
+	config *config.Config,
+	logger *log.Logger,
+) *NotificationService {
+	return &NotificationService{
+		repos:     repos,
+		channels:  channels,
+		templates: templates,
+		config:    config,
+		logger:    logger,
+	}
+}
+
+// newNotificationChannels registers a sender for each channel that can be delivered.
+// Email goes through SMTP or a local sink depending on configuration, and in-app
+// notifications are published on the event bus for the websocket hub. SMS and push
+// have no provider yet, so they are left unregistered.
+func newNotificationChannels(cfg config.NotificationConfig, bus *events.Bus) *notifications.Router {
+	router := notifications.NewRouter()
+
+	switch cfg.Sink {
+	case "smtp":
+		router.Register(notifications.ChannelEmail, notifications.NewSMTPSender(
+			cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.FromAddress, cfg.FromName,
+		))
+	case "memory":
+		router.Register(notifications.ChannelEmail, notifications.NewMemorySink())
+	default:
+		router.Register(notifications.ChannelEmail, notifications.NewFileSink(cfg.FilePath))
+	}
+
+	router.Register(notifications.ChannelInApp, notifications.SenderFunc(func(ctx context.Context, message *notifications.Message) error {
+		bus.PublishToUser(events.Notification, message.To.UserID, &events.NotificationPayload{
+			Template: message.Template,
+			Subject:  message.Subject,
+			Body:     message.Body,
+		})
+		return nil
+	}))
+
+	return router
+}
+
+// addressee is a resolved recipient with their notification preferences
+type addressee struct {
+	recipient notifications.Recipient
+	prefs     notifications.Preferences
+}
+
+// notice is one rendered-to-be notification for one recipient. Attachments
+// only go out by email, and only notifications that are never held have them.
+type notice struct {
+	addressee
+	template    string
+	data        *notifications.TemplateData
+	attachments []notifications.Attachment
+}
+
+// EnqueueWithTx queues a notification in the outbox within the transaction that made
+// the change it announces, so it is delivered if and only if the change commits.
+// Enqueueing the same idempotency key again is a no-op.
+func (s *NotificationService) EnqueueWithTx(tx *sql.Tx, kind models.NotificationKind, idempotencyKey string, payload models.NotificationPayload) error {
+	return s.repos.Outbox.EnqueueWithTx(tx, newOutboxEntry(kind, idempotencyKey, payload))
+}
+
+// Enqueue queues a notification in the outbox for changes made outside a transaction
+func (s *NotificationService) Enqueue(ctx context.Context, kind models.NotificationKind, idempotencyKey string, payload models.NotificationPayload) error {
+	return s.repos.Outbox.Enqueue(ctx, newOutboxEntry(kind, idempotencyKey, payload))
+}
+
+// newOutboxEntry builds a pending outbox entry
+func newOutboxEntry(kind models.NotificationKind, idempotencyKey string, payload models.NotificationPayload) *models.OutboxEntry {
+	return &models.OutboxEntry{
+		ID:             utils.GenerateUUID(),
+		IdempotencyKey: idempotencyKey,
+		Kind:           kind,
+		Payload:        payload,
+		Status:         models.OutboxPending,
+		DeliveredTo:    models.DeliveryKeys{},
+	}
+}
+
+// Deliver renders an outbox entry from current data and sends it to every recipient
+// on every channel they can be reached on and have not turned off. Messages that
+// fall in the recipient's quiet hours or belong in their daily digest are held
+// instead, which counts as delivered, unless their kind is never held. Recipient and channel pairs already in the
+// entry's DeliveredTo are skipped, so a retry never repeats a successful delivery.
+// It returns the updated delivery keys and an error if any delivery failed.
+func (s *NotificationService) Deliver(ctx context.Context, entry *models.OutboxEntry) (models.DeliveryKeys, error) {
+	delivered := append(models.DeliveryKeys{}, entry.DeliveredTo...)
+
+	if !s.config.Features.EnableNotifications {
+		return delivered, nil
+	}
+
+	notices, err := s.buildNotices(ctx, entry)
+	if err != nil {
+		return delivered, err
+	}
+
+	now := time.Now()
+	var failures []string
+	for _, n := range notices {
+		n.data.RecipientName = n.recipient.Name
+		subject, body, err := s.templates.Render(n.template, n.recipient.Locale, n.data)
+		if err != nil {
+			return delivered, fmt.Errorf("failed to render %s: %w", n.template, err)
+		}
+
+		for _, channel := range s.channelsFor(n.recipient, n.prefs) {
+			key := recipientKey(n.recipient) + ":" + string(channel)
+			if delivered.Contains(key) {
+				continue
+			}
+
+			message := &notifications.Message{
+				Channel:   channel,
+				Template:  n.template,
+				To:        n.recipient,
+				Subject:   subject,
+				Body:      body,
+				CreatedAt: now,
+			}
+			if channel == notifications.ChannelEmail {
+				message.Attachments = n.attachments
+			}
+
+			digest := entry.Kind.Digestible() && n.prefs.Digest
+			at := now
+			if entry.Kind.Holdable() {
+				at = n.prefs.DeliverAt(now, channel, entry.Kind.Digestible())
+			}
+			if at.After(now) && n.recipient.UserID != "" {
+				err = s.hold(ctx, entry.ID+":"+key, message, n.data, at, digest)
+			} else {
+				err = s.channels.Send(ctx, message)
+			}
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", key, err))
+				continue
+			}
+			delivered = append(delivered, key)
+		}
+	}
+
+	if len(failures) > 0 {
+		return delivered, fmt.Errorf("%d of the deliveries failed: %s", len(failures), strings.Join(failures, "; "))
+	}
+	return delivered, nil
+}
+
+// hold stores a rendered message to be sent at a later time
+func (s *NotificationService) hold(ctx context.Context, sourceKey string, message *notifications.Message, data *notifications.TemplateData, at time.Time, digest bool) error {
+	summary, err := s.templates.Summary(message.Template, message.To.Locale, data)
+	if err != nil {
+		return fmt.Errorf("failed to render %s summary: %w", message.Template, err)
+	}
+
+	held := &models.HeldNotification{
+		ID:            utils.GenerateUUID(),
+		SourceKey:     sourceKey,
+		UserID:        message.To.UserID,
+		RecipientName: message.To.Name,
+		Locale:        message.To.Locale,
+		Channel:       string(message.Channel),
+		Template:      message.Template,
+		Subject:       message.Subject,
+		Body:          message.Body,
+		Summary:       summary,
+		Digest:        digest,
+		DeliverAfter:  at,
+	}
+	if message.To.ParticipantID != "" {
+		held.ParticipantID = &message.To.ParticipantID
+	}
+	if message.To.Email != "" {
+		held.Email = &message.To.Email
+	}
+	if message.To.Phone != "" {
+		held.Phone = &message.To.Phone
+	}
+
+	return s.repos.HeldNotification.Hold(ctx, held)
+}
+
+// SendHeld sends held messages that came due for one user on one channel. A single
+// message held for quiet hours goes out as it was rendered; anything else is
+// combined into one digest listing each message's summary.
+func (s *NotificationService) SendHeld(ctx context.Context, held []*models.HeldNotification) error {
+	if len(held) == 0 {
+		return nil
+	}
+
+	first := held[0]
+	recipient := notifications.Recipient{
+		UserID: first.UserID,
+		Name:   first.RecipientName,
+		Locale: first.Locale,
+	}
+	if first.ParticipantID != nil {
+		recipient.ParticipantID = *first.ParticipantID
+	}
+	if first.Email != nil {
+		recipient.Email = *first.Email
+	}
+	if first.Phone != nil {
+		recipient.Phone = *first.Phone
+	}
+
+	message := &notifications.Message{
+		Channel:   notifications.Channel(first.Channel),
+		Template:  first.Template,
+		To:        recipient,
+		Subject:   first.Subject,
+		Body:      first.Body,
+		CreatedAt: time.Now(),
+	}
+
+	if len(held) > 1 || first.Digest {
+		data := &notifications.TemplateData{RecipientName: recipient.Name}
+		for _, h := range held {
+			data.Items = append(data.Items, h.Summary)
+		}
+
+		subject, body, err := s.templates.Render(notifications.TemplateDigest, recipient.Locale, data)
+		if err != nil {
+			return fmt.Errorf("failed to render digest: %w", err)
+		}
+		message.Template = notifications.TemplateDigest
+		message.Subject = subject
+		message.Body = body
+	}
+
+	return s.channels.Send(ctx, message)
+}
+
+// ListOutbox lists outbox entries in a status for inspection
+func (s *NotificationService) ListOutbox(ctx context.Context, status models.OutboxStatus, limit, offset int) ([]*models.OutboxEntry, error) {
+	return s.repos.Outbox.List(ctx, status, limit, offset)
+}
+
+// ReplayOutbox makes a dead-lettered entry due again
+func (s *NotificationService) ReplayOutbox(ctx context.Context, id string) error {
+	replayed, err := s.repos.Outbox.Replay(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !replayed {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// buildNotices resolves the recipients and template data of an outbox entry
+func (s *NotificationService) buildNotices(ctx context.Context, entry *models.OutboxEntry) ([]notice, error) {
+	tournament, err := s.repos.Tournament.GetByID(ctx, entry.Payload.TournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tournament %s: %w", entry.Payload.TournamentID, err)
+	}
+
+	switch entry.Kind {
+	case models.NotificationTournamentPublished:
+		participants, err := s.repos.TournamentParticipant.GetByTournamentID(ctx, tournament.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load participants: %w", err)
+		}
+		return s.tournamentNotices(ctx, notifications.TemplateTournamentPublished, tournament, participants), nil
+
+	case models.NotificationFixturesGenerated:
+		participants := s.loadParticipants(ctx, entry.Payload.ParticipantIDs)
+		return s.tournamentNotices(ctx, notifications.TemplateFixturesGenerated, tournament, participants), nil
+
+	case models.NotificationParticipantRegistered:
+		participants := s.loadParticipants(ctx, entry.Payload.ParticipantIDs)
+		return s.organizerNotices(ctx, notifications.TemplateParticipantRegistered, tournament, participants), nil
+
+	case models.NotificationPaymentReceipt:
+		return s.receiptNotices(ctx, tournament, entry.Payload)
+	}
+
+	match, err := s.repos.Match.GetByID(ctx, entry.Payload.MatchID)
+	if err != nil || match == nil {
+		return nil, fmt.Errorf("failed to load match %s: %v", entry.Payload.MatchID, err)
+	}
+
+	switch entry.Kind {
+	case models.NotificationMatchScheduled:
+		return s.matchNotices(ctx, notifications.TemplateMatchScheduled, tournament, match, nil), nil
+
+	case models.NotificationMatchResult:
+		return s.matchNotices(ctx, notifications.TemplateMatchResult, tournament, match, nil), nil
+
+	case models.NotificationMatchReminder:
+		// A reminder for a time the match has since moved from is dropped;
+		// the reminder scheduler queues one for the new time
+		scheduledFor := entry.Payload.ScheduledFor
+		if match.Status != models.MatchScheduled || match.ScheduledDatetime == nil ||
+			scheduledFor == nil || !match.ScheduledDatetime.Equal(*scheduledFor) {
+			return nil, nil
+		}
+		return s.matchNotices(ctx, notifications.TemplateMatchReminder, tournament, match, nil), nil
+
+	case models.NotificationReminderCorrection:
+		return s.matchNotices(ctx, notifications.TemplateReminderCorrection, tournament, match, entry.Payload.ScheduledFor), nil
+	}
+
+	return nil, fmt.Errorf("unknown notification kind %q", entry.Kind)
+}
+
+// tournamentNotices addresses a tournament-wide template to participants
+func (s *NotificationService) tournamentNotices(ctx context.Context, templateKey string, tournament *models.Tournament, participants []*models.Participant) []notice {
+	notices := make([]notice, 0, len(participants))
+	for _, to := range s.addresseesFor(ctx, participants) {
+		notices = append(notices, notice{
+			addressee: to,
+			template:  templateKey,
+			data: &notifications.TemplateData{
+				TournamentName: tournament.Name,
+				TournamentURL:  s.tournamentURL(tournament.ID),
+				StartDate:      tournament.StartDate.Format("2 January 2006"),
+			},
+		})
+	}
+	return notices
+}
+
+// organizerNotices addresses a template about participants to the tournament's organizer
+func (s *NotificationService) organizerNotices(ctx context.Context, templateKey string, tournament *models.Tournament, participants []*models.Participant) []notice {
+	if len(participants) == 0 {
+		return nil
+	}
+
+	organizer, err := s.repos.User.GetByID(ctx, tournament.OrganizerID)
+	if err != nil || organizer == nil {
+		s.logger.Printf("Skipping notification for organizer %s: %v", tournament.OrganizerID, err)
+		return nil
+	}
+
+	names := make([]string, 0, len(participants))
+	for _, p := range participants {
+		names = append(names, p.Name)
+	}
+
+	recipient := notifications.Recipient{
+		Name:   organizer.FullName,
+		Locale: s.config.Notifications.DefaultLocale,
+	}
+	return []notice{{
+		addressee: s.withAccount(ctx, recipient, &organizer.ID),
+		template:  templateKey,
+		data: &notifications.TemplateData{
+			TournamentName: tournament.Name,
+			TournamentURL:  s.tournamentURL(tournament.ID),
+			Participants:   strings.Join(names, ", "),
+		},
+	}}
+}
+
+// receiptNotices confirms a payment to the participant who paid, with the
+// invoice attached to the email
+func (s *NotificationService) receiptNotices(ctx context.Context, tournament *models.Tournament, payload models.NotificationPayload) ([]notice, error) {
+	invoice, err := s.repos.Invoice.GetByID(ctx, payload.InvoiceID)
+	if err != nil || invoice == nil {
+		return nil, fmt.Errorf("failed to load invoice %s: %v", payload.InvoiceID, err)
+	}
+
+	var pdf bytes.Buffer
+	if err := render.InvoicePDF(&pdf, invoice); err != nil {
+		return nil, err
+	}
+	attachment := notifications.Attachment{
+		Filename:    invoice.Number + ".pdf",
+		ContentType: "application/pdf",
+		Data:        pdf.Bytes(),
+	}
+
+	participants := s.loadParticipants(ctx, payload.ParticipantIDs)
+	notices := make([]notice, 0, len(participants))
+	for _, to := range s.addresseesFor(ctx, participants) {
+		notices = append(notices, notice{
+			addressee: to,
+			template:  notifications.TemplatePaymentReceipt,
+			data: &notifications.TemplateData{
+				TournamentName: tournament.Name,
+				TournamentURL:  s.tournamentURL(tournament.ID),
+				Amount:         invoice.Total.String(),
+				InvoiceNumber:  invoice.Number,
+			},
+			attachments: []notifications.Attachment{attachment},
+		})
+	}
+	return notices, nil
+}
+
+// matchNotices addresses a match template to both participants from their own
+// point of view. Reminders and their corrections also go to the assigned referee.
+// previous is the time a correction supersedes.
+func (s *NotificationService) matchNotices(ctx context.Context, templateKey string, tournament *models.Tournament, match *models.Match, previous *time.Time) []notice {
+	participantIDs := make([]string, 0, 2)
+	for _, id := range []*string{match.Participant1ID, match.Participant2ID} {
+		if id != nil {
+			participantIDs = append(participantIDs, *id)
+		}
+	}
+	participants := s.loadParticipants(ctx, participantIDs)
+	names := make(map[string]string, len(participants))
+	for _, p := range participants {
+		names[p.ID] = p.Name
+	}
+
+	base := notifications.TemplateData{
+		TournamentName: tournament.Name,
+		TournamentURL:  s.tournamentURL(tournament.ID),
+		Round:          match.RoundNumber,
+	}
+	if match.ScheduledDatetime != nil && match.Status != models.MatchCancelled && match.Status != models.MatchPostponed {
+		base.ScheduledAt = localTime(tournament, *match.ScheduledDatetime)
+	}
+	if previous != nil {
+		base.PreviousAt = localTime(tournament, *previous)
+	}
+	if match.VenueID != nil {
+		if venue, err := s.repos.Venue.GetByID(ctx, *match.VenueID); err == nil && venue != nil {
+			base.Venue = venue.Name
+		}
+	}
+	if match.WinnerID != nil {
+		base.Winner = names[*match.WinnerID]
+	}
+
+	notices := make([]notice, 0, len(participants))
+	for _, to := range s.addresseesFor(ctx, participants) {
+		data := base
+		own, opponent, ownScore, opponentScore := match.Participant1ID, match.Participant2ID, match.Score1, match.Score2
+		if own == nil || *own != to.recipient.ParticipantID {
+			own, opponent, ownScore, opponentScore = opponent, own, opponentScore, ownScore
+		}
+		if opponent != nil {
+			data.Opponent = names[*opponent]
+		}
+		if ownScore != nil && opponentScore != nil {
+			data.Score = fmt.Sprintf("%d-%d", *ownScore, *opponentScore)
+		}
+		data.Won = match.WinnerID != nil && own != nil && *match.WinnerID == *own
+
+		notices = append(notices, notice{addressee: to, template: templateKey, data: &data})
+	}
+
+	remindsReferee := templateKey == notifications.TemplateMatchReminder || templateKey == notifications.TemplateReminderCorrection
+	if remindsReferee && match.RefereeID != nil {
+		referee, err := s.repos.Referee.GetByID(ctx, *match.RefereeID)
+		if err != nil {
+			s.logger.Printf("Skipping notification for referee %s: %v", *match.RefereeID, err)
+			return notices
+		}
+
+		data := base
+		matchup := make([]string, 0, len(participants))
+		for _, p := range participants {
+			matchup = append(matchup, p.Name)
+		}
+		data.Participants = strings.Join(matchup, " vs ")
+
+		notices = append(notices, notice{addressee: s.refereeAddressee(ctx, referee), template: templateKey, data: &data})
+	}
+
+	return notices
+}
+
+// localTime formats a time in a tournament's timezone
+func localTime(tournament *models.Tournament, t time.Time) string {
+	return t.In(tournamentLocation(tournament)).Format("Mon 2 Jan 2006 15:04 MST")
+}
+
+// tournamentLocation is a tournament's timezone, or UTC if the zone is unknown
+func tournamentLocation(tournament *models.Tournament) *time.Location {
+	location, err := time.LoadLocation(tournament.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// recipientKey identifies a recipient in delivery keys
+func recipientKey(recipient notifications.Recipient) string {
+	if recipient.ParticipantID != "" {
+		return "participant:" + recipient.ParticipantID
+	}
+	if recipient.UserID != "" {
+		return "user:" + recipient.UserID
+	}
+	return "email:" + recipient.Email
+}
+
+// ResolveRecipients turns participant IDs into notification recipients with contact details
+func (s *NotificationService) ResolveRecipients(ctx context.Context, participantIDs []string) []notifications.Recipient {
+	addressees := s.addresseesFor(ctx, s.loadParticipants(ctx, participantIDs))
+	recipients := make([]notifications.Recipient, 0, len(addressees))
+	for _, to := range addressees {
+		recipients = append(recipients, to.recipient)
+	}
+	return recipients
+}
+
+// loadParticipants fetches participants by ID, skipping any that cannot be loaded
+func (s *NotificationService) loadParticipants(ctx context.Context, participantIDs []string) []*models.Participant {
+	participants := make([]*models.Participant, 0, len(participantIDs))
+	for _, id := range participantIDs {
+		p, err := s.repos.Participant.GetByID(ctx, id)
+		if err != nil || p == nil {
+			s.logger.Printf("Skipping notification for participant %s: %v", id, err)
+			continue
+		}
+		participants = append(participants, p)
+	}
+	return participants
+}
+
+// addresseesFor resolves contact details and preferences for participants. A
+// participant's own contact details come first; the linked user account fills in
+// what is missing and provides the locale and notification preferences.
+// Participants without an account get the default preferences.
+func (s *NotificationService) addresseesFor(ctx context.Context, participants []*models.Participant) []addressee {
+	addressees := make([]addressee, 0, len(participants))
+	for _, p := range participants {
+		recipient := notifications.Recipient{
+			ParticipantID: p.ID,
+			Name:          p.Name,
+			Locale:        s.config.Notifications.DefaultLocale,
+		}
+		if p.ContactEmail != nil {
+			recipient.Email = *p.ContactEmail
+		}
+		if p.ContactPhone != nil {
+			recipient.Phone = *p.ContactPhone
+		}
+
+		addressees = append(addressees, s.withAccount(ctx, recipient, p.UserID))
+	}
+	return addressees
+}
+
+// refereeAddressee resolves contact details and preferences for a referee the
+// same way as for a participant
+func (s *NotificationService) refereeAddressee(ctx context.Context, referee *models.Referee) addressee {
+	recipient := notifications.Recipient{
+		Name:   referee.Name,
+		Locale: s.config.Notifications.DefaultLocale,
+	}
+	if referee.ContactEmail != nil {
+		recipient.Email = *referee.ContactEmail
+	}
+	if referee.ContactPhone != nil {
+		recipient.Phone = *referee.ContactPhone
+	}
+	return s.withAccount(ctx, recipient, referee.UserID)
+}
+
+// withAccount completes a recipient from their linked user account, if any: the
+// account fills in missing contact details and provides the locale and
+// notification preferences. Without an account the defaults apply.
+func (s *NotificationService) withAccount(ctx context.Context, recipient notifications.Recipient, userID *string) addressee {
+	prefs := notifications.DefaultPreferences()
+	if userID == nil {
+		return addressee{recipient: recipient, prefs: prefs}
+	}
+
+	recipient.UserID = *userID
+	if user, err := s.repos.User.GetByID(ctx, *userID); err == nil && user != nil {
+		if recipient.Email == "" {
+			recipient.Email = user.Email
+		}
+		if recipient.Phone == "" && user.Phone != nil {
+			recipient.Phone = *user.Phone
+		}
+	}
+	if doc, err := s.repos.UserPreferences.Get(ctx, *userID); err == nil && doc != nil {
+		if language, ok := doc["language"].(string); ok && language != "" {
+			recipient.Locale = language
+		}
+		if prefs, err = notifications.ParsePreferences(doc); err != nil {
+			s.logger.Printf("Ignoring invalid notification preferences of user %s: %v", *userID, err)
+		}
+	}
+
+	return addressee{recipient: recipient, prefs: prefs}
+}
+
+// channelsFor lists the registered channels a recipient can be reached on and
+// has not turned off
+func (s *NotificationService) channelsFor(recipient notifications.Recipient, prefs notifications.Preferences) []notifications.Channel {
+	reachable := map[notifications.Channel]bool{
+		notifications.ChannelEmail: recipient.Email != "",
+		notifications.ChannelSMS:   recipient.Phone != "",
+		notifications.ChannelPush:  recipient.UserID != "",
+		notifications.ChannelInApp: recipient.UserID != "",
+	}
+
+	channels := make([]notifications.Channel, 0, 2)
+	for _, channel := range []notifications.Channel{
+		notifications.ChannelEmail, notifications.ChannelSMS, notifications.ChannelPush, notifications.ChannelInApp,
+	} {
+		if reachable[channel] && prefs.Allows(channel) && s.channels.Has(channel) {
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
+
+// tournamentURL links to a tournament's page on the frontend
+func (s *NotificationService) tournamentURL(tournamentID string) string {
+	return fmt.Sprintf("%s/tournaments/%s", s.config.External.FrontendURL, tournamentID)
+}
@@ -0,0 +1,114 @@
+// internal/services/payment_cancellation_test.go
+// Provider payments that are open or settle while a tournament is cancelled
+
+package services
+
+import (
+	"io"
+	"log"
+	"testing"
+
+	"tournament-planner/internal/config"
+	"tournament-planner/internal/events"
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/payments"
+)
+
+// startPayment starts a participant's payment without settling it and returns it
+func (f *revenueFixture) startPayment(t *testing.T, participantID string) *models.Payment {
+	t.Helper()
+
+	checkout, err := f.payments.CreatePayment(f.ctx, testTournamentID, participantID, testOrganizerID)
+	if err != nil {
+		t.Fatalf("create payment: %v", err)
+	}
+	payment, err := f.repos.Payment.GetByID(f.ctx, checkout.PaymentID)
+	if err != nil || payment == nil || payment.ProviderPaymentID == nil {
+		t.Fatalf("payment %s was not started: %v", checkout.PaymentID, err)
+	}
+	return payment
+}
+
+func TestCancelCancelsOpenPayments(t *testing.T) {
+	f := newRevenueFixture(t)
+	f.register(t, "p1", models.PriceStandard, 5000)
+	f.register(t, "p2", models.PriceStandard, 5000)
+
+	paid := f.payOnline(t, "p1", 170)
+	open := f.startPayment(t, "p2")
+
+	logger := log.New(io.Discard, "", 0)
+	cfg := &config.Config{}
+	tournaments := NewTournamentService(f.repos, newTestCache(t),
+		NewNotificationService(f.repos, nil, nil, cfg, logger), f.payments,
+		NewWebhookService(f.repos, nil, cfg, logger), events.NewBus(logger), logger)
+
+	if _, err := tournaments.Cancel(f.ctx, testTournamentID, testOrganizerID, CancelTournamentRequest{Reason: "venue flooded"}); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+
+	held, _ := f.provider.Payment(*open.ProviderPaymentID)
+	if !held.Canceled {
+		t.Errorf("open payment of p2 was not canceled with the provider")
+	}
+	canceled, err := f.repos.Payment.GetByID(f.ctx, open.ID)
+	if err != nil {
+		t.Fatalf("load payment: %v", err)
+	}
+	if canceled.Status != models.ChargeCanceled {
+		t.Errorf("open payment status = %s, want %s", canceled.Status, models.ChargeCanceled)
+	}
+
+	f.payments.refundDue(f.ctx)
+	if held, _ := f.provider.Payment(paid); len(held.Refunds) != 1 {
+		t.Errorf("settled payment of p1 has %d refunds, want 1", len(held.Refunds))
+	}
+}
+
+func TestPaymentSettlingAfterCancellationIsRefunded(t *testing.T) {
+	f := newRevenueFixture(t)
+	f.register(t, "p1", models.PriceStandard, 5000)
+	payment := f.startPayment(t, "p1")
+
+	// Cancelled while the payer was checking out; nothing had settled yet
+	tx, err := f.repos.BeginTx(f.ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	if err := f.repos.Tournament.UpdateStatusWithTx(tx, testTournamentID, models.StatusCancelled); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	if _, err := f.payments.QueueRefundBatchWithTx(f.ctx, tx, testTournamentID, testOrganizerID, "venue flooded"); err != nil {
+		t.Fatalf("queue refunds: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+
+	f.send(t, &payments.Event{
+		Type:              payments.EventPaymentSucceeded,
+		ProviderPaymentID: *payment.ProviderPaymentID,
+		Amount:            5000,
+	})
+
+	batch, err := f.repos.RefundBatch.GetLatestByTournament(f.ctx, testTournamentID, models.RefundBatchCancellation)
+	if err != nil || batch == nil {
+		t.Fatalf("load cancellation batch: %v", err)
+	}
+	if batch.CompletedAt != nil {
+		t.Errorf("cancellation batch is still completed after a late payment was added")
+	}
+	items, err := f.repos.RefundBatch.ListItems(f.ctx, batch.ID)
+	if err != nil {
+		t.Fatalf("list items: %v", err)
+	}
+	if len(items) != 1 || items[0].PaymentID != payment.ID || items[0].Amount.Amount != 5000 {
+		t.Fatalf("cancellation batch items = %+v, want a full refund of the late payment", items)
+	}
+
+	f.payments.refundDue(f.ctx)
+	held, _ := f.provider.Payment(*payment.ProviderPaymentID)
+	if len(held.Refunds) != 1 || held.Refunds[0].Reason != "tournament_cancelled" {
+		t.Fatalf("provider refunds = %+v, want one for the cancellation", held.Refunds)
+	}
+}
@@ -0,0 +1,53 @@
+// internal/services/payment_capture_test.go
+// Entry fees authorized online and captured by the organizer
+
+package services
+
+import (
+	"testing"
+
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/payments"
+)
+
+func TestCaptureEntryFeeAuthorizedForLater(t *testing.T) {
+	f := newRevenueFixture(t)
+	f.exec(t, `UPDATE tournaments SET capture_entry_fees_later = TRUE WHERE id = ?`, testTournamentID)
+	f.register(t, "p1", models.PriceStandard, 5000)
+
+	payment := f.startPayment(t, "p1")
+	held, _ := f.provider.Payment(*payment.ProviderPaymentID)
+	if !held.Request.CaptureLater {
+		t.Fatalf("payment was started for immediate capture")
+	}
+
+	// Nothing can be captured until the payer has authorized the payment
+	if err := f.payments.CapturePayment(f.ctx, payment.ID, testOrganizerID); err == nil {
+		t.Fatalf("captured a payment that was not authorized")
+	}
+
+	f.send(t, &payments.Event{
+		Type:              payments.EventPaymentAuthorized,
+		ProviderPaymentID: *payment.ProviderPaymentID,
+		Amount:            5000,
+	})
+	if err := f.payments.CapturePayment(f.ctx, payment.ID, testOrganizerID); err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+	if held, _ := f.provider.Payment(*payment.ProviderPaymentID); !held.Captured {
+		t.Fatalf("provider did not capture the payment")
+	}
+
+	f.send(t, &payments.Event{
+		Type:              payments.EventPaymentSucceeded,
+		ProviderPaymentID: *payment.ProviderPaymentID,
+		Amount:            5000,
+	})
+	captured, err := f.repos.Payment.GetByID(f.ctx, payment.ID)
+	if err != nil {
+		t.Fatalf("load payment: %v", err)
+	}
+	if captured.Status != models.ChargeSucceeded {
+		t.Fatalf("payment status = %s, want %s", captured.Status, models.ChargeSucceeded)
+	}
+}
@@ -0,0 +1,309 @@
+// internal/services/payment_invoices.go
+// Invoices issued when entry fees settle, credit notes for their refunds, and
+// the organizer billing profile printed on both
+
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/utils"
+)
+
+// Payment method labels printed on invoices
+const (
+	invoiceMethodOnline = "Card (online)"
+	invoiceMethodCash   = "Cash at the venue"
+	invoiceMethodCard   = "Card at the venue"
+)
+
+// BillingProfileRequest sets what an organizer prints on their invoices.
+// The tax rate is in basis points, so 2000 is 20%.
+type BillingProfileRequest struct {
+	LegalName          string `json:"legal_name" binding:"required,max=200"`
+	Address            string `json:"address" binding:"max=500"`
+	TaxID              string `json:"tax_id" binding:"max=50"`
+	Email              string `json:"email" binding:"omitempty,email"`
+	TaxLabel           string `json:"tax_label" binding:"max=20"`
+	TaxRateBasisPoints int    `json:"tax_rate_basis_points" binding:"min=0,max=10000"`
+}
+
+// GetBillingProfile retrieves the signed-in organizer's billing profile
+func (s *PaymentService) GetBillingProfile(ctx context.Context, userID string) (*models.BillingProfile, error) {
+	profile, err := s.repos.Invoice.GetBillingProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, ErrNotFound
+	}
+	return profile, nil
+}
+
+// UpdateBillingProfile sets the signed-in organizer's billing profile. Invoices
+// already issued keep the details they were issued with.
+func (s *PaymentService) UpdateBillingProfile(ctx context.Context, userID string, req BillingProfileRequest) (*models.BillingProfile, error) {
+	profile := &models.BillingProfile{
+		UserID:             userID,
+		LegalName:          strings.TrimSpace(req.LegalName),
+		Address:            optionalString(req.Address),
+		TaxID:              optionalString(req.TaxID),
+		Email:              optionalString(req.Email),
+		TaxLabel:           strings.TrimSpace(req.TaxLabel),
+		TaxRateBasisPoints: req.TaxRateBasisPoints,
+		UpdatedAt:          time.Now(),
+	}
+	if profile.LegalName == "" {
+		return nil, fmt.Errorf("%w: legal name is required", ErrInvalidInput)
+	}
+	if profile.TaxRateBasisPoints > 0 && profile.TaxLabel == "" {
+		profile.TaxLabel = "Tax"
+	}
+
+	if err := s.repos.Invoice.UpsertBillingProfile(ctx, profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// optionalString trims a value and turns an empty one into nil
+func optionalString(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// GetInvoice retrieves an invoice or credit note for the organizer who issued it
+// or the user whose participant it was issued to
+func (s *PaymentService) GetInvoice(ctx context.Context, invoiceID, userID string) (*models.Invoice, error) {
+	invoice, err := s.repos.Invoice.GetByID(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice == nil {
+		return nil, ErrNotFound
+	}
+	if invoice.OrganizerID == userID {
+		return invoice, nil
+	}
+
+	participant, err := s.repos.Participant.GetByID(ctx, invoice.ParticipantID)
+	if err != nil {
+		return nil, err
+	}
+	if participant == nil || participant.UserID == nil || *participant.UserID != userID {
+		return nil, ErrForbidden
+	}
+	return invoice, nil
+}
+
+// ListInvoices lists the invoices and credit notes issued for a tournament
+func (s *PaymentService) ListInvoices(ctx context.Context, tournamentID string) ([]*models.Invoice, error) {
+	return s.repos.Invoice.ListByTournament(ctx, tournamentID)
+}
+
+// ListUserInvoices lists the invoices and credit notes issued to a user's participants
+func (s *PaymentService) ListUserInvoices(ctx context.Context, userID string) ([]*models.Invoice, error) {
+	return s.repos.Invoice.ListByUser(ctx, userID)
+}
+
+// invoicePayment issues the invoice for a provider payment that settled. A
+// participant who withdrew while paying is still invoiced for what they paid.
+func (s *PaymentService) invoicePayment(ctx context.Context, tx *sql.Tx, payment *models.Payment, amount models.Money) error {
+	tournament, err := s.repos.Tournament.GetByID(ctx, payment.TournamentID)
+	if err != nil {
+		return err
+	}
+	participant, err := s.repos.TournamentParticipant.Get(ctx, payment.TournamentID, payment.ParticipantID)
+	if err == nil && participant == nil {
+		participant, err = s.repos.Participant.GetByID(ctx, payment.ParticipantID)
+	}
+	if err != nil {
+		return err
+	}
+	if participant == nil {
+		return fmt.Errorf("participant %s no longer exists", payment.ParticipantID)
+	}
+
+	_, err = s.issueInvoiceWithTx(ctx, tx, tournament, participant, payment.ID, amount, invoiceMethodOnline, time.Now())
+	return err
+}
+
+// creditRefund issues a credit note against the invoice of a refunded payment.
+// Payments settled before invoicing was introduced have no invoice to credit.
+func (s *PaymentService) creditRefund(tx *sql.Tx, payment *models.Payment, refundReference string, amount models.Money) error {
+	invoice, err := s.repos.Invoice.GetBySourceWithTx(tx, models.InvoiceKindInvoice, payment.ID)
+	if err != nil || invoice == nil {
+		return err
+	}
+	_, err = s.issueCreditNoteWithTx(tx, invoice, refundReference, amount, time.Now())
+	return err
+}
+
+// issueInvoiceWithTx issues the invoice for a settled entry fee within the
+// transaction that settles it, and queues the payer's confirmation email with
+// the invoice attached. sourceReference identifies the payment or collection;
+// an invoice already issued for it is returned as is.
+func (s *PaymentService) issueInvoiceWithTx(ctx context.Context, tx *sql.Tx, tournament *models.Tournament, participant *models.Participant, sourceReference string, amount models.Money, method string, at time.Time) (*models.Invoice, error) {
+	existing, err := s.repos.Invoice.GetBySourceWithTx(tx, models.InvoiceKindInvoice, sourceReference)
+	if err != nil || existing != nil {
+		return existing, err
+	}
+
+	seller, taxLabel, taxRate, err := s.seller(ctx, tournament.OrganizerID)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice := &models.Invoice{
+		ID:                 utils.GenerateUUID(),
+		Kind:               models.InvoiceKindInvoice,
+		OrganizerID:        tournament.OrganizerID,
+		TournamentID:       tournament.ID,
+		ParticipantID:      participant.ID,
+		SourceReference:    sourceReference,
+		Seller:             seller,
+		Buyer:              buyer(participant),
+		Lines:              entryFeeLines(tournament, participant, amount),
+		TaxLabel:           taxLabel,
+		TaxRateBasisPoints: taxRate,
+		Tax:                models.InclusiveTax(amount, taxRate),
+		Total:              amount,
+		PaymentMethod:      method,
+		IssuedAt:           at,
+	}
+	invoice.Subtotal = invoice.Total.Sub(invoice.Tax)
+
+	if err := s.numberAndCreate(tx, invoice); err != nil {
+		return nil, err
+	}
+
+	err = s.repos.Outbox.EnqueueWithTx(tx, newOutboxEntry(models.NotificationPaymentReceipt,
+		"payment_receipt:"+invoice.ID, models.NotificationPayload{
+			TournamentID:   tournament.ID,
+			ParticipantIDs: []string{participant.ID},
+			InvoiceID:      invoice.ID,
+		}))
+	if err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// issueCreditNoteWithTx issues a credit note for part or all of an invoice
+// within the transaction that records the refund. The tax is credited in
+// proportion at the invoice's rate. sourceReference identifies the refund.
+func (s *PaymentService) issueCreditNoteWithTx(tx *sql.Tx, invoice *models.Invoice, sourceReference string, amount models.Money, at time.Time) (*models.Invoice, error) {
+	existing, err := s.repos.Invoice.GetBySourceWithTx(tx, models.InvoiceKindCreditNote, sourceReference)
+	if err != nil || existing != nil {
+		return existing, err
+	}
+
+	note := &models.Invoice{
+		ID:                 utils.GenerateUUID(),
+		Kind:               models.InvoiceKindCreditNote,
+		OrganizerID:        invoice.OrganizerID,
+		TournamentID:       invoice.TournamentID,
+		ParticipantID:      invoice.ParticipantID,
+		CreditedInvoiceID:  &invoice.ID,
+		CreditedNumber:     invoice.Number,
+		SourceReference:    sourceReference,
+		Seller:             invoice.Seller,
+		Buyer:              invoice.Buyer,
+		TaxLabel:           invoice.TaxLabel,
+		TaxRateBasisPoints: invoice.TaxRateBasisPoints,
+		Tax:                models.InclusiveTax(amount, invoice.TaxRateBasisPoints),
+		Total:              amount,
+		PaymentMethod:      invoice.PaymentMethod,
+		IssuedAt:           at,
+	}
+	note.Subtotal = note.Total.Sub(note.Tax)
+	note.Lines = models.InvoiceLines{{
+		Description: fmt.Sprintf("Refund of entry fee, invoice %s", invoice.Number),
+		Quantity:    1,
+		UnitAmount:  amount,
+		Amount:      amount,
+	}}
+
+	if err := s.numberAndCreate(tx, note); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// numberAndCreate takes the organizer's next number for the document's kind and saves it
+func (s *PaymentService) numberAndCreate(tx *sql.Tx, invoice *models.Invoice) error {
+	number, err := s.repos.Invoice.NextNumberWithTx(tx, invoice.OrganizerID, invoice.Kind)
+	if err != nil {
+		return fmt.Errorf("failed to number %s: %w", invoice.Kind, err)
+	}
+	invoice.Number = fmt.Sprintf("%s-%06d", invoice.Kind.NumberPrefix(), number)
+
+	return s.repos.Invoice.CreateWithTx(tx, invoice)
+}
+
+// seller is the organizer as printed on their invoices, with the tax they
+// charge. Organizers without a billing profile issue under their account name
+// without tax.
+func (s *PaymentService) seller(ctx context.Context, organizerID string) (models.InvoiceParty, string, int, error) {
+	profile, err := s.repos.Invoice.GetBillingProfile(ctx, organizerID)
+	if err != nil {
+		return models.InvoiceParty{}, "", 0, err
+	}
+	if profile != nil {
+		party := models.InvoiceParty{Name: profile.LegalName}
+		if profile.Address != nil {
+			party.Address = *profile.Address
+		}
+		if profile.TaxID != nil {
+			party.TaxID = *profile.TaxID
+		}
+		if profile.Email != nil {
+			party.Email = *profile.Email
+		}
+		return party, profile.TaxLabel, profile.TaxRateBasisPoints, nil
+	}
+
+	organizer, err := s.repos.User.GetByID(ctx, organizerID)
+	if err != nil {
+		return models.InvoiceParty{}, "", 0, err
+	}
+	return models.InvoiceParty{Name: organizer.FullName, Email: organizer.Email}, "", 0, nil
+}
+
+// buyer is the participant as printed on their invoices
+func buyer(participant *models.Participant) models.InvoiceParty {
+	party := models.InvoiceParty{Name: participant.Name}
+	if participant.ContactEmail != nil {
+		party.Email = *participant.ContactEmail
+	}
+	return party
+}
+
+// entryFeeLines itemizes an entry fee. A registration discount is its own line
+// when the quoted price still adds up to what was paid.
+func entryFeeLines(tournament *models.Tournament, participant *models.Participant, amount models.Money) models.InvoiceLines {
+	description := fmt.Sprintf("Entry fee: %s", tournament.Name)
+	if participant.PriceTier != nil && *participant.PriceTier != models.PriceStandard {
+		description += fmt.Sprintf(" (%s)", strings.ReplaceAll(string(*participant.PriceTier), "_", " "))
+	}
+
+	if participant.BaseAmountCents != nil && participant.DiscountCents != nil && *participant.DiscountCents > 0 &&
+		*participant.BaseAmountCents-*participant.DiscountCents == amount.Amount {
+		base := models.NewMoney(*participant.BaseAmountCents, amount.Currency)
+		discount := models.NewMoney(-*participant.DiscountCents, amount.Currency)
+		return models.InvoiceLines{
+			{Description: description, Quantity: 1, UnitAmount: base, Amount: base},
+			{Description: "Discount", Quantity: 1, UnitAmount: discount, Amount: discount},
+		}
+	}
+	return models.InvoiceLines{{Description: description, Quantity: 1, UnitAmount: amount, Amount: amount}}
+}
@@ -0,0 +1,224 @@
+// internal/services/payment_ledger.go
+// Payment ledger views, manual entries and per-tournament reconciliation
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/utils"
+)
+
+// ParticipantLedger is a participant's ledger entries and their balance
+type ParticipantLedger struct {
+	TournamentID  string                `json:"tournament_id"`
+	ParticipantID string                `json:"participant_id"`
+	Entries       []*models.LedgerEntry `json:"entries"`
+	Balance       models.LedgerBalance  `json:"balance"`
+}
+
+// LedgerEntryRequest is a fee or adjustment an organizer records by hand, in
+// the tournament's currency. Adjustments are signed; fees are given as a
+// positive amount.
+type LedgerEntryRequest struct {
+	Type        models.LedgerEntryType `json:"type" binding:"required"`
+	Amount      models.Money           `json:"amount"`
+	Description string                 `json:"description" binding:"required"`
+}
+
+// ReconciliationLine compares one participant's ledger with their payment status
+type ReconciliationLine struct {
+	ParticipantID string                 `json:"participant_id"`
+	Name          string                 `json:"name,omitempty"`
+	PaymentStatus *models.PaymentStatus  `json:"payment_status,omitempty"`
+	Expected      []models.PaymentStatus `json:"expected_statuses"`
+	Balance       models.LedgerBalance   `json:"balance"`
+	Matches       bool                   `json:"matches"`
+	Issue         string                 `json:"issue,omitempty"`
+}
+
+// ReconciliationReport compares a tournament's ledger with its participants'
+// payment statuses. Unregistered lists ledger balances of participants who are
+// no longer registered; a withdrawn participant may keep a balance the refund
+// policy retained, or one still awaiting its refund.
+type ReconciliationReport struct {
+	TournamentID string                `json:"tournament_id"`
+	Currency     string                `json:"currency"`
+	Totals       models.LedgerBalance  `json:"totals"`
+	Participants []*ReconciliationLine `json:"participants"`
+	Unregistered []*ReconciliationLine `json:"unregistered"`
+	Mismatches   int                   `json:"mismatches"`
+	GeneratedAt  time.Time             `json:"generated_at"`
+}
+
+// Ledger retrieves a participant's ledger and balance
+func (s *PaymentService) Ledger(ctx context.Context, tournamentID, participantID string) (*ParticipantLedger, error) {
+	tournament, err := s.repos.Tournament.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.repos.Ledger.ListByParticipant(ctx, tournamentID, participantID)
+	if err != nil {
+		return nil, err
+	}
+
+	ledger := &ParticipantLedger{
+		TournamentID:  tournamentID,
+		ParticipantID: participantID,
+		Entries:       entries,
+		Balance:       models.NewLedgerBalance(tournament.Currency),
+	}
+	for _, entry := range entries {
+		ledger.Balance.Add(entry.Type, entry.Amount)
+	}
+	return ledger, nil
+}
+
+// RecordLedgerEntry records a fee or adjustment for a participant. Charges and
+// refunds only enter the ledger from provider events.
+func (s *PaymentService) RecordLedgerEntry(ctx context.Context, tournamentID, participantID, userID string, req LedgerEntryRequest) (*models.LedgerEntry, error) {
+	tournament, err := s.repos.Tournament.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Amount.Currency != "" && req.Amount.Currency != tournament.Currency {
+		return nil, fmt.Errorf("%w: amounts must be in %s", ErrInvalidInput, tournament.Currency)
+	}
+
+	amount := models.NewMoney(req.Amount.Amount, tournament.Currency)
+	switch req.Type {
+	case models.LedgerAdjustment:
+	case models.LedgerFee:
+		if amount.IsNegative() {
+			return nil, fmt.Errorf("%w: fee amount must be positive", ErrInvalidInput)
+		}
+		amount = amount.Neg()
+	default:
+		return nil, fmt.Errorf("%w: only fees and adjustments can be recorded by hand", ErrInvalidInput)
+	}
+	description := strings.TrimSpace(req.Description)
+	if amount.IsZero() || description == "" {
+		return nil, fmt.Errorf("%w: amount and description are required", ErrInvalidInput)
+	}
+
+	participant, err := s.repos.TournamentParticipant.Get(ctx, tournamentID, participantID)
+	if err != nil {
+		return nil, err
+	}
+	if participant == nil {
+		// Withdrawn participants keep their ledger
+		entries, err := s.repos.Ledger.ListByParticipant(ctx, tournamentID, participantID)
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) == 0 {
+			return nil, ErrNotFound
+		}
+	}
+
+	entry := &models.LedgerEntry{
+		ID:            utils.GenerateUUID(),
+		TournamentID:  tournamentID,
+		ParticipantID: participantID,
+		Type:          req.Type,
+		Amount:        amount,
+		Description:   description,
+		CreatedBy:     &userID,
+		CreatedAt:     time.Now(),
+	}
+	if err := s.repos.Ledger.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Reconcile compares every participant's ledger balance with their payment
+// status and totals the tournament's ledger
+func (s *PaymentService) Reconcile(ctx context.Context, tournamentID string) (*ReconciliationReport, error) {
+	tournament, err := s.repos.Tournament.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	participants, err := s.repos.TournamentParticipant.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	balances, err := s.repos.Ledger.BalancesByTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ReconciliationReport{
+		TournamentID: tournamentID,
+		Currency:     tournament.Currency,
+		Totals:       models.NewLedgerBalance(tournament.Currency),
+		Participants: make([]*ReconciliationLine, 0, len(participants)),
+		Unregistered: make([]*ReconciliationLine, 0),
+		GeneratedAt:  time.Now(),
+	}
+
+	for _, participant := range participants {
+		balance := models.NewLedgerBalance(tournament.Currency)
+		if b, ok := balances[participant.ID]; ok {
+			balance = *b
+			delete(balances, participant.ID)
+		}
+
+		line := reconcileLine(participant.ID, participant.PaymentStatus, balance)
+		line.Name = participant.Name
+		report.Participants = append(report.Participants, line)
+		report.Totals.Merge(balance)
+	}
+
+	for participantID, balance := range balances {
+		line := &ReconciliationLine{
+			ParticipantID: participantID,
+			Expected:      balance.ExpectedPaymentStatuses(),
+			Balance:       *balance,
+			Matches:       !balance.Paid.IsNegative(),
+		}
+		if !line.Matches {
+			line.Issue = "more was refunded than was paid"
+		}
+		report.Unregistered = append(report.Unregistered, line)
+		report.Totals.Merge(*balance)
+	}
+	slices.SortFunc(report.Unregistered, func(a, b *ReconciliationLine) int {
+		return strings.Compare(a.ParticipantID, b.ParticipantID)
+	})
+
+	for _, line := range append(report.Participants, report.Unregistered...) {
+		if !line.Matches {
+			report.Mismatches++
+		}
+	}
+
+	return report, nil
+}
+
+// reconcileLine checks a registered participant's status against their balance
+func reconcileLine(participantID string, status *models.PaymentStatus, balance models.LedgerBalance) *ReconciliationLine {
+	line := &ReconciliationLine{
+		ParticipantID: participantID,
+		PaymentStatus: status,
+		Expected:      balance.ExpectedPaymentStatuses(),
+		Balance:       balance,
+	}
+
+	switch {
+	case balance.Paid.IsNegative():
+		line.Issue = "more was refunded than was paid"
+	case status == nil:
+		line.Issue = "participant has no payment status"
+	case !slices.Contains(line.Expected, *status):
+		line.Issue = fmt.Sprintf("payment status is %s but the ledger shows %s paid of %s charged",
+			*status, balance.Paid, balance.Charged)
+	}
+	line.Matches = line.Issue == ""
+	return line
+}
@@ -0,0 +1,181 @@
+// internal/services/payment_onsite.go
+// Entry fees collected at the venue and the end-of-day cash summary
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/utils"
+)
+
+// OnsitePaymentRequest records an entry fee collected at the venue
+type OnsitePaymentRequest struct {
+	Method models.CollectionMethod `json:"method" binding:"required,oneof=cash card"`
+	Note   string                  `json:"note" binding:"max=500"`
+}
+
+// RecordOnsitePayment records that a staff member collected a participant's
+// outstanding entry fee at the venue, and marks the participant paid. A payment
+// still open with the provider is canceled first, so the payer can't complete it
+// as well; should it settle anyway, it is refunded as an overpayment.
+func (s *PaymentService) RecordOnsitePayment(ctx context.Context, tournamentID, participantID, staffID string, req OnsitePaymentRequest) (*models.OnsitePayment, error) {
+	participant, err := s.repos.TournamentParticipant.Get(ctx, tournamentID, participantID)
+	if err != nil {
+		return nil, err
+	}
+	if participant == nil {
+		return nil, ErrNotFound
+	}
+	if participant.PaymentStatus != nil && *participant.PaymentStatus != models.PaymentPending {
+		return nil, fmt.Errorf("%w: entry fee is already %s", ErrInvalidInput, *participant.PaymentStatus)
+	}
+
+	tournament, err := s.repos.Tournament.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	amount := amountDue(tournament, participant)
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("%w: participant owes no entry fee", ErrInvalidInput)
+	}
+
+	open, err := s.repos.Payment.GetOpenByParticipant(ctx, tournamentID, participantID)
+	if err != nil {
+		return nil, err
+	}
+	if open != nil {
+		if err := s.cancelOpenPayment(ctx, open); err != nil {
+			return nil, err
+		}
+	}
+
+	payment := &models.OnsitePayment{
+		ID:            utils.GenerateUUID(),
+		TournamentID:  tournamentID,
+		ParticipantID: participantID,
+		Amount:        amount,
+		Method:        req.Method,
+		CollectedBy:   staffID,
+		CollectedAt:   time.Now(),
+	}
+	if note := strings.TrimSpace(req.Note); note != "" {
+		payment.Note = &note
+	}
+
+	tx, err := s.repos.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Only one collection can settle the fee, even if two staff members try at once
+	settled, err := s.repos.TournamentParticipant.UpdatePaymentStatusFromWithTx(tx, tournamentID, participantID, models.PaymentPending, models.PaymentPaid)
+	if err != nil {
+		return nil, err
+	}
+	if !settled {
+		return nil, fmt.Errorf("%w: entry fee was already settled", ErrInvalidInput)
+	}
+
+	if err := s.repos.OnsitePayment.CreateWithTx(tx, payment); err != nil {
+		return nil, err
+	}
+	err = s.repos.Ledger.CreateWithTx(tx, &models.LedgerEntry{
+		ID:                utils.GenerateUUID(),
+		TournamentID:      tournamentID,
+		ParticipantID:     participantID,
+		Type:              models.LedgerCharge,
+		Amount:            amount,
+		ProviderReference: &payment.ID,
+		Description:       fmt.Sprintf("Entry fee collected at the venue (%s)", req.Method),
+		CreatedBy:         &staffID,
+		CreatedAt:         payment.CollectedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	method := invoiceMethodCash
+	if req.Method == models.CollectedCard {
+		method = invoiceMethodCard
+	}
+	_, err = s.issueInvoiceWithTx(ctx, tx, tournament, participant, payment.ID, amount, method, payment.CollectedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue invoice: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// CashSummary totals the onsite collections of one day, in the tournament's
+// timezone, per staff member. An empty date means today.
+func (s *PaymentService) CashSummary(ctx context.Context, tournamentID, date string) (*models.CashSummary, error) {
+	tournament, err := s.repos.Tournament.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	location := tournamentLocation(tournament)
+	day := time.Now().In(location)
+	if date != "" {
+		day, err = time.ParseInLocation("2006-01-02", date, location)
+		if err != nil {
+			return nil, fmt.Errorf("%w: date must be YYYY-MM-DD", ErrInvalidInput)
+		}
+	}
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, location)
+	to := from.AddDate(0, 0, 1)
+
+	collected, err := s.repos.OnsitePayment.ListCollected(ctx, tournamentID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	zero := models.NewMoney(0, tournament.Currency)
+	summary := &models.CashSummary{
+		TournamentID: tournamentID,
+		Date:         from.Format("2006-01-02"),
+		Timezone:     location.String(),
+		Staff:        make([]*models.StaffCashSummary, 0),
+		Cash:         zero,
+		Card:         zero,
+		Total:        zero,
+	}
+	byStaff := make(map[string]*models.StaffCashSummary)
+	for _, payment := range collected {
+		staff, ok := byStaff[payment.CollectedBy]
+		if !ok {
+			staff = &models.StaffCashSummary{
+				UserID: payment.CollectedBy,
+				Name:   payment.CollectedByName,
+				Cash:   zero,
+				Card:   zero,
+				Total:  zero,
+			}
+			byStaff[payment.CollectedBy] = staff
+			summary.Staff = append(summary.Staff, staff)
+		}
+		staff.Add(payment)
+	}
+	slices.SortFunc(summary.Staff, func(a, b *models.StaffCashSummary) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	for _, staff := range summary.Staff {
+		summary.Cash = summary.Cash.Add(staff.Cash)
+		summary.Card = summary.Card.Add(staff.Card)
+		summary.Total = summary.Total.Add(staff.Total)
+		summary.Collections += staff.Collections
+	}
+
+	return summary, nil
+}
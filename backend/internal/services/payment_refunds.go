@@ -0,0 +1,298 @@
+// internal/services/payment_refunds.go
+// Queued refunds for withdrawals and cancelled tournaments
+
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/payments"
+	"tournament-planner/internal/utils"
+)
+
+// refundBatchSize caps the batch items claimed per poll
+const refundBatchSize = 20
+
+// RefundBatchStatus is a refund batch's progress and its items
+type RefundBatchStatus struct {
+	*models.RefundBatchProgress
+	Items []*models.RefundBatchItem `json:"items"`
+}
+
+// QueueWithdrawalRefundWithTx queues the refund of a withdrawn participant's
+// settled payment, as far as the tournament's refund policy allows, within the
+// transaction that removes them. It returns nil when the participant had
+// nothing to refund through the provider; fees collected at the venue are
+// returned by hand.
+func (s *PaymentService) QueueWithdrawalRefundWithTx(ctx context.Context, tx *sql.Tx, tournament *models.Tournament, participantID, userID string, at time.Time) (*models.RefundDecision, error) {
+	payment, err := s.repos.Payment.GetLatestByParticipant(ctx, tournament.ID, participantID, models.ChargeSucceeded)
+	if err != nil {
+		return nil, err
+	}
+	if payment == nil || payment.ProviderPaymentID == nil {
+		return nil, nil
+	}
+
+	decision := tournament.RefundPolicy.Refund(payment.Amount, tournament.StartDate, at)
+	decision.Refund = decision.Refund.Min(payment.Refundable())
+	if !decision.Refund.IsPositive() {
+		decision.Refund.Amount = 0
+		return &decision, nil
+	}
+
+	batch := &models.RefundBatch{
+		ID:           utils.GenerateUUID(),
+		TournamentID: tournament.ID,
+		Kind:         models.RefundBatchWithdrawal,
+		Reason:       "participant withdrawn",
+		CreatedBy:    &userID,
+		CreatedAt:    at,
+	}
+	if err := s.repos.RefundBatch.CreateWithTx(tx, batch); err != nil {
+		return nil, err
+	}
+	item := &models.RefundBatchItem{
+		ID:            utils.GenerateUUID(),
+		BatchID:       batch.ID,
+		ParticipantID: participantID,
+		PaymentID:     payment.ID,
+		Amount:        decision.Refund,
+		Status:        models.RefundItemPending,
+	}
+	if err := s.repos.RefundBatch.CreateItemWithTx(tx, item); err != nil {
+		return nil, err
+	}
+
+	return &decision, nil
+}
+
+// queueOverpaymentRefundWithTx queues a full refund of a payment from a
+// participant who had already paid, within the transaction that records it
+func (s *PaymentService) queueOverpaymentRefundWithTx(tx *sql.Tx, payment *models.Payment) error {
+	batch := &models.RefundBatch{
+		ID:           utils.GenerateUUID(),
+		TournamentID: payment.TournamentID,
+		Kind:         models.RefundBatchOverpayment,
+		Reason:       "entry fee already paid",
+		CreatedAt:    time.Now(),
+	}
+	if err := s.repos.RefundBatch.CreateWithTx(tx, batch); err != nil {
+		return err
+	}
+	return s.repos.RefundBatch.CreateItemWithTx(tx, &models.RefundBatchItem{
+		ID:            utils.GenerateUUID(),
+		BatchID:       batch.ID,
+		ParticipantID: payment.ParticipantID,
+		PaymentID:     payment.ID,
+		Amount:        payment.Refundable(),
+		Status:        models.RefundItemPending,
+	})
+}
+
+// queueLateCancellationRefundWithTx adds a full refund of a payment that settled
+// after its tournament was cancelled to the cancellation's batch, within the
+// transaction that records it
+func (s *PaymentService) queueLateCancellationRefundWithTx(ctx context.Context, tx *sql.Tx, payment *models.Payment) error {
+	batch, err := s.repos.RefundBatch.GetLatestByTournament(ctx, payment.TournamentID, models.RefundBatchCancellation)
+	if err != nil {
+		return err
+	}
+	if batch == nil {
+		batch = &models.RefundBatch{
+			ID:           utils.GenerateUUID(),
+			TournamentID: payment.TournamentID,
+			Kind:         models.RefundBatchCancellation,
+			Reason:       "tournament cancelled",
+			CreatedAt:    time.Now(),
+		}
+		if err := s.repos.RefundBatch.CreateWithTx(tx, batch); err != nil {
+			return err
+		}
+	} else if err := s.repos.RefundBatch.ReopenWithTx(tx, batch.ID); err != nil {
+		return err
+	}
+
+	return s.repos.RefundBatch.CreateItemWithTx(tx, &models.RefundBatchItem{
+		ID:            utils.GenerateUUID(),
+		BatchID:       batch.ID,
+		ParticipantID: payment.ParticipantID,
+		PaymentID:     payment.ID,
+		Amount:        payment.Refundable(),
+		Status:        models.RefundItemPending,
+	})
+}
+
+// CancelOpenPayments cancels the payments of a cancelled tournament that are still
+// waiting for the payer or authorized. A payment that settles anyway is refunded
+// when its event arrives.
+func (s *PaymentService) CancelOpenPayments(ctx context.Context, tournamentID string) {
+	open, err := s.repos.Payment.ListOpenByTournament(ctx, tournamentID)
+	if err != nil {
+		s.logger.Printf("Failed to list open payments of tournament %s: %v", tournamentID, err)
+		return
+	}
+	for _, payment := range open {
+		if err := s.cancelOpenPayment(ctx, payment); err != nil {
+			s.logger.Printf("Failed to cancel payment %s of cancelled tournament %s: %v", payment.ID, tournamentID, err)
+		}
+	}
+}
+
+// QueueRefundBatchWithTx queues a full refund of every settled payment of a
+// tournament within the transaction that cancels it
+func (s *PaymentService) QueueRefundBatchWithTx(ctx context.Context, tx *sql.Tx, tournamentID, userID, reason string) (*models.RefundBatch, error) {
+	refundable, err := s.repos.Payment.ListRefundable(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &models.RefundBatch{
+		ID:           utils.GenerateUUID(),
+		TournamentID: tournamentID,
+		Kind:         models.RefundBatchCancellation,
+		Reason:       reason,
+		CreatedBy:    &userID,
+		CreatedAt:    time.Now(),
+	}
+	if len(refundable) == 0 {
+		batch.CompletedAt = &batch.CreatedAt
+	}
+	if err := s.repos.RefundBatch.CreateWithTx(tx, batch); err != nil {
+		return nil, err
+	}
+
+	for _, payment := range refundable {
+		item := &models.RefundBatchItem{
+			ID:            utils.GenerateUUID(),
+			BatchID:       batch.ID,
+			ParticipantID: payment.ParticipantID,
+			PaymentID:     payment.ID,
+			Amount:        payment.Refundable(),
+			Status:        models.RefundItemPending,
+		}
+		if err := s.repos.RefundBatch.CreateItemWithTx(tx, item); err != nil {
+			return nil, err
+		}
+	}
+
+	return batch, nil
+}
+
+// RefundProgress reports the progress of the refunds of a tournament's cancellation
+func (s *PaymentService) RefundProgress(ctx context.Context, tournamentID string) (*RefundBatchStatus, error) {
+	batch, err := s.repos.RefundBatch.GetLatestByTournament(ctx, tournamentID, models.RefundBatchCancellation)
+	if err != nil {
+		return nil, err
+	}
+	if batch == nil {
+		return nil, ErrNotFound
+	}
+
+	progress, err := s.repos.RefundBatch.Progress(ctx, batch)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.repos.RefundBatch.ListItems(ctx, batch.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RefundBatchStatus{RefundBatchProgress: progress, Items: items}, nil
+}
+
+// Run works off queued batch refunds until the context is cancelled
+func (s *PaymentService) Run(ctx context.Context) {
+	ticker := time.NewTicker(dispatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.refundDue(ctx)
+		}
+	}
+}
+
+// refundDue claims and requests one batch of due refunds. Failed requests are
+// retried with backoff until they run out of attempts.
+func (s *PaymentService) refundDue(ctx context.Context) {
+	items, err := s.repos.RefundBatch.ClaimDueItems(ctx, utils.GenerateUUID(), refundBatchSize, dispatchLease)
+	if err != nil {
+		s.logger.Printf("Failed to claim refund batch items: %v", err)
+		return
+	}
+
+	batches := make(map[string]bool)
+	for _, item := range items {
+		batches[item.BatchID] = true
+
+		var delay time.Duration
+		item.Attempts++
+		if err := s.refundItem(ctx, item); err != nil {
+			message := err.Error()
+			item.LastError = &message
+			if item.Attempts >= maxDeliveryAttempts {
+				item.Status = models.RefundItemFailed
+				s.logger.Printf("Refund of payment %s failed after %d attempts: %v", item.PaymentID, item.Attempts, err)
+			} else {
+				delay = retryDelay(item.Attempts)
+			}
+		} else {
+			item.Status = models.RefundItemRequested
+			item.LastError = nil
+		}
+
+		if err := s.repos.RefundBatch.RecordAttempt(ctx, item, delay); err != nil {
+			s.logger.Printf("Failed to record refund of payment %s: %v", item.PaymentID, err)
+		}
+	}
+
+	for batchID := range batches {
+		if err := s.repos.RefundBatch.CompleteIfDone(ctx, batchID); err != nil {
+			s.logger.Printf("Failed to complete refund batch %s: %v", batchID, err)
+		}
+	}
+}
+
+// refundItem requests a batch refund, capped at what is still left of the
+// payment in case part of it was refunded since the batch was queued
+func (s *PaymentService) refundItem(ctx context.Context, item *models.RefundBatchItem) error {
+	payment, err := s.repos.Payment.GetByID(ctx, item.PaymentID)
+	if err != nil {
+		return err
+	}
+	if payment == nil || payment.ProviderPaymentID == nil {
+		return fmt.Errorf("payment %s not found", item.PaymentID)
+	}
+
+	amount := item.Amount.Min(payment.Refundable())
+	if payment.Status != models.ChargeSucceeded || !amount.IsPositive() {
+		return nil
+	}
+
+	reason := "tournament_cancelled"
+	switch item.Kind {
+	case models.RefundBatchWithdrawal:
+		reason = "participant_withdrawn"
+	case models.RefundBatchOverpayment:
+		reason = "duplicate"
+	}
+
+	refundCtx, cancel := context.WithTimeout(ctx, paymentProviderTimeout)
+	defer cancel()
+
+	// The item is the reference, so retries never refund twice
+	_, err = s.provider.Refund(refundCtx, &payments.RefundRequest{
+		Reference:         item.ID,
+		ProviderPaymentID: *payment.ProviderPaymentID,
+		Amount:            amount.Amount,
+		Reason:            reason,
+	})
+	return err
+}
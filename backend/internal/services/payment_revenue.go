@@ -0,0 +1,109 @@
+// internal/services/payment_revenue.go
+// Organizer revenue reports totalled from the payment ledger
+
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"tournament-planner/internal/models"
+)
+
+// TournamentRevenue reports what a tournament's entry fees have earned its
+// organizer, by pricing tier and payment method
+func (s *PaymentService) TournamentRevenue(ctx context.Context, tournamentID string) (*models.RevenueReport, error) {
+	tournament, err := s.repos.Tournament.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.repos.Ledger.RevenueByTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.RevenueReport{
+		OrganizerID:  tournament.OrganizerID,
+		TournamentID: tournamentID,
+		Currencies:   make([]*models.RevenueSummary, 0, 1),
+		GeneratedAt:  time.Now(),
+	}
+	// The tournament's currency is reported even before any money comes in
+	revenueSummary(report, tournament.Currency)
+	addRevenue(report, entries, false)
+	return report, nil
+}
+
+// OrganizerRevenue reports what all of an organizer's tournaments have earned,
+// by tournament, pricing tier and payment method
+func (s *PaymentService) OrganizerRevenue(ctx context.Context, organizerID string) (*models.RevenueReport, error) {
+	entries, err := s.repos.Ledger.RevenueByOrganizer(ctx, organizerID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.RevenueReport{
+		OrganizerID: organizerID,
+		Currencies:  make([]*models.RevenueSummary, 0),
+		GeneratedAt: time.Now(),
+	}
+	addRevenue(report, entries, true)
+	return report, nil
+}
+
+// addRevenue counts ledger totals towards the summary of their currency and
+// its breakdowns. Tournaments keep the order of the entries; tiers and
+// methods are sorted by name.
+func addRevenue(report *models.RevenueReport, entries []*models.RevenueEntry, byTournament bool) {
+	for _, entry := range entries {
+		summary := revenueSummary(report, entry.Amount.Currency)
+		summary.Totals.Add(entry.Type, entry.Amount)
+		if byTournament {
+			group := revenueGroup(&summary.ByTournament, entry.TournamentID, summary.Currency)
+			group.Name = entry.TournamentName
+			group.Add(entry.Type, entry.Amount)
+		}
+		revenueGroup(&summary.ByTier, string(entry.PriceTier), summary.Currency).Add(entry.Type, entry.Amount)
+		revenueGroup(&summary.ByMethod, string(entry.Method), summary.Currency).Add(entry.Type, entry.Amount)
+	}
+
+	byKey := func(a, b *models.RevenueGroup) int { return strings.Compare(a.Key, b.Key) }
+	for _, summary := range report.Currencies {
+		slices.SortFunc(summary.ByTier, byKey)
+		slices.SortFunc(summary.ByMethod, byKey)
+	}
+}
+
+// revenueSummary finds the report's summary in a currency, adding an empty one
+// if there is none yet
+func revenueSummary(report *models.RevenueReport, currency string) *models.RevenueSummary {
+	for _, summary := range report.Currencies {
+		if summary.Currency == currency {
+			return summary
+		}
+	}
+
+	summary := &models.RevenueSummary{
+		Currency: currency,
+		Totals:   models.NewLedgerBalance(currency),
+		ByTier:   make([]*models.RevenueGroup, 0),
+		ByMethod: make([]*models.RevenueGroup, 0),
+	}
+	report.Currencies = append(report.Currencies, summary)
+	return summary
+}
+
+// revenueGroup finds a breakdown's group by key, adding an empty one if there is none yet
+func revenueGroup(groups *[]*models.RevenueGroup, key, currency string) *models.RevenueGroup {
+	for _, group := range *groups {
+		if group.Key == key {
+			return group
+		}
+	}
+
+	group := &models.RevenueGroup{Key: key, LedgerBalance: models.NewLedgerBalance(currency)}
+	*groups = append(*groups, group)
+	return group
+}
@@ -0,0 +1,352 @@
+// internal/services/payment_revenue_test.go
+// Revenue reports over a ledger written by real provider events
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"tournament-planner/internal/config"
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/payments"
+	"tournament-planner/internal/render"
+	"tournament-planner/internal/repositories"
+)
+
+const (
+	testWebhookSecret = "whsec_test"
+	testOrganizerID   = "organizer-1"
+	testTournamentID  = "tournament-1"
+)
+
+// revenueFixture is a EUR tournament whose participants pay through the fake
+// provider or at the venue
+type revenueFixture struct {
+	ctx      context.Context
+	repos    *repositories.Container
+	db       *sql.DB
+	provider *payments.FakeProvider
+	payments *PaymentService
+	events   int
+}
+
+func newRevenueFixture(t *testing.T) *revenueFixture {
+	t.Helper()
+
+	repos, db := newTestRepos(t)
+	provider := payments.NewFakeProvider(testWebhookSecret)
+	f := &revenueFixture{
+		ctx:      context.Background(),
+		repos:    repos,
+		db:       db,
+		provider: provider,
+		payments: NewPaymentService(repos, provider, config.ExternalConfig{
+			PaymentProvider:     payments.ProviderFake,
+			StripeWebhookSecret: testWebhookSecret,
+		}, log.New(io.Discard, "", 0)),
+	}
+
+	f.exec(t, `INSERT INTO users (id, email, password_hash, full_name, role) VALUES (?, ?, 'x', 'Olivia Organizer', 'organizer')`,
+		testOrganizerID, "olivia@example.com")
+	f.exec(t, `
+		INSERT INTO tournaments (
+			id, organizer_id, name, description, format_type, start_date, end_date, max_matches_per_day,
+			operational_hours, avg_match_duration, entry_fee_cents, currency, capacity_limit, status
+		) VALUES (?, ?, 'Spring Open', '', 'single_elimination', ?, ?, 8, '{}', 60, 5000, 'EUR', 16, 'registration_open')
+	`, testTournamentID, testOrganizerID, time.Now().AddDate(0, 1, 0), time.Now().AddDate(0, 1, 1))
+	return f
+}
+
+func (f *revenueFixture) exec(t *testing.T, query string, args ...interface{}) {
+	t.Helper()
+	if _, err := f.db.Exec(query, args...); err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+}
+
+// register enters a participant who owes due in a pricing tier
+func (f *revenueFixture) register(t *testing.T, id string, tier models.PriceTier, due int64) {
+	t.Helper()
+	f.exec(t, `INSERT INTO participants (id, name, type, contact_email) VALUES (?, ?, 'individual', ?)`,
+		id, "Player "+id, id+"@example.com")
+	f.exec(t, `
+		INSERT INTO tournament_participants (tournament_id, participant_id, payment_status, price_tier, amount_due_cents)
+		VALUES (?, ?, 'pending', ?, ?)
+	`, testTournamentID, id, tier, due)
+}
+
+// send signs an event as the fake provider and hands it to the webhook handler
+func (f *revenueFixture) send(t *testing.T, event *payments.Event) {
+	t.Helper()
+
+	f.events++
+	event.ID = fmt.Sprintf("evt_%d", f.events)
+	event.Currency = "EUR"
+	payload, header, err := f.provider.SignedEvent(event)
+	if err != nil {
+		t.Fatalf("sign event: %v", err)
+	}
+	if err := f.payments.HandleWebhook(f.ctx, payload, header); err != nil {
+		t.Fatalf("handle %s: %v", event.Type, err)
+	}
+}
+
+// payOnline starts a participant's payment and has the provider confirm it
+// with a processing fee. It returns the provider's payment ID.
+func (f *revenueFixture) payOnline(t *testing.T, participantID string, fee int64) string {
+	t.Helper()
+
+	checkout, err := f.payments.CreatePayment(f.ctx, testTournamentID, participantID, testOrganizerID)
+	if err != nil {
+		t.Fatalf("create payment: %v", err)
+	}
+	payment, err := f.repos.Payment.GetByID(f.ctx, checkout.PaymentID)
+	if err != nil || payment == nil || payment.ProviderPaymentID == nil {
+		t.Fatalf("payment %s was not started: %v", checkout.PaymentID, err)
+	}
+
+	f.send(t, &payments.Event{
+		Type:              payments.EventPaymentSucceeded,
+		ProviderPaymentID: *payment.ProviderPaymentID,
+		Amount:            checkout.Amount.Amount,
+		Fee:               fee,
+	})
+	return *payment.ProviderPaymentID
+}
+
+// confirmRefunds has the provider confirm every refund it was asked for
+func (f *revenueFixture) confirmRefunds(t *testing.T, providerPaymentID string) {
+	t.Helper()
+
+	held, ok := f.provider.Payment(providerPaymentID)
+	if !ok || len(held.Refunds) == 0 {
+		t.Fatalf("no refund was requested for %s", providerPaymentID)
+	}
+	var refunded int64
+	for _, refund := range held.Refunds {
+		refunded += refund.Amount
+	}
+	f.send(t, &payments.Event{
+		Type:              payments.EventPaymentRefunded,
+		ProviderPaymentID: providerPaymentID,
+		AmountRefunded:    refunded,
+	})
+}
+
+// withdraw takes a participant out of the tournament and works off the
+// refund queued with the withdrawal
+func (f *revenueFixture) withdraw(t *testing.T, participantID string) {
+	t.Helper()
+
+	tournament, err := f.repos.Tournament.GetByID(f.ctx, testTournamentID)
+	if err != nil {
+		t.Fatalf("load tournament: %v", err)
+	}
+	tx, err := f.repos.BeginTx(f.ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := f.repos.TournamentParticipant.DeleteWithTx(tx, testTournamentID, participantID); err != nil {
+		t.Fatalf("remove participant: %v", err)
+	}
+	if _, err := f.payments.QueueWithdrawalRefundWithTx(f.ctx, tx, tournament, participantID, testOrganizerID, time.Now()); err != nil {
+		t.Fatalf("queue refund: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+
+	f.payments.refundDue(f.ctx)
+}
+
+// settle runs the season the reports below are checked against:
+//
+//	p1  early bird  40.00 online, 1.50 fee
+//	p2  standard    50.00 online, 1.70 fee, 20.00 refunded by the organizer
+//	p3  standard    50.00 cash at the venue
+//	p4  early bird  40.00 card at the venue
+//	p5  standard    50.00 online, 1.70 fee, withdrawn and refunded in full
+func (f *revenueFixture) settle(t *testing.T) {
+	t.Helper()
+
+	f.register(t, "p1", models.PriceEarlyBird, 4000)
+	f.register(t, "p2", models.PriceStandard, 5000)
+	f.register(t, "p3", models.PriceStandard, 5000)
+	f.register(t, "p4", models.PriceEarlyBird, 4000)
+	f.register(t, "p5", models.PriceStandard, 5000)
+
+	f.payOnline(t, "p1", 150)
+
+	p2 := f.payOnline(t, "p2", 170)
+	err := f.payments.RefundPayment(f.ctx, testTournamentID, "p2", testOrganizerID, models.NewMoney(2000, "EUR"), "")
+	if err != nil {
+		t.Fatalf("refund p2: %v", err)
+	}
+	f.confirmRefunds(t, p2)
+
+	for participantID, method := range map[string]models.CollectionMethod{"p3": models.CollectedCash, "p4": models.CollectedCard} {
+		req := OnsitePaymentRequest{Method: method}
+		if _, err := f.payments.RecordOnsitePayment(f.ctx, testTournamentID, participantID, testOrganizerID, req); err != nil {
+			t.Fatalf("collect from %s: %v", participantID, err)
+		}
+	}
+
+	p5 := f.payOnline(t, "p5", 170)
+	f.withdraw(t, "p5")
+	f.confirmRefunds(t, p5)
+}
+
+// balance is a ledger balance in EUR as decimals: gross, refunds, fees, net
+type balance [4]string
+
+func balanceOf(b models.LedgerBalance) balance {
+	return balance{b.Charged.Decimal(), b.Refunded.Decimal(), b.Fees.Decimal(), b.Net.Decimal()}
+}
+
+func groupBalances(groups []*models.RevenueGroup) map[string]balance {
+	balances := make(map[string]balance, len(groups))
+	for _, group := range groups {
+		balances[group.Key] = balanceOf(group.LedgerBalance)
+	}
+	return balances
+}
+
+func TestTournamentRevenueFromProviderEvents(t *testing.T) {
+	f := newRevenueFixture(t)
+	f.settle(t)
+
+	report, err := f.payments.TournamentRevenue(f.ctx, testTournamentID)
+	if err != nil {
+		t.Fatalf("TournamentRevenue: %v", err)
+	}
+	if len(report.Currencies) != 1 || report.Currencies[0].Currency != "EUR" {
+		t.Fatalf("currencies = %+v, want EUR only", report.Currencies)
+	}
+	summary := report.Currencies[0]
+
+	if got, want := balanceOf(summary.Totals), (balance{"230.00", "70.00", "4.90", "155.10"}); got != want {
+		t.Errorf("totals = %v, want %v", got, want)
+	}
+	if summary.ByTournament != nil {
+		t.Errorf("tournament report has a tournament breakdown: %+v", summary.ByTournament)
+	}
+
+	// The withdrawn participant's registration is gone, so their money has no tier left
+	wantTiers := map[string]balance{
+		"early_bird": {"80.00", "0.00", "1.50", "78.50"},
+		"standard":   {"100.00", "20.00", "1.70", "78.30"},
+		"withdrawn":  {"50.00", "50.00", "1.70", "-1.70"},
+	}
+	if got := groupBalances(summary.ByTier); !equalBalances(got, wantTiers) {
+		t.Errorf("by tier = %v, want %v", got, wantTiers)
+	}
+	wantMethods := map[string]balance{
+		"card":   {"40.00", "0.00", "0.00", "40.00"},
+		"cash":   {"50.00", "0.00", "0.00", "50.00"},
+		"online": {"140.00", "70.00", "4.90", "65.10"},
+	}
+	if got := groupBalances(summary.ByMethod); !equalBalances(got, wantMethods) {
+		t.Errorf("by method = %v, want %v", got, wantMethods)
+	}
+
+	for _, groups := range [][]*models.RevenueGroup{summary.ByTier, summary.ByMethod} {
+		for i := 1; i < len(groups); i++ {
+			if groups[i-1].Key > groups[i].Key {
+				t.Errorf("groups out of order: %s before %s", groups[i-1].Key, groups[i].Key)
+			}
+		}
+	}
+}
+
+func TestTournamentRevenueLeavesOutOverpayments(t *testing.T) {
+	f := newRevenueFixture(t)
+	f.register(t, "p1", models.PriceStandard, 5000)
+
+	// The fee is collected at the venue while the payer completes an online payment
+	checkout, err := f.payments.CreatePayment(f.ctx, testTournamentID, "p1", testOrganizerID)
+	if err != nil {
+		t.Fatalf("create payment: %v", err)
+	}
+	payment, err := f.repos.Payment.GetByID(f.ctx, checkout.PaymentID)
+	if err != nil {
+		t.Fatalf("load payment: %v", err)
+	}
+	f.exec(t, `UPDATE tournament_participants SET payment_status = 'paid' WHERE participant_id = 'p1'`)
+	f.send(t, &payments.Event{
+		Type:              payments.EventPaymentSucceeded,
+		ProviderPaymentID: *payment.ProviderPaymentID,
+		Amount:            checkout.Amount.Amount,
+		Fee:               90,
+	})
+
+	f.payments.refundDue(f.ctx)
+	f.confirmRefunds(t, *payment.ProviderPaymentID)
+
+	report, err := f.payments.TournamentRevenue(f.ctx, testTournamentID)
+	if err != nil {
+		t.Fatalf("TournamentRevenue: %v", err)
+	}
+	if got, want := balanceOf(report.Currencies[0].Totals), (balance{"0.00", "0.00", "0.90", "-0.90"}); got != want {
+		t.Errorf("totals = %v, want %v: only the overpayment's fee is kept", got, want)
+	}
+
+	participant, err := f.repos.TournamentParticipant.Get(f.ctx, testTournamentID, "p1")
+	if err != nil {
+		t.Fatalf("load participant: %v", err)
+	}
+	if participant.PaymentStatus == nil || *participant.PaymentStatus != models.PaymentPaid {
+		t.Errorf("participant payment status = %v, want paid after the overpayment is refunded", participant.PaymentStatus)
+	}
+}
+
+func TestRevenueCSVFromProviderEvents(t *testing.T) {
+	f := newRevenueFixture(t)
+	f.settle(t)
+
+	report, err := f.payments.OrganizerRevenue(f.ctx, testOrganizerID)
+	if err != nil {
+		t.Fatalf("OrganizerRevenue: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := render.RevenueCSV(&out, report); err != nil {
+		t.Fatalf("RevenueCSV: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"currency,breakdown,key,name,gross,refunds,fees,adjustments,net",
+		"EUR,total,,,230.00,70.00,4.90,0.00,155.10",
+		"EUR,tournament,tournament-1,Spring Open,230.00,70.00,4.90,0.00,155.10",
+		"EUR,price_tier,early_bird,,80.00,0.00,1.50,0.00,78.50",
+		"EUR,price_tier,standard,,100.00,20.00,1.70,0.00,78.30",
+		"EUR,price_tier,withdrawn,,50.00,50.00,1.70,0.00,-1.70",
+		"EUR,payment_method,card,,40.00,0.00,0.00,0.00,40.00",
+		"EUR,payment_method,cash,,50.00,0.00,0.00,0.00,50.00",
+		"EUR,payment_method,online,,140.00,70.00,4.90,0.00,65.10",
+	}, "\n") + "\n"
+	if out.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func equalBalances(got, want map[string]balance) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for key, b := range want {
+		if got[key] != b {
+			return false
+		}
+	}
+	return true
+}
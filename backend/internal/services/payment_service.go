@@ -0,0 +1,469 @@
+// internal/services/payment_service.go
+// Entry fee payments through the payment provider, settled by its webhook events
+
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"tournament-planner/internal/config"
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/payments"
+	"tournament-planner/internal/repositories"
+	"tournament-planner/internal/utils"
+)
+
+// paymentProviderTimeout bounds each call to the payment provider's API
+const paymentProviderTimeout = 20 * time.Second
+
+// PaymentService handles payment operations. Clients only start payments; a
+// participant's payment status changes when the provider confirms the outcome
+// through a signed webhook event.
+type PaymentService struct {
+	repos    *repositories.Container
+	provider payments.Provider
+	config   config.ExternalConfig
+	logger   *log.Logger
+}
+
+// NewPaymentService creates a new payment service
+func NewPaymentService(repos *repositories.Container, provider payments.Provider, config config.ExternalConfig, logger *log.Logger) *PaymentService {
+	return &PaymentService{
+		repos:    repos,
+		provider: provider,
+		config:   config,
+		logger:   logger,
+	}
+}
+
+// newPaymentProvider creates the configured payment provider. The fake provider
+// signs its webhooks with the Stripe webhook secret and accepts none without one.
+func newPaymentProvider(cfg config.ExternalConfig) payments.Provider {
+	if cfg.PaymentProvider == payments.ProviderStripe {
+		return payments.NewStripeProvider(cfg.StripeSecretKey, cfg.StripeWebhookSecret,
+			&http.Client{Timeout: paymentProviderTimeout})
+	}
+	return payments.NewFakeProvider(cfg.StripeWebhookSecret)
+}
+
+// PaymentCheckout is what a client needs to complete a payment with the provider
+type PaymentCheckout struct {
+	PaymentID    string       `json:"payment_id"`
+	Provider     string       `json:"provider"`
+	ClientSecret string       `json:"client_secret"`
+	Amount       models.Money `json:"amount"`
+}
+
+// CreatePayment starts a payment of a participant's entry fee. The amount comes
+// from the tournament, never from the client. The participant's own user and
+// the organizer may start it. A participant has at most one open payment: one
+// still waiting for the payer is canceled with the provider before the new one
+// starts, and one already authorized is refused, so the fee is never charged twice.
+func (s *PaymentService) CreatePayment(ctx context.Context, tournamentID, participantID, userID string) (*PaymentCheckout, error) {
+	participant, err := s.repos.TournamentParticipant.Get(ctx, tournamentID, participantID)
+	if err != nil {
+		return nil, err
+	}
+	if participant == nil {
+		return nil, ErrNotFound
+	}
+
+	tournament, err := s.repos.Tournament.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if tournament.OrganizerID != userID && (participant.UserID == nil || *participant.UserID != userID) {
+		return nil, ErrForbidden
+	}
+	if participant.PaymentStatus != nil && *participant.PaymentStatus != models.PaymentPending {
+		return nil, fmt.Errorf("%w: entry fee is already %s", ErrInvalidInput, *participant.PaymentStatus)
+	}
+
+	amount := amountDue(tournament, participant)
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("%w: tournament has no entry fee", ErrInvalidInput)
+	}
+
+	open, err := s.repos.Payment.GetOpenByParticipant(ctx, tournamentID, participantID)
+	if err != nil {
+		return nil, err
+	}
+	if open != nil {
+		if open.Status == models.ChargeAuthorized {
+			return nil, fmt.Errorf("%w: entry fee payment is already authorized", ErrInvalidInput)
+		}
+		if err := s.cancelOpenPayment(ctx, open); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	payment := &models.Payment{
+		ID:             utils.GenerateUUID(),
+		TournamentID:   tournamentID,
+		ParticipantID:  participantID,
+		Provider:       s.provider.Name(),
+		Amount:         amount,
+		AmountRefunded: models.NewMoney(0, amount.Currency),
+		Status:         models.ChargeRequiresPayment,
+		CreatedBy:      &userID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := s.repos.Payment.Create(ctx, payment); err != nil {
+		if errors.Is(err, repositories.ErrOpenPaymentExists) {
+			return nil, fmt.Errorf("%w: a payment for this participant is already being started", ErrInvalidInput)
+		}
+		return nil, err
+	}
+
+	req := &payments.PaymentRequest{
+		Reference:    payment.ID,
+		Amount:       amount.Amount,
+		Currency:     amount.Currency,
+		Description:  fmt.Sprintf("%s entry fee: %s", tournament.Name, participant.Name),
+		CaptureLater: tournament.CaptureEntryFeesLater,
+		Metadata: map[string]string{
+			"tournament_id":  tournamentID,
+			"participant_id": participantID,
+		},
+	}
+	if participant.ContactEmail != nil {
+		req.CustomerEmail = *participant.ContactEmail
+	}
+
+	started, err := s.provider.CreatePayment(ctx, req)
+	if err != nil {
+		if markErr := s.repos.Payment.MarkFailed(ctx, payment.ID, err.Error()); markErr != nil {
+			s.logger.Printf("Failed to mark payment %s failed: %v", payment.ID, markErr)
+		}
+		return nil, fmt.Errorf("failed to start payment: %w", err)
+	}
+	if err := s.repos.Payment.SetProviderPaymentID(ctx, payment.ID, started.ProviderPaymentID); err != nil {
+		return nil, err
+	}
+
+	return &PaymentCheckout{
+		PaymentID:    payment.ID,
+		Provider:     payment.Provider,
+		ClientSecret: started.ClientSecret,
+		Amount:       amount,
+	}, nil
+}
+
+// cancelOpenPayment abandons a payment that waits for the payer or is authorized.
+// The provider cancels it first, and refuses if the payer completed it in the
+// meantime, in which case its success event settles it as usual.
+func (s *PaymentService) cancelOpenPayment(ctx context.Context, payment *models.Payment) error {
+	if payment.ProviderPaymentID == nil {
+		// Another request is still starting it with the provider
+		if time.Since(payment.CreatedAt) < 2*paymentProviderTimeout {
+			return fmt.Errorf("%w: a payment for this participant is already being started", ErrInvalidInput)
+		}
+	} else if err := s.provider.Cancel(ctx, *payment.ProviderPaymentID); err != nil {
+		return fmt.Errorf("failed to cancel open payment %s: %w", payment.ID, err)
+	}
+
+	if _, err := s.repos.Payment.Cancel(ctx, payment.ID); err != nil {
+		return fmt.Errorf("failed to cancel open payment %s: %w", payment.ID, err)
+	}
+	return nil
+}
+
+// amountDue is what a participant owes, in the tournament's currency.
+// Registrations carry the price they were quoted; older ones pay the standard fee.
+func amountDue(tournament *models.Tournament, participant *models.Participant) models.Money {
+	if participant.AmountDueCents != nil {
+		return models.NewMoney(*participant.AmountDueCents, tournament.Currency)
+	}
+	return tournament.EntryFee
+}
+
+// CapturePayment asks the provider to collect an authorized payment. The
+// payment settles when the provider confirms the capture.
+func (s *PaymentService) CapturePayment(ctx context.Context, paymentID, userID string) error {
+	payment, err := s.repos.Payment.GetByID(ctx, paymentID)
+	if err != nil {
+		return err
+	}
+	if payment == nil {
+		return ErrNotFound
+	}
+	if err := s.requireOrganizer(ctx, payment.TournamentID, userID); err != nil {
+		return err
+	}
+	if payment.Status != models.ChargeAuthorized || payment.ProviderPaymentID == nil {
+		return fmt.Errorf("%w: payment is not awaiting capture", ErrInvalidInput)
+	}
+
+	return s.provider.Capture(ctx, *payment.ProviderPaymentID)
+}
+
+// RefundPayment asks the provider to refund a participant's settled payment,
+// in full when the amount is zero. A payment can be refunded in several parts
+// until nothing is left. The refund reaches the ledger, and the participant is
+// marked refunded once fully refunded, when the provider confirms it.
+//
+// Retrying a request with the same idempotency key refunds once. Without a key
+// one is derived from the payment, the amount and what is refunded so far, so a
+// retry before the provider confirms the refund does not issue a second one.
+func (s *PaymentService) RefundPayment(ctx context.Context, tournamentID, participantID, userID string, amount models.Money, idempotencyKey string) error {
+	if err := s.requireOrganizer(ctx, tournamentID, userID); err != nil {
+		return err
+	}
+
+	payment, err := s.repos.Payment.GetLatestByParticipant(ctx, tournamentID, participantID, models.ChargeSucceeded)
+	if err != nil {
+		return err
+	}
+	if payment == nil || payment.ProviderPaymentID == nil {
+		return fmt.Errorf("%w: participant has no settled payment", ErrInvalidInput)
+	}
+
+	refundable := payment.Refundable()
+	if amount.Currency != "" && amount.Currency != refundable.Currency {
+		return fmt.Errorf("%w: the payment was made in %s", ErrInvalidInput, refundable.Currency)
+	}
+	if amount.IsNegative() || amount.Amount > refundable.Amount {
+		return fmt.Errorf("%w: at most %s can be refunded", ErrInvalidInput, refundable)
+	}
+	if amount.IsZero() {
+		amount = refundable
+	}
+
+	reference := fmt.Sprintf("%s:%d:%d", payment.ID, amount.Amount, payment.AmountRefunded.Amount)
+	if idempotencyKey != "" {
+		reference = payment.ID + ":" + idempotencyKey
+	}
+
+	_, err = s.provider.Refund(ctx, &payments.RefundRequest{
+		Reference:         reference,
+		ProviderPaymentID: *payment.ProviderPaymentID,
+		Amount:            amount.Amount,
+		Reason:            "requested_by_organizer",
+	})
+	return err
+}
+
+// HandleWebhook verifies a provider webhook request and applies its event.
+// Verification failures wrap payments.ErrInvalidWebhook.
+func (s *PaymentService) HandleWebhook(ctx context.Context, payload []byte, header http.Header) error {
+	event, err := s.provider.ParseWebhook(payload, header)
+	if err != nil {
+		return err
+	}
+	if event.Type == payments.EventIgnored || event.ProviderPaymentID == "" {
+		return nil
+	}
+
+	// Providers that don't report the fee with the event are asked for it. A failed
+	// lookup fails the webhook, so the provider redelivers it and the fee isn't lost.
+	if event.Type == payments.EventPaymentSucceeded && event.Fee == 0 {
+		providerCtx, cancel := context.WithTimeout(ctx, paymentProviderTimeout)
+		event.Fee, err = s.provider.PaymentFee(providerCtx, event.ProviderPaymentID, event.Currency)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to look up payment fee: %w", err)
+		}
+	}
+
+	return s.applyEvent(ctx, event)
+}
+
+// applyEvent moves a payment and its participant to the state an event confirms.
+// Each event is applied once, and events arriving out of order never move a
+// payment back to an earlier state.
+func (s *PaymentService) applyEvent(ctx context.Context, event *payments.Event) error {
+	tx, err := s.repos.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	provider := s.provider.Name()
+	fresh, err := s.repos.Payment.RecordEventWithTx(tx, provider, event.ID, string(event.Type))
+	if err != nil || !fresh {
+		return err
+	}
+
+	payment, err := s.repos.Payment.GetByProviderPaymentIDWithTx(tx, provider, event.ProviderPaymentID)
+	if err != nil {
+		return err
+	}
+	if payment == nil {
+		s.logger.Printf("Ignoring %s event %s for unknown payment %s", provider, event.ID, event.ProviderPaymentID)
+		return tx.Commit()
+	}
+
+	// Money in another currency or of another amount than was asked for is never
+	// booked; the payment keeps its state and is flagged for the organizer instead
+	if problem := eventMismatch(payment, event); problem != "" {
+		s.logger.Printf("Rejecting %s event %s for payment %s: %s", provider, event.ID, payment.ID, problem)
+		flag := "needs review: " + problem
+		payment.FailureMessage = &flag
+		if err := s.repos.Payment.UpdateStatusWithTx(tx, payment); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+
+	status := payment.Status
+	refunded := models.NewMoney(0, payment.Amount.Currency)
+	switch event.Type {
+	case payments.EventPaymentAuthorized:
+		status = models.ChargeAuthorized
+	case payments.EventPaymentSucceeded:
+		status = models.ChargeSucceeded
+	case payments.EventPaymentFailed:
+		status = models.ChargeFailed
+		if event.FailureMessage != "" {
+			payment.FailureMessage = &event.FailureMessage
+		}
+	case payments.EventPaymentCanceled:
+		status = models.ChargeCanceled
+	case payments.EventPaymentRefunded:
+		// Providers report the running total, so the ledger gets the difference
+		if event.AmountRefunded > payment.AmountRefunded.Amount {
+			refunded.Amount = event.AmountRefunded - payment.AmountRefunded.Amount
+			payment.AmountRefunded.Amount = event.AmountRefunded
+		}
+		if !payment.Refundable().IsPositive() {
+			status = models.ChargeRefunded
+		}
+	}
+
+	changed := status != payment.Status
+	if changed && !payment.Status.CanBecome(status) {
+		s.logger.Printf("Ignoring %s event %s: payment %s is already %s", provider, event.ID, payment.ID, payment.Status)
+		return tx.Commit()
+	}
+	payment.Status = status
+
+	// A participant who already settled their fee, at the venue or with another
+	// payment, has paid twice. The second payment is refunded in full and never
+	// booked, and the participant stays paid whatever becomes of it.
+	settles := changed && status == models.ChargeSucceeded
+	if settles {
+		// Locking the tournament orders this against a cancellation: either the
+		// cancellation's refund batch sees the payment, or the payment sees the cancellation
+		tournamentStatus, err := s.repos.Tournament.GetStatusForUpdateWithTx(tx, payment.TournamentID)
+		if err != nil {
+			return err
+		}
+
+		paid, err := s.repos.TournamentParticipant.UpdatePaymentStatusFromWithTx(tx, payment.TournamentID, payment.ParticipantID, models.PaymentPending, models.PaymentPaid)
+		if err != nil {
+			return err
+		}
+		switch {
+		case !paid:
+			s.logger.Printf("Payment %s is an overpayment: participant %s already settled their fee", payment.ID, payment.ParticipantID)
+			payment.Overpayment = true
+			if err := s.queueOverpaymentRefundWithTx(tx, payment); err != nil {
+				return fmt.Errorf("failed to queue overpayment refund: %w", err)
+			}
+		case tournamentStatus == models.StatusCancelled:
+			s.logger.Printf("Payment %s settled after tournament %s was cancelled; refunding it", payment.ID, payment.TournamentID)
+			if err := s.queueLateCancellationRefundWithTx(ctx, tx, payment); err != nil {
+				return fmt.Errorf("failed to queue cancellation refund: %w", err)
+			}
+		}
+	}
+
+	if err := s.repos.Payment.UpdateStatusWithTx(tx, payment); err != nil {
+		return err
+	}
+	// An overpayment only costs the organizer its processing fee
+	if settles && !payment.Overpayment {
+		if err := s.recordEvent(tx, payment, event, models.LedgerCharge, payment.Amount, "Entry fee payment"); err != nil {
+			return err
+		}
+		if err := s.invoicePayment(ctx, tx, payment, payment.Amount); err != nil {
+			return fmt.Errorf("failed to issue invoice: %w", err)
+		}
+	}
+	if changed && event.Fee > 0 {
+		fee := models.NewMoney(-event.Fee, payment.Amount.Currency)
+		if err := s.recordEvent(tx, payment, event, models.LedgerFee, fee, "Payment processing fee"); err != nil {
+			return err
+		}
+	}
+	if refunded.IsPositive() && !payment.Overpayment {
+		if err := s.recordEvent(tx, payment, event, models.LedgerRefund, refunded.Neg(), "Entry fee refund"); err != nil {
+			return err
+		}
+		if err := s.creditRefund(tx, payment, event.ID, refunded); err != nil {
+			return fmt.Errorf("failed to issue credit note: %w", err)
+		}
+	}
+
+	if changed && status == models.ChargeRefunded && !payment.Overpayment {
+		err := s.repos.TournamentParticipant.UpdatePaymentStatusWithTx(tx, payment.TournamentID, payment.ParticipantID, models.PaymentRefunded)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// eventMismatch describes how an event's money disagrees with the payment it is
+// about, or returns "" when it agrees. Authorizations and charges must be for the
+// payment's amount, and refunds can't exceed it.
+func eventMismatch(payment *models.Payment, event *payments.Event) string {
+	switch event.Type {
+	case payments.EventPaymentAuthorized, payments.EventPaymentSucceeded, payments.EventPaymentRefunded:
+	default:
+		return ""
+	}
+
+	if !strings.EqualFold(event.Currency, payment.Amount.Currency) {
+		return fmt.Sprintf("provider reported currency %q, expected %s", event.Currency, payment.Amount.Currency)
+	}
+	if event.Type == payments.EventPaymentRefunded {
+		if event.AmountRefunded > payment.Amount.Amount {
+			return fmt.Sprintf("provider reported %s refunded of %s",
+				models.NewMoney(event.AmountRefunded, payment.Amount.Currency), payment.Amount)
+		}
+		return ""
+	}
+	if event.Amount != payment.Amount.Amount {
+		return fmt.Sprintf("provider reported %s, expected %s",
+			models.NewMoney(event.Amount, payment.Amount.Currency), payment.Amount)
+	}
+	return ""
+}
+
+// recordEvent adds the ledger entry a provider event confirms
+func (s *PaymentService) recordEvent(tx *sql.Tx, payment *models.Payment, event *payments.Event, entryType models.LedgerEntryType, amount models.Money, description string) error {
+	return s.repos.Ledger.CreateWithTx(tx, &models.LedgerEntry{
+		ID:                utils.GenerateUUID(),
+		TournamentID:      payment.TournamentID,
+		ParticipantID:     payment.ParticipantID,
+		PaymentID:         &payment.ID,
+		Type:              entryType,
+		Amount:            amount,
+		ProviderReference: &event.ID,
+		Description:       description,
+		CreatedAt:         time.Now(),
+	})
+}
+
+// requireOrganizer checks that a user organizes a tournament
+func (s *PaymentService) requireOrganizer(ctx context.Context, tournamentID, userID string) error {
+	tournament, err := s.repos.Tournament.GetByID(ctx, tournamentID)
+	if err != nil {
+		return err
+	}
+	if tournament.OrganizerID != userID {
+		return ErrForbidden
+	}
+	return nil
+}
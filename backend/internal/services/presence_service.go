@@ -0,0 +1,109 @@
+// internal/services/presence_service.go
+// Live connection tracking and participant presence
+
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/repositories"
+)
+
+// presenceTimeout is how long a connection counts as online after its last heartbeat
+const presenceTimeout = 2 * time.Minute
+
+// PresenceService records live connections and reports who is online
+type PresenceService struct {
+	repos  *repositories.Container
+	logger *log.Logger
+}
+
+// NewPresenceService creates a new presence service
+func NewPresenceService(repos *repositories.Container, logger *log.Logger) *PresenceService {
+	return &PresenceService{
+		repos:  repos,
+		logger: logger,
+	}
+}
+
+// Connected records a new connection for a user
+func (s *PresenceService) Connected(ctx context.Context, connectionID, userID string) error {
+	now := time.Now()
+	return s.repos.Connection.Create(ctx, &models.Connection{
+		ConnectionID:            connectionID,
+		UserID:                  userID,
+		TournamentSubscriptions: []string{},
+		ConnectedAt:             now,
+		LastPing:                now,
+	})
+}
+
+// Disconnected removes a closed connection
+func (s *PresenceService) Disconnected(ctx context.Context, connectionID string) error {
+	return s.repos.Connection.Delete(ctx, connectionID)
+}
+
+// Subscribed records that a connection follows a tournament
+func (s *PresenceService) Subscribed(ctx context.Context, connectionID, tournamentID string) error {
+	return s.repos.Connection.AddSubscription(ctx, connectionID, tournamentID)
+}
+
+// Unsubscribed records that a connection stopped following a tournament
+func (s *PresenceService) Unsubscribed(ctx context.Context, connectionID, tournamentID string) error {
+	return s.repos.Connection.RemoveSubscription(ctx, connectionID, tournamentID)
+}
+
+// Heartbeat keeps a connection online
+func (s *PresenceService) Heartbeat(ctx context.Context, connectionID string) error {
+	return s.repos.Connection.Touch(ctx, connectionID, time.Now())
+}
+
+// GetTournamentPresence reports which of a tournament's participants are online right now
+func (s *PresenceService) GetTournamentPresence(ctx context.Context, tournamentID string) ([]*models.ParticipantPresence, error) {
+	if _, err := s.repos.Tournament.GetByID(ctx, tournamentID); err != nil {
+		return nil, ErrNotFound
+	}
+
+	participants, err := s.repos.TournamentParticipant.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(participants))
+	for _, p := range participants {
+		if p.UserID != nil {
+			userIDs = append(userIDs, *p.UserID)
+		}
+	}
+
+	online := map[string]*models.UserPresence{}
+	if len(userIDs) > 0 {
+		online, err = s.repos.Connection.GetPresence(ctx, userIDs, time.Now().Add(-presenceTimeout))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	presence := make([]*models.ParticipantPresence, 0, len(participants))
+	for _, p := range participants {
+		entry := &models.ParticipantPresence{
+			ParticipantID: p.ID,
+			Name:          p.Name,
+			UserID:        p.UserID,
+		}
+		if p.UserID != nil {
+			if user, ok := online[*p.UserID]; ok {
+				entry.Online = true
+				entry.Connections = user.Connections
+				lastSeen := user.LastSeen
+				entry.LastSeen = &lastSeen
+			}
+		}
+		presence = append(presence, entry)
+	}
+
+	return presence, nil
+}
@@ -0,0 +1,216 @@
+// internal/services/schedule_service.go
+// Schedule views grouped by local day and venue, used by the schedule endpoint and calendar feeds
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/repositories"
+)
+
+// ScheduleService builds schedule views for tournaments
+type ScheduleService struct {
+	repos  *repositories.Container
+	logger *log.Logger
+}
+
+// NewScheduleService creates a new schedule service
+func NewScheduleService(repos *repositories.Container, logger *log.Logger) *ScheduleService {
+	return &ScheduleService{
+		repos:  repos,
+		logger: logger,
+	}
+}
+
+// ScheduleFilter narrows the matches returned by GetSchedule
+type ScheduleFilter struct {
+	ParticipantID string
+	Division      string
+	VenueID       string
+}
+
+// GetSchedule returns a tournament's matches grouped by local date and venue
+func (s *ScheduleService) GetSchedule(ctx context.Context, tournamentID string, filter ScheduleFilter) (*models.Schedule, error) {
+	tournament, err := s.repos.Tournament.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	matches, err := s.repos.Match.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch matches: %w", err)
+	}
+
+	participants, err := s.repos.TournamentParticipant.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch participants: %w", err)
+	}
+
+	venues, err := s.repos.Venue.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch venues: %w", err)
+	}
+
+	byID := make(map[string]*models.Participant, len(participants))
+	for _, p := range participants {
+		byID[p.ID] = p
+	}
+	venuesByID := make(map[string]*models.Venue, len(venues))
+	for _, v := range venues {
+		venuesByID[v.ID] = v
+	}
+
+	schedule := &models.Schedule{
+		TournamentID:   tournament.ID,
+		TournamentName: tournament.Name,
+		MatchDuration:  tournament.AvgMatchDuration,
+		Days:           make([]*models.ScheduleDay, 0),
+		Unscheduled:    make([]*models.Match, 0),
+	}
+
+	// Filters that name something outside the tournament are not found rather than empty
+	if filter.ParticipantID != "" {
+		p, ok := byID[filter.ParticipantID]
+		if !ok {
+			return nil, ErrNotFound
+		}
+		schedule.Participant = p
+	}
+	if filter.VenueID != "" {
+		v, ok := venuesByID[filter.VenueID]
+		if !ok {
+			return nil, ErrNotFound
+		}
+		schedule.Venue = v
+	}
+
+	// Dates are grouped in the tournament's local time; fall back to UTC if the zone is unknown
+	loc, err := time.LoadLocation(tournament.Timezone)
+	if err != nil {
+		s.logger.Printf("Unknown timezone %q for tournament %s, using UTC", tournament.Timezone, tournamentID)
+		loc = time.UTC
+	}
+	schedule.Timezone = loc.String()
+
+	days := make(map[string]map[string]*models.ScheduleVenue)
+	for _, m := range matches {
+		if !matchesScheduleFilter(m, filter, byID) {
+			continue
+		}
+
+		// Attach participant details so clients don't need a second lookup
+		if m.Participant1ID != nil {
+			m.Participant1 = byID[*m.Participant1ID]
+		}
+		if m.Participant2ID != nil {
+			m.Participant2 = byID[*m.Participant2ID]
+		}
+		if m.VenueID != nil {
+			m.Venue = venuesByID[*m.VenueID]
+		}
+
+		if m.ScheduledDatetime == nil {
+			schedule.Unscheduled = append(schedule.Unscheduled, m)
+			continue
+		}
+
+		date := m.ScheduledDatetime.In(loc).Format("2006-01-02")
+		if days[date] == nil {
+			days[date] = make(map[string]*models.ScheduleVenue)
+		}
+
+		venueKey := ""
+		if m.Venue != nil {
+			venueKey = m.Venue.ID
+		}
+		entry, ok := days[date][venueKey]
+		if !ok {
+			entry = &models.ScheduleVenue{VenueName: "Unassigned", Matches: make([]*models.Match, 0)}
+			if m.Venue != nil {
+				entry.VenueID = &m.Venue.ID
+				entry.VenueName = m.Venue.Name
+			}
+			days[date][venueKey] = entry
+		}
+		entry.Matches = append(entry.Matches, m)
+	}
+
+	dates := make([]string, 0, len(days))
+	for date := range days {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	for _, date := range dates {
+		day := &models.ScheduleDay{Date: date, Venues: make([]*models.ScheduleVenue, 0, len(days[date]))}
+		for _, entry := range days[date] {
+			sort.Slice(entry.Matches, func(i, j int) bool {
+				a, b := entry.Matches[i], entry.Matches[j]
+				if !a.ScheduledDatetime.Equal(*b.ScheduledDatetime) {
+					return a.ScheduledDatetime.Before(*b.ScheduledDatetime)
+				}
+				return a.MatchNumber < b.MatchNumber
+			})
+			day.Venues = append(day.Venues, entry)
+		}
+
+		// Venues by name, with matches still waiting on a venue last
+		sort.Slice(day.Venues, func(i, j int) bool {
+			a, b := day.Venues[i], day.Venues[j]
+			if (a.VenueID == nil) != (b.VenueID == nil) {
+				return b.VenueID == nil
+			}
+			return a.VenueName < b.VenueName
+		})
+		schedule.Days = append(schedule.Days, day)
+	}
+
+	sort.Slice(schedule.Unscheduled, func(i, j int) bool {
+		a, b := schedule.Unscheduled[i], schedule.Unscheduled[j]
+		if a.RoundNumber != b.RoundNumber {
+			return a.RoundNumber < b.RoundNumber
+		}
+		return a.MatchNumber < b.MatchNumber
+	})
+
+	return schedule, nil
+}
+
+// matchesScheduleFilter reports whether a match passes the participant, division and venue filters
+func matchesScheduleFilter(m *models.Match, filter ScheduleFilter, participants map[string]*models.Participant) bool {
+	if filter.VenueID != "" && (m.VenueID == nil || *m.VenueID != filter.VenueID) {
+		return false
+	}
+
+	if filter.ParticipantID != "" {
+		involved := (m.Participant1ID != nil && *m.Participant1ID == filter.ParticipantID) ||
+			(m.Participant2ID != nil && *m.Participant2ID == filter.ParticipantID)
+		if !involved {
+			return false
+		}
+	}
+
+	// A match belongs to a division when either side is registered in it
+	if filter.Division != "" {
+		inDivision := false
+		for _, id := range []*string{m.Participant1ID, m.Participant2ID} {
+			if id == nil {
+				continue
+			}
+			if p, ok := participants[*id]; ok && p.Division != nil && *p.Division == filter.Division {
+				inDivision = true
+			}
+		}
+		if !inDivision {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,426 @@
+// internal/services/standings_service.go
+// League table computation with configurable tiebreakers for round robin and group stages
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"sort"
+	"time"
+
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/repositories"
+)
+
+// StandingsService computes league tables from completed matches
+type StandingsService struct {
+	repos  *repositories.Container
+	cache  *CacheService
+	logger *log.Logger
+}
+
+// NewStandingsService creates a new standings service
+func NewStandingsService(repos *repositories.Container, cache *CacheService, logger *log.Logger) *StandingsService {
+	return &StandingsService{
+		repos:  repos,
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// StandingsFilter narrows the tables returned by GetStandings
+type StandingsFilter struct {
+	Group    string
+	Division string
+}
+
+// GetStandings returns the league tables of a tournament, one per group and division
+func (s *StandingsService) GetStandings(ctx context.Context, tournamentID string, filter StandingsFilter) ([]*models.StandingsTable, error) {
+	var tables []*models.StandingsTable
+	if err := s.cache.Get(fmt.Sprintf("tournament_standings_%s", tournamentID), &tables); err != nil {
+		tables, err = s.computeStandings(ctx, tournamentID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Cache for 5 minutes; ReportScore invalidates on every result
+		s.cache.Set(fmt.Sprintf("tournament_standings_%s", tournamentID), tables, 5*time.Minute)
+	}
+
+	filtered := make([]*models.StandingsTable, 0, len(tables))
+	for _, table := range tables {
+		if filter.Group != "" && table.Group != filter.Group {
+			continue
+		}
+		if filter.Division != "" && table.Division != filter.Division {
+			continue
+		}
+		filtered = append(filtered, table)
+	}
+
+	return filtered, nil
+}
+
+// computeStandings builds every table of the tournament from the database
+func (s *StandingsService) computeStandings(ctx context.Context, tournamentID string) ([]*models.StandingsTable, error) {
+	tournament, err := s.repos.Tournament.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch tournament.FormatType {
+	case models.FormatRoundRobin, models.FormatGroupToKnockout, models.FormatSwiss:
+	default:
+		return nil, fmt.Errorf("%w: standings are only available for league and group formats", ErrInvalidFormat)
+	}
+
+	participants, err := s.repos.TournamentParticipant.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch participants: %w", err)
+	}
+
+	matches, err := s.repos.Match.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch matches: %w", err)
+	}
+
+	points := models.DefaultPointsSystem
+	tiebreakers := models.DefaultTiebreakers
+	if tournament.FormatConfig != nil {
+		if tournament.FormatConfig.Points != nil {
+			points = *tournament.FormatConfig.Points
+		}
+		if len(tournament.FormatConfig.Tiebreakers) > 0 {
+			tiebreakers = tournament.FormatConfig.Tiebreakers
+		}
+	}
+
+	// Knockout rounds of a group-to-knockout tournament don't count towards groups
+	groupOnly := tournament.FormatType == models.FormatGroupToKnockout
+
+	return buildStandingsTables(tournamentID, participants, matches, points, tiebreakers, groupOnly, s.logger), nil
+}
+
+// tableKey identifies one league table
+type tableKey struct {
+	division string
+	group    string
+}
+
+// buildStandingsTables groups participants into tables and ranks each one
+func buildStandingsTables(
+	tournamentID string,
+	participants []*models.Participant,
+	matches []*models.Match,
+	points models.PointsSystem,
+	tiebreakers []models.Tiebreaker,
+	groupOnly bool,
+	logger *log.Logger,
+) []*models.StandingsTable {
+	rows := make(map[string]*models.Standing, len(participants))
+	keyOf := make(map[string]tableKey, len(participants))
+	tableRows := make(map[tableKey][]*models.Standing)
+	order := make([]tableKey, 0)
+
+	for _, p := range participants {
+		key := tableKey{}
+		if p.Division != nil {
+			key.division = *p.Division
+		}
+		if p.GroupName != nil {
+			key.group = *p.GroupName
+		}
+		keyOf[p.ID] = key
+	}
+
+	// Participants without a stored group take the group of the matches they play
+	for _, m := range matches {
+		if m.GroupName == nil || m.Participant1ID == nil || m.Participant2ID == nil {
+			continue
+		}
+		for _, id := range []string{*m.Participant1ID, *m.Participant2ID} {
+			if key, ok := keyOf[id]; ok && key.group == "" {
+				key.group = *m.GroupName
+				keyOf[id] = key
+			}
+		}
+	}
+
+	for _, p := range participants {
+		row := &models.Standing{
+			ParticipantID:   p.ID,
+			ParticipantName: p.Name,
+			Seed:            p.Seed,
+		}
+		rows[p.ID] = row
+
+		key := keyOf[p.ID]
+		if _, exists := tableRows[key]; !exists {
+			order = append(order, key)
+		}
+		tableRows[key] = append(tableRows[key], row)
+	}
+
+	// Only results within a table count towards it
+	results := make(map[tableKey][]*models.Match)
+	for _, m := range matches {
+		if !countsForStandings(m, groupOnly) {
+			continue
+		}
+		key1, ok1 := keyOf[*m.Participant1ID]
+		key2, ok2 := keyOf[*m.Participant2ID]
+		if !ok1 || !ok2 || key1 != key2 {
+			continue
+		}
+
+		applyResult(rows[*m.Participant1ID], rows[*m.Participant2ID], m, points)
+		results[key1] = append(results[key1], m)
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		if order[i].division != order[j].division {
+			return order[i].division < order[j].division
+		}
+		return order[i].group < order[j].group
+	})
+
+	tables := make([]*models.StandingsTable, 0, len(order))
+	for _, key := range order {
+		ranker := &standingsRanker{
+			tournamentID: tournamentID,
+			matches:      results[key],
+			points:       points,
+			tiebreakers:  tiebreakers,
+			logger:       logger,
+		}
+		ranked := ranker.rank(tableRows[key])
+		for i, row := range ranked {
+			row.Position = i + 1
+		}
+
+		tables = append(tables, &models.StandingsTable{
+			Group:       key.group,
+			Division:    key.division,
+			Tiebreakers: tiebreakers,
+			Standings:   ranked,
+		})
+	}
+
+	return tables
+}
+
+// countsForStandings reports whether a match result belongs in a league table
+func countsForStandings(m *models.Match, groupOnly bool) bool {
+	if m.Status != models.MatchCompleted && m.Status != models.MatchWalkover {
+		return false
+	}
+	if m.Participant1ID == nil || m.Participant2ID == nil || m.Score1 == nil || m.Score2 == nil {
+		return false
+	}
+	if groupOnly && m.GroupName == nil {
+		return false
+	}
+	return true
+}
+
+// applyResult adds one match result to both participants' rows
+func applyResult(row1, row2 *models.Standing, m *models.Match, points models.PointsSystem) {
+	score1, score2 := *m.Score1, *m.Score2
+
+	row1.Played++
+	row2.Played++
+	row1.ScoreFor += score1
+	row1.ScoreAgainst += score2
+	row2.ScoreFor += score2
+	row2.ScoreAgainst += score1
+	row1.ScoreDifference = row1.ScoreFor - row1.ScoreAgainst
+	row2.ScoreDifference = row2.ScoreFor - row2.ScoreAgainst
+
+	switch {
+	case score1 > score2:
+		row1.Won++
+		row2.Lost++
+		row1.Points += points.Win
+		row2.Points += points.Loss
+	case score2 > score1:
+		row2.Won++
+		row1.Lost++
+		row2.Points += points.Win
+		row1.Points += points.Loss
+	default:
+		row1.Drawn++
+		row2.Drawn++
+		row1.Points += points.Draw
+		row2.Points += points.Draw
+	}
+
+	if m.ScoreDetails != nil {
+		for _, set := range m.ScoreDetails.Sets {
+			if set.Player1Score > set.Player2Score {
+				row1.SetsWon++
+				row2.SetsLost++
+			} else if set.Player2Score > set.Player1Score {
+				row2.SetsWon++
+				row1.SetsLost++
+			}
+		}
+	}
+}
+
+// standingsRanker orders a table by points and then by the configured tiebreakers
+type standingsRanker struct {
+	tournamentID string
+	matches      []*models.Match
+	points       models.PointsSystem
+	tiebreakers  []models.Tiebreaker
+	logger       *log.Logger
+}
+
+// rank sorts rows by points and resolves ties recursively
+func (r *standingsRanker) rank(rows []*models.Standing) []*models.Standing {
+	sorted := make([]*models.Standing, len(rows))
+	copy(sorted, rows)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Points != sorted[j].Points {
+			return sorted[i].Points > sorted[j].Points
+		}
+		return sorted[i].ParticipantName < sorted[j].ParticipantName
+	})
+
+	return r.resolveBlocks(sorted, func(row *models.Standing) float64 {
+		return float64(row.Points)
+	}, 0)
+}
+
+// resolveBlocks splits rows into runs sharing the same value and breaks each run
+// with the tiebreakers from position next onwards
+func (r *standingsRanker) resolveBlocks(rows []*models.Standing, value func(*models.Standing) float64, next int) []*models.Standing {
+	result := make([]*models.Standing, 0, len(rows))
+	for start := 0; start < len(rows); {
+		end := start + 1
+		for end < len(rows) && value(rows[end]) == value(rows[start]) {
+			end++
+		}
+
+		block := rows[start:end]
+		if len(block) > 1 {
+			block = r.breakTie(block, next)
+		}
+		result = append(result, block...)
+		start = end
+	}
+	return result
+}
+
+// breakTie orders a block of tied rows using tiebreakers starting at index next
+func (r *standingsRanker) breakTie(block []*models.Standing, next int) []*models.Standing {
+	for i := next; i < len(r.tiebreakers); i++ {
+		tiebreaker := r.tiebreakers[i]
+		value, ok := r.criterion(tiebreaker, block)
+		if !ok {
+			continue
+		}
+
+		sorted := make([]*models.Standing, len(block))
+		copy(sorted, block)
+		sort.SliceStable(sorted, func(a, b int) bool {
+			return value(sorted[a]) > value(sorted[b])
+		})
+
+		// Skip criteria that don't separate anyone
+		if value(sorted[0]) == value(sorted[len(sorted)-1]) {
+			continue
+		}
+
+		for _, row := range sorted {
+			row.TiebreakerApplied = string(tiebreaker)
+		}
+		return r.resolveBlocks(sorted, value, i+1)
+	}
+
+	return block
+}
+
+// criterion returns the comparison value for a tiebreaker, higher ranks first.
+// The boolean is false when the tiebreaker doesn't apply to this block.
+func (r *standingsRanker) criterion(tiebreaker models.Tiebreaker, block []*models.Standing) (func(*models.Standing) float64, bool) {
+	switch tiebreaker {
+	case models.TiebreakerHeadToHead:
+		// Head-to-head only decides between exactly two participants
+		if len(block) != 2 {
+			return nil, false
+		}
+		mini := r.miniLeague(block)
+		return func(row *models.Standing) float64 { return float64(mini[row.ParticipantID].Points) }, true
+
+	case models.TiebreakerMiniLeague:
+		mini := r.miniLeague(block)
+		return func(row *models.Standing) float64 {
+			m := mini[row.ParticipantID]
+			// Points first, then score difference among the tied participants
+			return float64(m.Points)*1e6 + float64(m.ScoreDifference)
+		}, true
+
+	case models.TiebreakerScoreDifference:
+		return func(row *models.Standing) float64 { return float64(row.ScoreDifference) }, true
+
+	case models.TiebreakerScoreFor:
+		return func(row *models.Standing) float64 { return float64(row.ScoreFor) }, true
+
+	case models.TiebreakerSetsRatio:
+		return func(row *models.Standing) float64 { return setsRatio(row) }, true
+
+	case models.TiebreakerSeed:
+		return func(row *models.Standing) float64 {
+			if row.Seed == nil {
+				return -1e9
+			}
+			return -float64(*row.Seed)
+		}, true
+
+	case models.TiebreakerCoinToss:
+		// Deterministic per tournament so the table doesn't change between requests
+		return func(row *models.Standing) float64 {
+			h := fnv.New32a()
+			h.Write([]byte(r.tournamentID + ":" + row.ParticipantID))
+			return float64(h.Sum32())
+		}, true
+
+	default:
+		r.logger.Printf("Ignoring unknown tiebreaker %q", tiebreaker)
+		return nil, false
+	}
+}
+
+// miniLeague computes a table restricted to matches between the given rows
+func (r *standingsRanker) miniLeague(block []*models.Standing) map[string]*models.Standing {
+	mini := make(map[string]*models.Standing, len(block))
+	for _, row := range block {
+		mini[row.ParticipantID] = &models.Standing{ParticipantID: row.ParticipantID}
+	}
+
+	for _, m := range r.matches {
+		row1, ok1 := mini[*m.Participant1ID]
+		row2, ok2 := mini[*m.Participant2ID]
+		if ok1 && ok2 {
+			applyResult(row1, row2, m, r.points)
+		}
+	}
+
+	return mini
+}
+
+// setsRatio returns sets won divided by sets lost
+func setsRatio(row *models.Standing) float64 {
+	if row.SetsLost == 0 {
+		if row.SetsWon == 0 {
+			return 0
+		}
+		// Undefeated in sets ranks above any finite ratio
+		return 1e6 + float64(row.SetsWon)
+	}
+	return float64(row.SetsWon) / float64(row.SetsLost)
+}
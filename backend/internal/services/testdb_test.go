@@ -0,0 +1,117 @@
+// internal/services/testdb_test.go
+// In-memory MySQL-compatible database loaded with the schema, for service tests
+
+package services
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"tournament-planner/internal/database"
+	"tournament-planner/internal/repositories"
+
+	"github.com/alicebob/miniredis/v2"
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/server"
+	gmssql "github.com/dolthub/go-mysql-server/sql"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// schemaPath is the schema the server is initialized with
+const schemaPath = "../../migrations/init-database.sql"
+
+// newTestRepos starts an in-memory database server with the schema and returns
+// repositories connected to it. MongoDB is never reached; its repositories get
+// a client that would only connect on first use.
+func newTestRepos(t *testing.T) (*repositories.Container, *sql.DB) {
+	t.Helper()
+
+	logrus.SetOutput(io.Discard)
+
+	provider := memory.NewDBProvider(memory.NewDatabase("tournament_planner"))
+	engine := sqle.NewDefault(provider)
+	cfg := server.Config{Protocol: "tcp", Address: "127.0.0.1:0"}
+	srv, err := server.NewServer(cfg, engine, gmssql.NewContext, memory.NewSessionBuilder(provider), nil)
+	if err != nil {
+		t.Fatalf("start database: %v", err)
+	}
+	go srv.Start()
+	t.Cleanup(func() { srv.Close() })
+
+	// The in-memory engine can't index the referenced side of foreign keys, so
+	// they are declared without being checked
+	dsn := "root@tcp(" + srv.Listener.Addr().String() + ")/tournament_planner?parseTime=true&foreign_key_checks=0"
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	schema, err := os.ReadFile(schemaPath)
+	if err != nil {
+		t.Fatalf("read schema: %v", err)
+	}
+	for _, statement := range schemaStatements(string(schema)) {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("load schema: %v\n%s", err, statement)
+		}
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:1"))
+	if err != nil {
+		t.Fatalf("create mongo client: %v", err)
+	}
+	t.Cleanup(func() { client.Disconnect(context.Background()) })
+
+	repos := repositories.NewContainer(&database.Connections{
+		MySQL:   db,
+		MongoDB: client.Database("tournament_planner"),
+	})
+	return repos, db
+}
+
+// newTestCache returns a cache backed by an in-memory Redis
+func newTestCache(t *testing.T) *CacheService {
+	t.Helper()
+
+	client := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewCacheService(client, log.New(io.Discard, "", 0))
+}
+
+// schemaStatements splits the schema into its table statements, leaving out
+// comments and the database and account setup the test server has no use for
+func schemaStatements(schema string) []string {
+	var statements []string
+	for _, chunk := range strings.Split(schema, ";\n") {
+		var lines []string
+		for _, line := range strings.Split(chunk, "\n") {
+			if !strings.HasPrefix(strings.TrimSpace(line), "--") {
+				lines = append(lines, line)
+			}
+		}
+
+		statement := strings.TrimSpace(strings.Join(lines, "\n"))
+		switch {
+		case statement == "",
+			strings.HasPrefix(statement, "CREATE DATABASE"),
+			strings.HasPrefix(statement, "CREATE USER"),
+			strings.HasPrefix(statement, "GRANT"),
+			strings.HasPrefix(statement, "FLUSH"),
+			strings.HasPrefix(statement, "USE "):
+			continue
+		}
+		statements = append(statements, statement)
+	}
+	return statements
+}
@@ -0,0 +1,74 @@
+// internal/services/tournament_membership_test.go
+// Member prices apply only to accounts the organizer lists as members
+
+package services
+
+import (
+	"io"
+	"log"
+	"testing"
+
+	"tournament-planner/internal/config"
+	"tournament-planner/internal/events"
+)
+
+func TestMemberPriceRequiresListedMember(t *testing.T) {
+	f := newRevenueFixture(t)
+	f.exec(t, `UPDATE tournaments SET pricing = ? WHERE id = ?`,
+		`{"member_fee":{"amount":3000,"currency":"EUR"}}`, testTournamentID)
+	for _, u := range []struct{ id, email string }{
+		{"user-member", "member@example.com"},
+		{"user-guest", "guest@example.com"},
+	} {
+		f.exec(t, `INSERT INTO users (id, email, password_hash, full_name, role) VALUES (?, ?, 'x', ?, 'user')`,
+			u.id, u.email, u.email)
+	}
+
+	logger := log.New(io.Discard, "", 0)
+	cfg := &config.Config{}
+	tournaments := NewTournamentService(f.repos, newTestCache(t),
+		NewNotificationService(f.repos, nil, nil, cfg, logger), f.payments,
+		NewWebhookService(f.repos, nil, cfg, logger), events.NewBus(logger), logger)
+
+	if _, err := tournaments.AddMember(f.ctx, testTournamentID, testOrganizerID, MemberRequest{Email: "member@example.com"}); err != nil {
+		t.Fatalf("add member: %v", err)
+	}
+	if _, err := tournaments.AddMember(f.ctx, testTournamentID, testOrganizerID, MemberRequest{Email: "nobody@example.com"}); err == nil {
+		t.Errorf("adding an email without an account succeeded")
+	}
+
+	tests := []struct {
+		userID     string
+		wantMember bool
+		wantDue    int64
+	}{
+		{"user-member", true, 3000},
+		{"user-guest", false, 5000},
+		{"", false, 5000},
+	}
+	for _, tt := range tests {
+		reg, err := tournaments.Register(f.ctx, testTournamentID, tt.userID, RegisterParticipantRequest{
+			Name:         "Player " + tt.userID,
+			Type:         "individual",
+			ContactEmail: "player@example.com",
+		})
+		if err != nil {
+			t.Fatalf("register %q: %v", tt.userID, err)
+		}
+		if reg.Price.Member != tt.wantMember || reg.Price.AmountDue.Amount != tt.wantDue {
+			t.Errorf("register %q: member = %v, due = %d; want %v, %d",
+				tt.userID, reg.Price.Member, reg.Price.AmountDue.Amount, tt.wantMember, tt.wantDue)
+		}
+	}
+
+	if err := tournaments.RemoveMember(f.ctx, testTournamentID, "user-member"); err != nil {
+		t.Fatalf("remove member: %v", err)
+	}
+	quote, err := tournaments.QuotePrice(f.ctx, testTournamentID, "user-member", "")
+	if err != nil {
+		t.Fatalf("quote: %v", err)
+	}
+	if quote.Member {
+		t.Errorf("removed member is still quoted member prices")
+	}
+}
@@ -0,0 +1,283 @@
+// internal/services/tournament_pricing.go
+// Entry fee pricing tiers, discount codes and price quotes for registrations
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/utils"
+)
+
+// discountCodePattern limits codes to what is easy to read out and type
+var discountCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,50}$`)
+
+// PricingRequest sets a tournament's currency, standard entry fee and pricing
+// tiers. A nil pricing removes the tiers.
+type PricingRequest struct {
+	Currency string               `json:"currency"`
+	EntryFee *models.Money        `json:"entry_fee"`
+	Pricing  *models.EntryPricing `json:"pricing"`
+}
+
+// DiscountCodeRequest creates a discount code
+type DiscountCodeRequest struct {
+	Code      string              `json:"code" binding:"required"`
+	Type      models.DiscountType `json:"type" binding:"required,oneof=percent fixed"`
+	Value     int64               `json:"value" binding:"required,min=1"`
+	MaxUses   *int                `json:"max_uses" binding:"omitempty,min=1"`
+	ExpiresAt *time.Time          `json:"expires_at"`
+}
+
+// MemberRequest lists the account with an email as a tournament member
+type MemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// DiscountCodeUpdate replaces a discount code's usage limit, expiry and active flag
+type DiscountCodeUpdate struct {
+	MaxUses   *int       `json:"max_uses" binding:"omitempty,min=1"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	IsActive  *bool      `json:"is_active" binding:"required"`
+}
+
+// UpdatePricing changes a tournament's entry fee and pricing tiers. Existing
+// registrations keep the price they were quoted. The currency can only change
+// before anyone registers, and the entry fee must then be given in it.
+func (s *TournamentService) UpdatePricing(ctx context.Context, tournamentID string, req PricingRequest) (*models.Tournament, error) {
+	tournament, err := s.repos.Tournament.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Registrations and the ledger are priced in the tournament's currency
+	currency := tournament.Currency
+	if req.Currency != "" && !strings.EqualFold(req.Currency, currency) {
+		balances, err := s.repos.Ledger.BalancesByTournament(ctx, tournamentID)
+		if err != nil {
+			return nil, err
+		}
+		if tournament.CurrentParticipants > 0 || len(balances) > 0 {
+			return nil, fmt.Errorf("%w: the currency can't change once participants have registered", ErrInvalidInput)
+		}
+		if req.EntryFee == nil {
+			return nil, fmt.Errorf("%w: give the entry fee in the new currency", ErrInvalidInput)
+		}
+		if policy := tournament.RefundPolicy; policy != nil {
+			if !policy.ProcessingFee.IsZero() {
+				return nil, fmt.Errorf("%w: remove the refund processing fee before changing the currency", ErrInvalidInput)
+			}
+			policy.ProcessingFee.Currency = strings.ToUpper(req.Currency)
+		}
+		currency = strings.ToUpper(req.Currency)
+	}
+	fee := tournament.EntryFee
+	if req.EntryFee != nil {
+		fee = *req.EntryFee
+	}
+	if fee, err = entryFee(fee, currency); err != nil {
+		return nil, err
+	}
+	if err := req.Pricing.Validate(currency); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	tournament.Currency = currency
+	tournament.EntryFee = fee
+	tournament.Pricing = req.Pricing
+	tournament.UpdatedAt = time.Now()
+
+	if err := s.repos.Tournament.Update(ctx, tournament); err != nil {
+		return nil, err
+	}
+	s.cache.Delete(fmt.Sprintf("tournament_%s", tournamentID))
+
+	return tournament, nil
+}
+
+// QuotePrice works out what registering now would cost the signed-in user, or a
+// guest when userID is empty, without using the code
+func (s *TournamentService) QuotePrice(ctx context.Context, tournamentID, userID, code string) (*models.PriceQuote, error) {
+	tournament, err := s.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	member, err := s.isMember(ctx, tournamentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.quote(ctx, tournament, member, code, time.Now())
+}
+
+// isMember reports whether a signed-in user is on the tournament's member list
+func (s *TournamentService) isMember(ctx context.Context, tournamentID, userID string) (bool, error) {
+	if userID == "" {
+		return false, nil
+	}
+	return s.repos.TournamentMember.IsMember(ctx, tournamentID, userID)
+}
+
+// quote prices a registration at a time: the tier and membership pick the base
+// fee and a discount code, if given, is taken off it
+func (s *TournamentService) quote(ctx context.Context, tournament *models.Tournament, member bool, code string, now time.Time) (*models.PriceQuote, error) {
+	fee, tier := tournament.Pricing.Price(tournament.EntryFee, member, now)
+	quote := &models.PriceQuote{
+		Tier:      tier,
+		Member:    member,
+		Base:      fee,
+		Discount:  models.NewMoney(0, fee.Currency),
+		AmountDue: fee,
+	}
+
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return quote, nil
+	}
+
+	discount, err := s.repos.DiscountCode.GetByCode(ctx, tournament.ID, code)
+	if err != nil {
+		return nil, err
+	}
+	if discount == nil {
+		return nil, fmt.Errorf("%w: unknown discount code", ErrInvalidInput)
+	}
+	if reason := discount.Unavailable(now); reason != "" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInput, reason)
+	}
+
+	quote.DiscountCode = discount.Code
+	quote.DiscountCodeID = &discount.ID
+	quote.Discount = discount.Discount(quote.Base)
+	quote.AmountDue = quote.Base.Sub(quote.Discount)
+	return quote, nil
+}
+
+// entryFee checks a standard entry fee against the tournament's currency. A fee
+// given without a currency is taken to be in it.
+func entryFee(fee models.Money, currency string) (models.Money, error) {
+	if !models.ValidCurrency(currency) {
+		return models.Money{}, fmt.Errorf("%w: unsupported currency %q", ErrInvalidInput, currency)
+	}
+	if fee.Currency != "" && fee.Currency != currency {
+		return models.Money{}, fmt.Errorf("%w: the entry fee must be in %s", ErrInvalidInput, currency)
+	}
+	if fee.IsNegative() {
+		return models.Money{}, fmt.Errorf("%w: the entry fee can't be negative", ErrInvalidInput)
+	}
+	return models.NewMoney(fee.Amount, currency), nil
+}
+
+// ListMembers lists the accounts that pay member prices for a tournament
+func (s *TournamentService) ListMembers(ctx context.Context, tournamentID string) ([]*models.TournamentMember, error) {
+	return s.repos.TournamentMember.ListByTournament(ctx, tournamentID)
+}
+
+// AddMember lists the account with an email as a member of a tournament.
+// Registrations already made keep the price they were quoted.
+func (s *TournamentService) AddMember(ctx context.Context, tournamentID, userID string, req MemberRequest) (*models.TournamentMember, error) {
+	user, err := s.repos.User.GetByEmail(ctx, strings.TrimSpace(req.Email))
+	if err != nil {
+		return nil, fmt.Errorf("%w: no account is registered with that email", ErrInvalidInput)
+	}
+
+	listed, err := s.repos.TournamentMember.IsMember(ctx, tournamentID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if listed {
+		return nil, fmt.Errorf("%w: %s is already a member", ErrInvalidInput, user.Email)
+	}
+
+	member := &models.TournamentMember{
+		TournamentID: tournamentID,
+		UserID:       user.ID,
+		Email:        user.Email,
+		FullName:     user.FullName,
+		AddedBy:      userID,
+		CreatedAt:    time.Now(),
+	}
+	if err := s.repos.TournamentMember.Add(ctx, member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// RemoveMember takes an account off a tournament's member list
+func (s *TournamentService) RemoveMember(ctx context.Context, tournamentID, memberID string) error {
+	removed, err := s.repos.TournamentMember.Remove(ctx, tournamentID, memberID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ListDiscountCodes lists a tournament's discount codes with their usage
+func (s *TournamentService) ListDiscountCodes(ctx context.Context, tournamentID string) ([]*models.DiscountCode, error) {
+	return s.repos.DiscountCode.ListByTournament(ctx, tournamentID)
+}
+
+// CreateDiscountCode adds a discount code to a tournament
+func (s *TournamentService) CreateDiscountCode(ctx context.Context, tournamentID, userID string, req DiscountCodeRequest) (*models.DiscountCode, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if !discountCodePattern.MatchString(code) {
+		return nil, fmt.Errorf("%w: codes are 3 to 50 letters, digits, dashes or underscores", ErrInvalidInput)
+	}
+	if req.Type == models.DiscountPercent && req.Value > 100 {
+		return nil, fmt.Errorf("%w: a percentage discount can be at most 100", ErrInvalidInput)
+	}
+
+	existing, err := s.repos.DiscountCode.GetByCode(ctx, tournamentID, code)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: discount code %s already exists", ErrInvalidInput, code)
+	}
+
+	now := time.Now()
+	discount := &models.DiscountCode{
+		ID:           utils.GenerateUUID(),
+		TournamentID: tournamentID,
+		Code:         code,
+		Type:         req.Type,
+		Value:        req.Value,
+		MaxUses:      req.MaxUses,
+		ExpiresAt:    req.ExpiresAt,
+		IsActive:     true,
+		CreatedBy:    userID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := s.repos.DiscountCode.Create(ctx, discount); err != nil {
+		return nil, err
+	}
+	return discount, nil
+}
+
+// UpdateDiscountCode changes a discount code's usage limit, expiry or active flag
+func (s *TournamentService) UpdateDiscountCode(ctx context.Context, tournamentID, codeID string, req DiscountCodeUpdate) (*models.DiscountCode, error) {
+	discount, err := s.repos.DiscountCode.GetByID(ctx, codeID)
+	if err != nil {
+		return nil, err
+	}
+	if discount == nil || discount.TournamentID != tournamentID {
+		return nil, ErrNotFound
+	}
+
+	discount.MaxUses = req.MaxUses
+	discount.ExpiresAt = req.ExpiresAt
+	discount.IsActive = *req.IsActive
+
+	if err := s.repos.DiscountCode.Update(ctx, discount); err != nil {
+		return nil, err
+	}
+	return discount, nil
+}
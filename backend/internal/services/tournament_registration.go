@@ -0,0 +1,169 @@
+// internal/services/tournament_registration.go
+// Participant registration at a quoted entry fee
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"tournament-planner/internal/events"
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/utils"
+)
+
+// RegisterParticipantRequest is a registration for a tournament
+type RegisterParticipantRequest struct {
+	Name             string                 `json:"name" binding:"required"`
+	Type             string                 `json:"type" binding:"required,oneof=individual team"`
+	ContactEmail     string                 `json:"contact_email" binding:"required,email"`
+	ContactPhone     string                 `json:"contact_phone"`
+	RegistrationData map[string]interface{} `json:"registration_data"`
+	DiscountCode     string                 `json:"discount_code"`
+	PayAtVenue       bool                   `json:"pay_at_venue"`
+}
+
+// Registration is a completed registration and what it costs
+type Registration struct {
+	Participant *models.Participant `json:"participant"`
+	Price       *models.PriceQuote  `json:"price"`
+}
+
+// Register signs a participant up for a tournament. Registrants signed in to an
+// account are linked to the participant, and pay member prices if the organizer
+// lists them as members; anyone else may register as a guest. The price, after
+// any discount code, is fixed on the registration; a registration that costs
+// nothing is marked waived. Where the tournament allows it, the fee can be left
+// to pay at the venue by the start of the first day.
+func (s *TournamentService) Register(ctx context.Context, tournamentID, userID string, req RegisterParticipantRequest) (*Registration, error) {
+	tournament, err := s.repos.Tournament.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if tournament.Status != models.StatusRegistrationOpen ||
+		(tournament.RegistrationDeadline != nil && now.After(*tournament.RegistrationDeadline)) {
+		return nil, ErrRegistrationClosed
+	}
+	if tournament.CurrentParticipants >= tournament.CapacityLimit {
+		return nil, ErrTournamentFull
+	}
+
+	if userID != "" {
+		registered, err := s.repos.TournamentParticipant.IsRegisteredUser(ctx, tournamentID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if registered {
+			return nil, ErrAlreadyRegistered
+		}
+	}
+
+	if req.PayAtVenue && !tournament.AllowOnsitePayment {
+		return nil, fmt.Errorf("%w: this tournament does not take payment at the venue", ErrInvalidInput)
+	}
+
+	member, err := s.isMember(ctx, tournamentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := s.quote(ctx, tournament, member, req.DiscountCode, now)
+	if err != nil {
+		return nil, err
+	}
+
+	participant := &models.Participant{
+		ID:           utils.GenerateUUID(),
+		Name:         req.Name,
+		Type:         models.ParticipantType(req.Type),
+		ContactEmail: &req.ContactEmail,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if userID != "" {
+		participant.UserID = &userID
+	}
+	if req.ContactPhone != "" {
+		participant.ContactPhone = &req.ContactPhone
+	}
+
+	status, method := models.PaymentPending, models.PaymentOnline
+	switch {
+	case quote.AmountDue.IsZero():
+		status = models.PaymentWaived
+	case req.PayAtVenue:
+		method = models.PaymentOnsite
+		dueAt := onsitePaymentDue(tournament)
+		participant.PaymentDueAt = &dueAt
+	}
+	participant.PaymentStatus = &status
+	participant.PaymentMethod = &method
+	participant.AmountDueCents = &quote.AmountDue.Amount
+	participant.RegistrationData = req.RegistrationData
+
+	tx, err := s.repos.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Take a place first, so concurrent registrations can't overfill the tournament
+	placed, err := s.repos.Tournament.IncrementParticipantsWithTx(tx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if !placed {
+		return nil, ErrTournamentFull
+	}
+
+	if quote.DiscountCodeID != nil {
+		redeemed, err := s.repos.DiscountCode.RedeemWithTx(tx, *quote.DiscountCodeID)
+		if err != nil {
+			return nil, err
+		}
+		if !redeemed {
+			return nil, fmt.Errorf("%w: discount code is no longer available", ErrInvalidInput)
+		}
+	}
+
+	if err := s.repos.Participant.CreateWithTx(tx, participant); err != nil {
+		return nil, fmt.Errorf("failed to create participant: %w", err)
+	}
+	if err := s.repos.TournamentParticipant.CreateWithTx(tx, tournamentID, participant, quote); err != nil {
+		return nil, fmt.Errorf("failed to register participant: %w", err)
+	}
+
+	payload := models.NotificationPayload{TournamentID: tournamentID, ParticipantIDs: []string{participant.ID}}
+	key := fmt.Sprintf("participant_registered:%s:%s", tournamentID, participant.ID)
+	if err := s.notification.EnqueueWithTx(tx, models.NotificationParticipantRegistered, key, payload); err != nil {
+		return nil, err
+	}
+
+	event := &events.ParticipantPayload{
+		TournamentID:  tournamentID,
+		ParticipantID: participant.ID,
+		Name:          participant.Name,
+	}
+	if err := s.webhook.EnqueueWithTx(tx, events.ParticipantRegistered, tournamentID, event); err != nil {
+		return nil, fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.cache.Delete(fmt.Sprintf("tournament_%s", tournamentID))
+
+	s.bus.Publish(events.ParticipantRegistered, tournamentID, event)
+
+	return &Registration{Participant: participant, Price: quote}, nil
+}
+
+// onsitePaymentDue is when a fee left to pay at the venue falls due: the start
+// of the tournament's first day in its timezone
+func onsitePaymentDue(tournament *models.Tournament) time.Time {
+	start := tournament.StartDate
+	return time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, tournamentLocation(tournament))
+}
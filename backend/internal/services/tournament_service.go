@@ -9,8 +9,10 @@ import (
 	"log"
 	"math"
 	"sort"
+	"strings"
 	"time"
 
+	"tournament-planner/internal/events"
 	"tournament-planner/internal/models"
 	"tournament-planner/internal/repositories"
 	"tournament-planner/internal/utils"
@@ -21,6 +23,9 @@ type TournamentService struct {
 	repos        *repositories.Container
 	cache        *CacheService
 	notification *NotificationService
+	payment      *PaymentService
+	webhook      *WebhookService
+	bus          *events.Bus
 	logger       *log.Logger
 }
 
@@ -29,35 +34,46 @@ func NewTournamentService(
 	repos *repositories.Container,
 	cache *CacheService,
 	notification *NotificationService,
+	payment *PaymentService,
+	webhook *WebhookService,
+	bus *events.Bus,
 	logger *log.Logger,
 ) *TournamentService {
 	return &TournamentService{
 		repos:        repos,
 		cache:        cache,
 		notification: notification,
+		payment:      payment,
+		webhook:      webhook,
+		bus:          bus,
 		logger:       logger,
 	}
 }
 
 // CreateTournamentRequest represents the data needed to create a tournament
 type CreateTournamentRequest struct {
-	Name                 string                  `json:"name" binding:"required,min=3,max=255"`
-	Description          string                  `json:"description" binding:"max=1000"`
-	SportID              *string                 `json:"sport_id"`
-	FormatType           models.TournamentFormat `json:"format_type" binding:"required"`
-	FormatConfig         *models.FormatConfig    `json:"format_config"`
-	StartDate            time.Time               `json:"start_date" binding:"required"`
-	EndDate              time.Time               `json:"end_date" binding:"required,gtfield=StartDate"`
-	Timezone             string                  `json:"timezone" binding:"required,timezone"`
-	MaxMatchesPerDay     int                     `json:"max_matches_per_day" binding:"required,min=1"`
-	OperationalHours     models.OperationalHours `json:"operational_hours" binding:"required"`
-	AvgMatchDuration     int                     `json:"avg_match_duration" binding:"required,min=5,max=480"`
-	BufferTime           int                     `json:"buffer_time" binding:"min=0,max=60"`
-	RegistrationDeadline *time.Time              `json:"registration_deadline"`
-	EntryFee             float64                 `json:"entry_fee" binding:"min=0"`
-	AllowOnsitePayment   bool                    `json:"allow_onsite_payment"`
-	CustomFields         []models.CustomField    `json:"custom_fields"`
-	Venues               []CreateVenueRequest    `json:"venues" binding:"required,min=1,dive"`
+	Name                  string                  `json:"name" binding:"required,min=3,max=255"`
+	Description           string                  `json:"description" binding:"max=1000"`
+	SportID               *string                 `json:"sport_id"`
+	FormatType            models.TournamentFormat `json:"format_type" binding:"required"`
+	FormatConfig          *models.FormatConfig    `json:"format_config"`
+	StartDate             time.Time               `json:"start_date" binding:"required"`
+	EndDate               time.Time               `json:"end_date" binding:"required,gtfield=StartDate"`
+	Timezone              string                  `json:"timezone" binding:"required,timezone"`
+	MaxMatchesPerDay      int                     `json:"max_matches_per_day" binding:"required,min=1"`
+	OperationalHours      models.OperationalHours `json:"operational_hours" binding:"required"`
+	AvgMatchDuration      int                     `json:"avg_match_duration" binding:"required,min=5,max=480"`
+	BufferTime            int                     `json:"buffer_time" binding:"min=0,max=60"`
+	RegistrationDeadline  *time.Time              `json:"registration_deadline"`
+	Currency              string                  `json:"currency"`
+	EntryFee              models.Money            `json:"entry_fee"`
+	Pricing               *models.EntryPricing    `json:"pricing"`
+	RefundPolicy          *models.RefundPolicy    `json:"refund_policy"`
+	AllowOnsitePayment    bool                    `json:"allow_onsite_payment"`
+	RequirePaidCheckIn    bool                    `json:"require_paid_checkin"`
+	CaptureEntryFeesLater bool                    `json:"capture_entry_fees_later"`
+	CustomFields          []models.CustomField    `json:"custom_fields"`
+	Venues                []CreateVenueRequest    `json:"venues" binding:"required,min=1,dive"`
 }
 
 // CreateVenueRequest represents venue creation data
@@ -79,32 +95,61 @@ func (s *TournamentService) Create(ctx context.Context, organizerID string, req
 		return nil, fmt.Errorf("tournament constraints too restrictive - minimum 2 participants required")
 	}
 
+	// Tiebreakers must be ones the standings engine understands
+	if req.FormatConfig != nil {
+		for _, tb := range req.FormatConfig.Tiebreakers {
+			if !tb.IsValid() {
+				return nil, fmt.Errorf("%w: unknown tiebreaker %q", ErrInvalidInput, tb)
+			}
+		}
+	}
+
+	currency := models.DefaultCurrency
+	if req.Currency != "" {
+		currency = strings.ToUpper(req.Currency)
+	}
+	fee, err := entryFee(req.EntryFee, currency)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Pricing.Validate(currency); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err := req.RefundPolicy.Validate(currency); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
 	// Step 3: Create tournament entity
 	tournament := &models.Tournament{
-		ID:                   utils.GenerateUUID(),
-		OrganizerID:          organizerID,
-		Name:                 req.Name,
-		Description:          req.Description,
-		SportID:              req.SportID,
-		FormatType:           req.FormatType,
-		FormatConfig:         req.FormatConfig,
-		StartDate:            req.StartDate,
-		EndDate:              req.EndDate,
-		Timezone:             req.Timezone,
-		MaxMatchesPerDay:     req.MaxMatchesPerDay,
-		OperationalHours:     req.OperationalHours,
-		AvgMatchDuration:     req.AvgMatchDuration,
-		BufferTime:           req.BufferTime,
-		RegistrationDeadline: req.RegistrationDeadline,
-		EntryFee:             req.EntryFee,
-		AllowOnsitePayment:   req.AllowOnsitePayment,
-		CapacityLimit:        capacity,
-		CurrentParticipants:  0,
-		Status:               models.StatusDraft,
-		IsPublic:             false,
-		CustomFields:         req.CustomFields,
-		CreatedAt:            time.Now(),
-		UpdatedAt:            time.Now(),
+		ID:                    utils.GenerateUUID(),
+		OrganizerID:           organizerID,
+		Name:                  req.Name,
+		Description:           req.Description,
+		SportID:               req.SportID,
+		FormatType:            req.FormatType,
+		FormatConfig:          req.FormatConfig,
+		StartDate:             req.StartDate,
+		EndDate:               req.EndDate,
+		Timezone:              req.Timezone,
+		MaxMatchesPerDay:      req.MaxMatchesPerDay,
+		OperationalHours:      req.OperationalHours,
+		AvgMatchDuration:      req.AvgMatchDuration,
+		BufferTime:            req.BufferTime,
+		RegistrationDeadline:  req.RegistrationDeadline,
+		Currency:              currency,
+		EntryFee:              fee,
+		Pricing:               req.Pricing,
+		RefundPolicy:          req.RefundPolicy,
+		AllowOnsitePayment:    req.AllowOnsitePayment,
+		RequirePaidCheckIn:    req.RequirePaidCheckIn,
+		CaptureEntryFeesLater: req.CaptureEntryFeesLater,
+		CapacityLimit:         capacity,
+		CurrentParticipants:   0,
+		Status:                models.StatusDraft,
+		IsPublic:              false,
+		CustomFields:          req.CustomFields,
+		CreatedAt:             time.Now(),
+		UpdatedAt:             time.Now(),
 	}
 
 	// Step 4: Begin transaction for atomicity
@@ -311,6 +356,15 @@ func (s *TournamentService) Update(ctx context.Context, id string, updates map[s
 	if description, ok := updates["description"].(string); ok {
 		tournament.Description = description
 	}
+	if allow, ok := updates["allow_onsite_payment"].(bool); ok {
+		tournament.AllowOnsitePayment = allow
+	}
+	if require, ok := updates["require_paid_checkin"].(bool); ok {
+		tournament.RequirePaidCheckIn = require
+	}
+	if later, ok := updates["capture_entry_fees_later"].(bool); ok {
+		tournament.CaptureEntryFeesLater = later
+	}
 	// ... other fields
 
 	tournament.UpdatedAt = time.Now()
@@ -356,15 +410,93 @@ func (s *TournamentService) Publish(ctx context.Context, id string) error {
 	tournament.Status = models.StatusRegistrationOpen
 	tournament.IsPublic = true
 
-	if err := s.repos.Tournament.Update(ctx, tournament); err != nil {
+	tx, err := s.repos.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := s.repos.Tournament.PublishWithTx(tx, id); err != nil {
+		return err
+	}
+
+	// Queue notifications and webhook deliveries with the change; they are sent in the background
+	payload := models.NotificationPayload{TournamentID: id}
+	if err := s.notification.EnqueueWithTx(tx, models.NotificationTournamentPublished, "tournament_published:"+id, payload); err != nil {
+		return fmt.Errorf("failed to queue publish notification: %w", err)
+	}
+	event := &events.TournamentPayload{
+		TournamentID: id,
+		Name:         tournament.Name,
+		Status:       tournament.Status,
+	}
+	if err := s.webhook.EnqueueWithTx(tx, events.TournamentPublished, id, event); err != nil {
+		return fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
 		return err
 	}
 
 	// Clear cache
 	s.cache.Delete(fmt.Sprintf("tournament_%s", id))
 
-	// Send notifications
-	go s.notification.NotifyTournamentPublished(tournament)
+	s.bus.Publish(events.TournamentPublished, id, event)
+
+	return nil
+}
+
+// CheckInParticipant marks a registered participant as present at the venue
+func (s *TournamentService) CheckInParticipant(ctx context.Context, tournamentID, participantID string) error {
+	participants, err := s.repos.TournamentParticipant.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch participants: %w", err)
+	}
+
+	var participant *models.Participant
+	for _, p := range participants {
+		if p.ID == participantID {
+			participant = p
+			break
+		}
+	}
+	if participant == nil {
+		return ErrNotFound
+	}
+
+	// Tournaments can turn away participants whose entry fee is still outstanding
+	tournament, err := s.GetByID(ctx, tournamentID)
+	if err != nil {
+		return err
+	}
+	if tournament.RequirePaidCheckIn && participant.PaymentStatus != nil && *participant.PaymentStatus == models.PaymentPending {
+		return ErrPaymentRequired
+	}
+
+	tx, err := s.repos.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := s.repos.TournamentParticipant.CheckInWithTx(tx, tournamentID, participantID); err != nil {
+		return err
+	}
+
+	event := &events.ParticipantPayload{
+		TournamentID:  tournamentID,
+		ParticipantID: participantID,
+		Name:          participant.Name,
+	}
+	if err := s.webhook.EnqueueWithTx(tx, events.ParticipantCheckedIn, tournamentID, event); err != nil {
+		return fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	s.bus.Publish(events.ParticipantCheckedIn, tournamentID, event)
 
 	return nil
 }
@@ -379,6 +511,47 @@ func (s *TournamentService) IsOwner(ctx context.Context, tournamentID, userID st
 	return tournament.OrganizerID == userID, nil
 }
 
+// IsStaff checks if a user may work a tournament's venue: the organizer or one
+// of their referees
+func (s *TournamentService) IsStaff(ctx context.Context, tournamentID, userID string) (bool, error) {
+	tournament, err := s.repos.Tournament.GetByID(ctx, tournamentID)
+	if err != nil {
+		return false, err
+	}
+	if tournament.OrganizerID == userID {
+		return true, nil
+	}
+
+	return s.repos.Tournament.IsStaff(ctx, tournamentID, userID)
+}
+
+// CanView checks if a user may follow a tournament's live updates. Public tournaments
+// are open to everyone; private ones only to the organizer, their staff, registered
+// participants and admins. An empty userID is an anonymous viewer.
+func (s *TournamentService) CanView(ctx context.Context, tournamentID, userID, role string) (bool, error) {
+	tournament, err := s.GetByID(ctx, tournamentID)
+	if err != nil {
+		return false, ErrNotFound
+	}
+
+	if tournament.IsPublic {
+		return true, nil
+	}
+	if userID == "" {
+		return false, nil
+	}
+	if tournament.OrganizerID == userID || role == string(models.RoleAdmin) {
+		return true, nil
+	}
+
+	staff, err := s.repos.Tournament.IsStaff(ctx, tournamentID, userID)
+	if err != nil || staff {
+		return staff, err
+	}
+
+	return s.repos.TournamentParticipant.IsRegisteredUser(ctx, tournamentID, userID)
+}
+
 // SeedingData represents participant seeding information
 type SeedingData struct {
 	ParticipantID string `json:"participant_id"`
@@ -460,6 +633,35 @@ func (s *TournamentService) GenerateFixtures(ctx context.Context, tournamentID s
 		return nil, fmt.Errorf("failed to update tournament status: %w", err)
 	}
 
+	// Queue notifications with the fixtures so they survive a crash after commit
+	participantIDs := make([]string, 0, len(participants))
+	for _, p := range participants {
+		participantIDs = append(participantIDs, p.ID)
+	}
+	payload := models.NotificationPayload{TournamentID: tournamentID, ParticipantIDs: participantIDs}
+	// Fixtures are generated once per tournament, so its ID alone makes a retry a no-op
+	key := fmt.Sprintf("fixtures_generated:%s", tournamentID)
+	if err := s.notification.EnqueueWithTx(tx, models.NotificationFixturesGenerated, key, payload); err != nil {
+		return nil, fmt.Errorf("failed to queue fixtures notification: %w", err)
+	}
+
+	rounds := 0
+	for _, fixture := range fixtures {
+		rounds = max(rounds, fixture.RoundNumber)
+	}
+	generated := &events.FixturesPayload{
+		TournamentID: tournamentID,
+		MatchCount:   len(fixtures),
+		Rounds:       rounds,
+	}
+	bracket := &events.BracketPayload{TournamentID: tournamentID}
+	if err := s.webhook.EnqueueWithTx(tx, events.FixturesGenerated, tournamentID, generated); err != nil {
+		return nil, fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+	if err := s.webhook.EnqueueWithTx(tx, events.BracketUpdated, tournamentID, bracket); err != nil {
+		return nil, fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+
 	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
@@ -467,9 +669,10 @@ func (s *TournamentService) GenerateFixtures(ctx context.Context, tournamentID s
 	// Clear caches
 	s.cache.Delete(fmt.Sprintf("tournament_%s", tournamentID))
 	s.cache.Delete(fmt.Sprintf("tournament_bracket_%s", tournamentID))
+	s.cache.Delete(fmt.Sprintf("tournament_standings_%s", tournamentID))
 
-	// Send notifications
-	go s.notification.NotifyFixturesGenerated(tournamentID, participants)
+	s.bus.Publish(events.FixturesGenerated, tournamentID, generated)
+	s.bus.Publish(events.BracketUpdated, tournamentID, bracket)
 
 	return fixtures, nil
 }
@@ -0,0 +1,168 @@
+// internal/services/tournament_withdrawal.go
+// Withdrawals, removals and cancellation, with entry fee refunds
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"tournament-planner/internal/events"
+	"tournament-planner/internal/models"
+)
+
+// Withdrawal is a participant leaving a tournament and what comes back of their
+// entry fee. The refund is queued with the withdrawal and requested in the
+// background, with retries.
+type Withdrawal struct {
+	ParticipantID string                 `json:"participant_id"`
+	Refund        *models.RefundDecision `json:"refund,omitempty"`
+}
+
+// CancelTournamentRequest is the reason given for cancelling a tournament
+type CancelTournamentRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// UpdateRefundPolicy sets the refund policy for withdrawals. A nil policy
+// refunds withdrawals in full.
+func (s *TournamentService) UpdateRefundPolicy(ctx context.Context, tournamentID string, policy *models.RefundPolicy) (*models.Tournament, error) {
+	tournament, err := s.repos.Tournament.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := policy.Validate(tournament.Currency); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	tournament.RefundPolicy = policy
+	tournament.UpdatedAt = time.Now()
+
+	if err := s.repos.Tournament.Update(ctx, tournament); err != nil {
+		return nil, err
+	}
+	s.cache.Delete(fmt.Sprintf("tournament_%s", tournamentID))
+
+	return tournament, nil
+}
+
+// Withdraw takes the signed-in user's participant out of a tournament
+func (s *TournamentService) Withdraw(ctx context.Context, tournamentID, userID string) (*Withdrawal, error) {
+	participant, err := s.repos.TournamentParticipant.GetByUser(ctx, tournamentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if participant == nil {
+		return nil, ErrNotFound
+	}
+	return s.removeParticipant(ctx, tournamentID, participant.ID, userID)
+}
+
+// RemoveParticipant takes a participant out of a tournament on the organizer's behalf
+func (s *TournamentService) RemoveParticipant(ctx context.Context, tournamentID, participantID, userID string) (*Withdrawal, error) {
+	return s.removeParticipant(ctx, tournamentID, participantID, userID)
+}
+
+// removeParticipant frees the participant's place and queues the refund of
+// their entry fee under the tournament's refund policy. Participants can only
+// leave before the tournament starts.
+func (s *TournamentService) removeParticipant(ctx context.Context, tournamentID, participantID, userID string) (*Withdrawal, error) {
+	tournament, err := s.repos.Tournament.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	switch tournament.Status {
+	case models.StatusInProgress, models.StatusCompleted, models.StatusCancelled:
+		return nil, fmt.Errorf("%w: participants can only leave before the tournament starts", ErrInvalidInput)
+	}
+
+	tx, err := s.repos.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	removed, err := s.repos.TournamentParticipant.DeleteWithTx(tx, tournamentID, participantID)
+	if err != nil {
+		return nil, err
+	}
+	if !removed {
+		return nil, ErrNotFound
+	}
+	if err := s.repos.Tournament.DecrementParticipantsWithTx(tx, tournamentID); err != nil {
+		return nil, err
+	}
+
+	withdrawal := &Withdrawal{ParticipantID: participantID}
+	withdrawal.Refund, err = s.payment.QueueWithdrawalRefundWithTx(ctx, tx, tournament, participantID, userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue refund: %w", err)
+	}
+
+	event := &events.ParticipantPayload{
+		TournamentID:  tournamentID,
+		ParticipantID: participantID,
+	}
+	if err := s.webhook.EnqueueWithTx(tx, events.ParticipantWithdrawn, tournamentID, event); err != nil {
+		return nil, fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.cache.Delete(fmt.Sprintf("tournament_%s", tournamentID))
+
+	s.bus.Publish(events.ParticipantWithdrawn, tournamentID, event)
+
+	return withdrawal, nil
+}
+
+// Cancel cancels a tournament, queues a full refund of every settled entry fee
+// and cancels the payments still open with the provider
+func (s *TournamentService) Cancel(ctx context.Context, tournamentID, userID string, req CancelTournamentRequest) (*models.RefundBatch, error) {
+	tournament, err := s.repos.Tournament.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if tournament.Status == models.StatusCompleted || tournament.Status == models.StatusCancelled {
+		return nil, fmt.Errorf("%w: tournament is already %s", ErrInvalidInput, tournament.Status)
+	}
+
+	tx, err := s.repos.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := s.repos.Tournament.UpdateStatusWithTx(tx, tournamentID, models.StatusCancelled); err != nil {
+		return nil, err
+	}
+	batch, err := s.payment.QueueRefundBatchWithTx(ctx, tx, tournamentID, userID, req.Reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue refunds: %w", err)
+	}
+
+	event := &events.TournamentPayload{
+		TournamentID: tournamentID,
+		Name:         tournament.Name,
+		Status:       models.StatusCancelled,
+	}
+	if err := s.webhook.EnqueueWithTx(tx, events.TournamentCancelled, tournamentID, event); err != nil {
+		return nil, fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.cache.Delete(fmt.Sprintf("tournament_%s", tournamentID))
+
+	// Nobody should pay for a cancelled tournament; the provider is only called
+	// once the cancellation is committed
+	s.payment.CancelOpenPayments(ctx, tournamentID)
+
+	s.bus.Publish(events.TournamentCancelled, tournamentID, event)
+
+	return batch, nil
+}
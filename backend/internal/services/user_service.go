@@ -9,6 +9,7 @@ import (
 	"log"
 
 	"tournament-planner/internal/models"
+	"tournament-planner/internal/notifications"
 	"tournament-planner/internal/repositories"
 )
 
@@ -83,18 +84,28 @@ func (s *UserService) GetPreferences(ctx context.Context, userID string) (map[st
 	return prefs, nil
 }
 
-// UpdatePreferences updates user preferences
+// UpdatePreferences updates user preferences. Notification settings and the
+// timezone are validated, since notification delivery depends on them.
 func (s *UserService) UpdatePreferences(ctx context.Context, userID string, preferences map[string]interface{}) error {
+	if _, err := notifications.ParsePreferences(preferences); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
 	return s.preferencesRepo.Set(ctx, userID, preferences)
 }
 
 // getDefaultPreferences returns default user preferences
 func (s *UserService) getDefaultPreferences() map[string]interface{} {
 	return map[string]interface{}{
-		"notifications": map[string]bool{
-			"email": true,
-			"push":  true,
-			"sms":   false,
+		"notifications": map[string]interface{}{
+			"email":       true,
+			"push":        true,
+			"sms":         false,
+			"in_app":      true,
+			"quiet_hours": nil,
+			"digest": map[string]interface{}{
+				"enabled": false,
+				"time":    "18:00",
+			},
 		},
 		"theme":    "light",
 		"language": "en",
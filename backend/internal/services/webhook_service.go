@@ -0,0 +1,399 @@
+// internal/services/webhook_service.go
+// Organizer webhooks: registration, event fan-out, signed delivery with retries
+
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"sync"
+	"time"
+
+	"tournament-planner/internal/config"
+	"tournament-planner/internal/events"
+	"tournament-planner/internal/models"
+	"tournament-planner/internal/repositories"
+	"tournament-planner/internal/utils"
+	"tournament-planner/internal/webhooks"
+)
+
+const (
+	// WebhookTimeout bounds a single delivery request
+	WebhookTimeout = 10 * time.Second
+
+	// WebhookTestEvent is the event type of deliveries sent with "send test event"
+	WebhookTestEvent = "webhook_test"
+
+	// webhookConcurrency caps the deliveries in flight per instance, so one
+	// slow receiver holds up a single slot rather than the whole batch
+	webhookConcurrency = 10
+)
+
+// webhookEventTypes are the events organizers can subscribe to
+var webhookEventTypes = map[string]bool{
+	string(events.TournamentPublished):   true,
+	string(events.TournamentCancelled):   true,
+	string(events.FixturesGenerated):     true,
+	string(events.MatchScheduled):        true,
+	string(events.MatchStarted):          true,
+	string(events.MatchScoreUpdated):     true,
+	string(events.MatchCompleted):        true,
+	string(events.BracketUpdated):        true,
+	string(events.ParticipantCheckedIn):  true,
+	string(events.ParticipantRegistered): true,
+	string(events.ParticipantWithdrawn):  true,
+}
+
+// WebhookRequest registers or changes a webhook. An empty event type list
+// subscribes to every event.
+type WebhookRequest struct {
+	URL        string   `json:"url" binding:"required"`
+	EventTypes []string `json:"event_types"`
+	IsActive   *bool    `json:"is_active"`
+}
+
+// webhookEnvelope is the JSON body of every delivery. The ID identifies the
+// event and stays the same across retries, so receivers can deduplicate.
+type webhookEnvelope struct {
+	ID           string      `json:"id"`
+	Type         string      `json:"type"`
+	TournamentID string      `json:"tournament_id"`
+	OccurredAt   time.Time   `json:"occurred_at"`
+	Data         interface{} `json:"data"`
+}
+
+// WebhookService manages organizer webhooks and delivers tournament events to them.
+// Deliveries are written to the delivery log in the transaction that made the change,
+// like the notification outbox, and worked off with leases and retried with backoff.
+type WebhookService struct {
+	repos  *repositories.Container
+	client *webhooks.Client
+	config *config.Config
+	logger *log.Logger
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(repos *repositories.Container, client *webhooks.Client, config *config.Config, logger *log.Logger) *WebhookService {
+	return &WebhookService{
+		repos:  repos,
+		client: client,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Create registers a webhook and generates its signing secret
+func (s *WebhookService) Create(ctx context.Context, tournamentID, userID string, req WebhookRequest) (*models.Webhook, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+
+	secret, err := webhooks.NewSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	webhook := &models.Webhook{
+		ID:           utils.GenerateUUID(),
+		TournamentID: tournamentID,
+		URL:          req.URL,
+		Secret:       secret,
+		EventTypes:   req.EventTypes,
+		IsActive:     req.IsActive == nil || *req.IsActive,
+		CreatedBy:    userID,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+	if err := s.repos.Webhook.Create(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// List retrieves the webhooks of a tournament
+func (s *WebhookService) List(ctx context.Context, tournamentID string) ([]*models.Webhook, error) {
+	return s.repos.Webhook.ListByTournament(ctx, tournamentID)
+}
+
+// Update changes a webhook's URL, event filter or active flag. The secret stays the same.
+func (s *WebhookService) Update(ctx context.Context, tournamentID, webhookID string, req WebhookRequest) (*models.Webhook, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.get(ctx, tournamentID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.URL = req.URL
+	webhook.EventTypes = req.EventTypes
+	if req.IsActive != nil {
+		webhook.IsActive = *req.IsActive
+	}
+	if err := s.repos.Webhook.Update(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// Delete removes a webhook together with its delivery log
+func (s *WebhookService) Delete(ctx context.Context, tournamentID, webhookID string) error {
+	if _, err := s.get(ctx, tournamentID, webhookID); err != nil {
+		return err
+	}
+	return s.repos.Webhook.Delete(ctx, webhookID)
+}
+
+// ListDeliveries retrieves a webhook's delivery log, newest first
+func (s *WebhookService) ListDeliveries(ctx context.Context, tournamentID, webhookID string, limit, offset int) ([]*models.WebhookDelivery, error) {
+	if _, err := s.get(ctx, tournamentID, webhookID); err != nil {
+		return nil, err
+	}
+	return s.repos.Webhook.ListDeliveries(ctx, webhookID, limit, offset)
+}
+
+// SendTest delivers a test event to a webhook straight away, whether or not it is
+// active or subscribed to the test event, and returns the logged delivery.
+// Test deliveries are not retried.
+func (s *WebhookService) SendTest(ctx context.Context, tournamentID, webhookID string) (*models.WebhookDelivery, error) {
+	webhook, err := s.get(ctx, tournamentID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.newDelivery(webhook, &events.Event{
+		Type:         WebhookTestEvent,
+		TournamentID: tournamentID,
+		Payload:      map[string]string{"message": "This is a test event from Tournament Planner"},
+		OccurredAt:   time.Now(),
+	}, utils.GenerateUUID())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repos.Webhook.CreateDelivery(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("failed to log test delivery: %w", err)
+	}
+
+	s.attempt(ctx, webhook, delivery, true)
+	return delivery, nil
+}
+
+// EnqueueWithTx queues deliveries of an event to the tournament's active webhooks
+// subscribed to it, within the transaction that made the change it announces.
+// Every delivery of the event carries the same event ID.
+func (s *WebhookService) EnqueueWithTx(tx *sql.Tx, eventType events.Type, tournamentID string, payload interface{}) error {
+	eventID, body, err := s.newEvent(eventType, tournamentID, payload)
+	if err != nil {
+		return err
+	}
+	return s.repos.Webhook.CreateEventDeliveriesWithTx(tx, tournamentID, eventID, string(eventType), body)
+}
+
+// Enqueue queues deliveries of an event for changes made outside a transaction
+func (s *WebhookService) Enqueue(ctx context.Context, eventType events.Type, tournamentID string, payload interface{}) error {
+	eventID, body, err := s.newEvent(eventType, tournamentID, payload)
+	if err != nil {
+		return err
+	}
+	return s.repos.Webhook.CreateEventDeliveries(ctx, tournamentID, eventID, string(eventType), body)
+}
+
+// newEvent renders an event's delivery body under a new event ID
+func (s *WebhookService) newEvent(eventType events.Type, tournamentID string, payload interface{}) (string, []byte, error) {
+	eventID := utils.GenerateUUID()
+	body, err := encodeWebhookEvent(eventID, &events.Event{
+		Type:         eventType,
+		TournamentID: tournamentID,
+		Payload:      payload,
+		OccurredAt:   time.Now(),
+	})
+	return eventID, body, err
+}
+
+// Run works off due deliveries until the context is cancelled
+func (s *WebhookService) Run(ctx context.Context) {
+	ticker := time.NewTicker(dispatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.deliverDue(ctx)
+		}
+	}
+}
+
+// deliverDue claims and attempts one batch of due deliveries
+func (s *WebhookService) deliverDue(ctx context.Context) {
+	deliveries, err := s.repos.Webhook.ClaimDueDeliveries(ctx, utils.GenerateUUID(), dispatchBatchSize, dispatchLease)
+	if err != nil {
+		s.logger.Printf("Failed to claim webhook deliveries: %v", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, webhookConcurrency)
+	for _, delivery := range deliveries {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(delivery *models.WebhookDelivery) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			s.deliver(ctx, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+}
+
+// deliver attempts a claimed delivery, or dead-letters it when its webhook is gone or inactive
+func (s *WebhookService) deliver(ctx context.Context, delivery *models.WebhookDelivery) {
+	webhook, err := s.repos.Webhook.GetByID(ctx, delivery.WebhookID)
+	if err != nil {
+		s.logger.Printf("Failed to load webhook %s: %v", delivery.WebhookID, err)
+		return
+	}
+	if webhook == nil || !webhook.IsActive {
+		reason := "webhook was deactivated"
+		delivery.Status = models.OutboxDead
+		delivery.LastError = &reason
+		if err := s.repos.Webhook.RecordAttempt(ctx, delivery, 0); err != nil {
+			s.logger.Printf("Failed to record webhook delivery %s: %v", delivery.ID, err)
+		}
+		return
+	}
+
+	s.attempt(ctx, webhook, delivery, false)
+}
+
+// attempt sends a delivery once and records the outcome. Failures are retried
+// with backoff until they run out of attempts, unless final is set.
+func (s *WebhookService) attempt(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery, final bool) {
+	sendCtx, cancel := context.WithTimeout(ctx, WebhookTimeout)
+	result, err := s.client.Send(sendCtx, &webhooks.Request{
+		URL:        webhook.URL,
+		Secret:     webhook.Secret,
+		DeliveryID: delivery.ID,
+		EventType:  delivery.EventType,
+		Body:       delivery.Payload,
+	})
+	cancel()
+
+	delivery.Attempts++
+	durationMs := int(result.Duration.Milliseconds())
+	delivery.DurationMs = &durationMs
+	delivery.ResponseStatus = nil
+	if result.StatusCode != 0 {
+		delivery.ResponseStatus = &result.StatusCode
+	}
+
+	var delay time.Duration
+	if err == nil {
+		delivery.Status = models.OutboxDelivered
+		delivery.LastError = nil
+		now := time.Now()
+		delivery.DeliveredAt = &now
+	} else {
+		message := err.Error()
+		delivery.LastError = &message
+		delivery.Status = models.OutboxPending
+		delay = retryDelay(delivery.Attempts)
+		if final || delivery.Attempts >= maxDeliveryAttempts {
+			delivery.Status = models.OutboxDead
+		}
+		s.logger.Printf("Webhook delivery %s to %s attempt %d failed: %v", delivery.ID, webhook.URL, delivery.Attempts, err)
+	}
+	delivery.NextAttemptAt = time.Now().Add(delay)
+
+	if err := s.repos.Webhook.RecordAttempt(ctx, delivery, delay); err != nil {
+		s.logger.Printf("Failed to record webhook delivery %s: %v", delivery.ID, err)
+	}
+}
+
+// newDelivery renders an event into a pending delivery for a webhook
+func (s *WebhookService) newDelivery(webhook *models.Webhook, event *events.Event, eventID string) (*models.WebhookDelivery, error) {
+	body, err := encodeWebhookEvent(eventID, event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WebhookDelivery{
+		ID:        utils.GenerateUUID(),
+		WebhookID: webhook.ID,
+		EventID:   eventID,
+		EventType: string(event.Type),
+		Payload:   body,
+		Status:    models.OutboxPending,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// encodeWebhookEvent renders the JSON body every delivery of an event is sent with
+func encodeWebhookEvent(eventID string, event *events.Event) ([]byte, error) {
+	body, err := json.Marshal(&webhookEnvelope{
+		ID:           eventID,
+		Type:         string(event.Type),
+		TournamentID: event.TournamentID,
+		OccurredAt:   event.OccurredAt.UTC(),
+		Data:         event.Payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event: %w", event.Type, err)
+	}
+	return body, nil
+}
+
+// get loads a webhook of a tournament
+func (s *WebhookService) get(ctx context.Context, tournamentID, webhookID string) (*models.Webhook, error) {
+	webhook, err := s.repos.Webhook.GetByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if webhook == nil || webhook.TournamentID != tournamentID {
+		return nil, ErrNotFound
+	}
+	return webhook, nil
+}
+
+// validate checks a webhook's URL and event filter. Plain HTTP endpoints are
+// only accepted outside production, and the host must resolve to public addresses.
+// The delivery client checks the address again when it connects.
+func (s *WebhookService) validate(ctx context.Context, req WebhookRequest) error {
+	endpoint, err := url.Parse(req.URL)
+	if err != nil || endpoint.Hostname() == "" {
+		return fmt.Errorf("%w: url must be an absolute URL", ErrInvalidInput)
+	}
+	switch endpoint.Scheme {
+	case "https":
+	case "http":
+		if s.config.Environment == "production" {
+			return fmt.Errorf("%w: url must use https", ErrInvalidInput)
+		}
+	default:
+		return fmt.Errorf("%w: url must use https", ErrInvalidInput)
+	}
+	if err := webhooks.CheckHost(ctx, endpoint.Hostname()); err != nil {
+		if errors.Is(err, webhooks.ErrNonPublicAddress) {
+			return fmt.Errorf("%w: url must point to a public address", ErrInvalidInput)
+		}
+		return fmt.Errorf("%w: url host could not be resolved", ErrInvalidInput)
+	}
+
+	for _, eventType := range req.EventTypes {
+		if !webhookEventTypes[eventType] {
+			return fmt.Errorf("%w: unknown event type %q", ErrInvalidInput, eventType)
+		}
+	}
+	return nil
+}
@@ -35,6 +35,16 @@ func GenerateJWT(userID, role, secret string, expiration time.Duration) (string,
 
 // ValidateJWT validates a JWT token and returns the claims
 func ValidateJWT(tokenString, secret string) (string, string, error) {
+	claims, err := ParseJWT(tokenString, secret)
+	if err != nil {
+		return "", "", err
+	}
+
+	return claims.UserID, claims.Role, nil
+}
+
+// ParseJWT validates a JWT token and returns all of its claims, including expiry
+func ParseJWT(tokenString, secret string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
@@ -43,12 +53,12 @@ func ValidateJWT(tokenString, secret string) (string, string, error) {
 	})
 
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
 
 	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		return claims.UserID, claims.Role, nil
+		return claims, nil
 	}
 
-	return "", "", fmt.Errorf("invalid token")
+	return nil, fmt.Errorf("invalid token")
 }
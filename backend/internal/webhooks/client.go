@@ -0,0 +1,68 @@
+// internal/webhooks/client.go
+// HTTP delivery of signed webhook payloads
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Request is one delivery attempt of a payload to an endpoint
+type Request struct {
+	URL        string
+	Secret     string
+	DeliveryID string
+	EventType  string
+	Body       []byte
+}
+
+// Result is what the receiver answered. StatusCode is zero when no response arrived.
+// The response body is never read, so nothing a receiver returns ends up in the log.
+type Result struct {
+	StatusCode int
+	Duration   time.Duration
+}
+
+// Client posts signed payloads to webhook endpoints
+type Client struct {
+	http *http.Client
+	now  func() time.Time
+}
+
+// NewClient creates a client that sends through the given HTTP client. Tests can
+// pass an httptest server's client; production passes one from NewHTTPClient.
+func NewClient(httpClient *http.Client) *Client {
+	return &Client{http: httpClient, now: time.Now}
+}
+
+// Send signs and posts a payload. Any response other than 2xx is an error,
+// returned together with the result so it can be logged.
+func (c *Client) Send(ctx context.Context, req *Request) (*Result, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return &Result{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "TournamentPlanner-Webhooks/1.0")
+	httpReq.Header.Set(EventHeader, req.EventType)
+	httpReq.Header.Set(DeliveryHeader, req.DeliveryID)
+	httpReq.Header.Set(SignatureHeader, Sign(req.Secret, c.now(), req.Body))
+
+	start := time.Now()
+	resp, err := c.http.Do(httpReq)
+	result := &Result{Duration: time.Since(start)}
+	if err != nil {
+		return result, err
+	}
+	resp.Body.Close()
+	result.StatusCode = resp.StatusCode
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return result, fmt.Errorf("receiver responded %d", resp.StatusCode)
+	}
+	return result, nil
+}
@@ -0,0 +1,104 @@
+// internal/webhooks/client_test.go
+// Delivery of signed payloads to a test receiver
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientSend(t *testing.T) {
+	const secret = "whsec_test"
+	body := []byte(`{"event":"bracket_updated"}`)
+
+	var received *http.Request
+	var receivedBody []byte
+	status := http.StatusNoContent
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer receiver.Close()
+
+	client := NewClient(receiver.Client())
+	sentAt := time.Unix(1700000000, 0)
+	client.now = func() time.Time { return sentAt }
+
+	req := &Request{
+		URL:        receiver.URL,
+		Secret:     secret,
+		DeliveryID: "delivery-1",
+		EventType:  "bracket_updated",
+		Body:       body,
+	}
+	result, err := client.Send(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if result.StatusCode != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", result.StatusCode, http.StatusNoContent)
+	}
+
+	if received.Method != http.MethodPost {
+		t.Errorf("method = %s, want POST", received.Method)
+	}
+	for header, want := range map[string]string{
+		"Content-Type":  "application/json",
+		EventHeader:     "bracket_updated",
+		DeliveryHeader:  "delivery-1",
+		SignatureHeader: Sign(secret, sentAt, body),
+	} {
+		if got := received.Header.Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+	if err := Verify(secret, received.Header.Get(SignatureHeader), receivedBody, 0, sentAt); err != nil {
+		t.Errorf("receiver could not verify the delivery: %v", err)
+	}
+
+	for _, code := range []int{http.StatusMovedPermanently, http.StatusBadRequest, http.StatusInternalServerError} {
+		status = code
+		result, err := client.Send(context.Background(), req)
+		if err == nil {
+			t.Errorf("Send succeeded on a %d response", code)
+		}
+		if result.StatusCode != code {
+			t.Errorf("status = %d, want %d", result.StatusCode, code)
+		}
+	}
+}
+
+func TestClientSendUnreachable(t *testing.T) {
+	receiver := httptest.NewServer(http.NotFoundHandler())
+	url := receiver.URL
+	receiver.Close()
+
+	result, err := NewClient(http.DefaultClient).Send(context.Background(), &Request{URL: url, Secret: "whsec_test"})
+	if err == nil {
+		t.Fatalf("Send to a closed receiver succeeded")
+	}
+	if result.StatusCode != 0 {
+		t.Errorf("status = %d, want 0 without a response", result.StatusCode)
+	}
+}
+
+func TestHTTPClientRefusesLoopback(t *testing.T) {
+	var reached bool
+	receiver := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { reached = true }))
+	defer receiver.Close()
+
+	_, err := NewClient(NewHTTPClient(time.Second)).Send(context.Background(), &Request{URL: receiver.URL, Secret: "whsec_test"})
+	if !errors.Is(err, ErrNonPublicAddress) {
+		t.Errorf("Send to loopback = %v, want ErrNonPublicAddress", err)
+	}
+	if reached {
+		t.Errorf("the loopback receiver was reached")
+	}
+}
@@ -0,0 +1,70 @@
+// internal/webhooks/signature_test.go
+// Signing and verification of webhook payloads
+
+package webhooks
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	const secret = "whsec_test"
+	body := []byte(`{"event":"match_completed"}`)
+	sentAt := time.Unix(1700000000, 0)
+	header := Sign(secret, sentAt, body)
+
+	if !strings.HasPrefix(header, "t=1700000000,v1=") {
+		t.Fatalf("header = %q, want the timestamp first", header)
+	}
+
+	tests := []struct {
+		name      string
+		secret    string
+		header    string
+		body      []byte
+		tolerance time.Duration
+		now       time.Time
+		want      error
+	}{
+		{"valid", secret, header, body, 5 * time.Minute, sentAt.Add(time.Minute), nil},
+		{"at tolerance", secret, header, body, 5 * time.Minute, sentAt.Add(5 * time.Minute), nil},
+		{"too old", secret, header, body, 5 * time.Minute, sentAt.Add(5*time.Minute + time.Second), ErrSignatureExpired},
+		{"from the future", secret, header, body, 5 * time.Minute, sentAt.Add(-6 * time.Minute), ErrSignatureExpired},
+		{"no tolerance", secret, header, body, 0, sentAt.Add(24 * time.Hour), nil},
+		{"tampered body", secret, header, []byte(`{"event":"match_cancelled"}`), 5 * time.Minute, sentAt, ErrSignatureMismatch},
+		{"wrong secret", "whsec_other", header, body, 5 * time.Minute, sentAt, ErrSignatureMismatch},
+		{"rotated secret", secret, Sign("whsec_old", sentAt, body) + "," + strings.SplitN(header, ",", 2)[1], body, 5 * time.Minute, sentAt, nil},
+		{"no timestamp", secret, strings.SplitN(header, ",", 2)[1], body, 5 * time.Minute, sentAt, ErrMalformedSignature},
+		{"no signature", secret, "t=1700000000", body, 5 * time.Minute, sentAt, ErrMalformedSignature},
+		{"bad hex", secret, "t=1700000000,v1=zz", body, 5 * time.Minute, sentAt, ErrMalformedSignature},
+		{"bad timestamp", secret, "t=soon,v1=00", body, 5 * time.Minute, sentAt, ErrMalformedSignature},
+		{"empty", secret, "", body, 5 * time.Minute, sentAt, ErrMalformedSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.header, tt.body, tt.tolerance, tt.now)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Verify() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSecret(t *testing.T) {
+	a, err := NewSecret()
+	if err != nil {
+		t.Fatalf("NewSecret: %v", err)
+	}
+	b, _ := NewSecret()
+
+	if !strings.HasPrefix(a, secretPrefix) || len(a) != len(secretPrefix)+64 {
+		t.Errorf("secret %q is not a prefixed 32-byte hex string", a)
+	}
+	if a == b {
+		t.Errorf("two secrets are the same")
+	}
+}
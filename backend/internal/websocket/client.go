@@ -4,8 +4,10 @@
 package websocket
 
 import (
+	"context"
 	"encoding/json"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -23,15 +25,35 @@ const (
 
 	// Maximum message size allowed from peer
 	maxMessageSize = 512 * 1024 // 512KB
+
+	// Time allowed to record a presence change
+	presenceWriteTimeout = 5 * time.Second
 )
 
 // Client represents a websocket client connection
 type Client struct {
+	id          string
 	hub         *Hub
 	conn        *websocket.Conn
 	send        chan []byte
 	userID      string
+	role        string
 	tournaments []string
+
+	// When the access token expires; zero for anonymous connections.
+	// New expiry times from in-band re-authentication go to the write pump.
+	expiresAt time.Time
+	reauth    chan time.Time
+
+	// Highest sequence per tournament already sent by a resume replay
+	replayedTo map[string]int64
+
+	// Live broadcasts held per tournament while a resume reads the replay
+	// buffer. Guarded by the hub.
+	resuming map[string][]*Message
+
+	// The hub and the connection handler may both close the client
+	closeOnce sync.Once
 }
 
 // ClientMessage represents a message from client
@@ -46,12 +68,18 @@ func (c *Client) readPump() {
 	defer func() {
 		c.hub.unregister <- c
 		c.conn.Close()
+		c.track(func(ctx context.Context) error {
+			return c.hub.services.Presence.Disconnected(ctx, c.id)
+		})
 	}()
 
 	c.conn.SetReadLimit(maxMessageSize)
 	c.conn.SetReadDeadline(time.Now().Add(pongWait))
 	c.conn.SetPongHandler(func(string) error {
 		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+		c.track(func(ctx context.Context) error {
+			return c.hub.services.Presence.Heartbeat(ctx, c.id)
+		})
 		return nil
 	})
 
@@ -73,6 +101,8 @@ func (c *Client) readPump() {
 			c.handleUnsubscribe(msg)
 		case "ping":
 			c.handlePing()
+		case "authenticate":
+			c.handleAuthenticate(msg)
 		default:
 			log.Printf("Unknown message type: %s", msg.Type)
 		}
@@ -87,6 +117,15 @@ func (c *Client) writePump() {
 		c.conn.Close()
 	}()
 
+	// Authenticated connections close when their token expires unless renewed in-band
+	var expired <-chan time.Time
+	var expiry *time.Timer
+	if !c.expiresAt.IsZero() {
+		expiry = time.NewTimer(time.Until(c.expiresAt))
+		defer expiry.Stop()
+		expired = expiry.C
+	}
+
 	for {
 		select {
 		case message, ok := <-c.send:
@@ -106,6 +145,14 @@ func (c *Client) writePump() {
 			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
 				return
 			}
+
+		case expiresAt := <-c.reauth:
+			expiry.Reset(time.Until(expiresAt))
+
+		case <-expired:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "token expired"))
+			return
 		}
 	}
 }
@@ -114,6 +161,7 @@ func (c *Client) writePump() {
 func (c *Client) handleSubscribe(msg ClientMessage) {
 	var data struct {
 		TournamentID string `json:"tournament_id"`
+		LastSeq      *int64 `json:"last_seq"`
 	}
 
 	if err := json.Unmarshal(msg.Data, &data); err != nil {
@@ -122,14 +170,39 @@ func (c *Client) handleSubscribe(msg ClientMessage) {
 	}
 
 	if data.TournamentID != "" {
-		c.hub.SubscribeToTournament(c, data.TournamentID)
+		// Private tournaments only stream to their organizer, staff and participants
+		allowed, err := c.hub.services.Tournament.CanView(context.Background(), data.TournamentID, c.userID, c.role)
+		if err != nil || !allowed {
+			reason := "Access denied"
+			if err != nil {
+				reason = "Tournament not found"
+			}
+			c.sendMessage(&Message{
+				Type:         MessageError,
+				TournamentID: data.TournamentID,
+				Data:         map[string]string{"error": reason},
+			})
+			return
+		}
+
+		confirmation := map[string]interface{}{
+			"tournament_id": data.TournamentID,
+		}
+
+		// Reconnecting clients pass the last sequence they saw to get missed updates first
+		if data.LastSeq != nil {
+			confirmation["last_seq"] = c.hub.ResumeTournament(c, data.TournamentID, *data.LastSeq)
+		} else {
+			c.hub.SubscribeToTournament(c, data.TournamentID)
+		}
+		c.track(func(ctx context.Context) error {
+			return c.hub.services.Presence.Subscribed(ctx, c.id, data.TournamentID)
+		})
 
 		// Send confirmation
 		response := Message{
 			Type: "subscribed",
-			Data: map[string]string{
-				"tournament_id": data.TournamentID,
-			},
+			Data: confirmation,
 		}
 
 		if responseData, err := json.Marshal(response); err == nil {
@@ -151,6 +224,9 @@ func (c *Client) handleUnsubscribe(msg ClientMessage) {
 
 	if data.TournamentID != "" {
 		c.hub.UnsubscribeFromTournament(c, data.TournamentID)
+		c.track(func(ctx context.Context) error {
+			return c.hub.services.Presence.Unsubscribed(ctx, c.id, data.TournamentID)
+		})
 
 		// Send confirmation
 		response := Message{
@@ -180,7 +256,83 @@ func (c *Client) handlePing() {
 	}
 }
 
+// handleAuthenticate renews the connection's access token before it expires.
+// The token must belong to the user the connection was opened for.
+func (c *Client) handleAuthenticate(msg ClientMessage) {
+	var data struct {
+		Token string `json:"token"`
+	}
+
+	if err := json.Unmarshal(msg.Data, &data); err != nil {
+		log.Printf("Failed to unmarshal authenticate data: %v", err)
+		return
+	}
+
+	if c.userID == "" {
+		c.sendMessage(&Message{
+			Type: MessageError,
+			Data: map[string]string{"error": "Anonymous connections must reconnect with a token"},
+		})
+		return
+	}
+
+	claims, err := c.hub.services.Auth.ParseToken(data.Token)
+	if err != nil || claims.ExpiresAt == nil || claims.UserID != c.userID {
+		c.sendMessage(&Message{
+			Type: MessageError,
+			Data: map[string]string{"error": "Invalid or expired token"},
+		})
+		return
+	}
+
+	c.role = claims.Role
+
+	// Replace any expiry the write pump has not picked up yet
+	select {
+	case <-c.reauth:
+	default:
+	}
+	c.reauth <- claims.ExpiresAt.Time
+
+	c.sendMessage(&Message{
+		Type: MessageAuthenticated,
+		Data: map[string]interface{}{"expires_at": claims.ExpiresAt.Time},
+	})
+}
+
+// track records a change to this connection for presence. Only authenticated
+// connections are recorded, since presence is reported per user.
+func (c *Client) track(record func(ctx context.Context) error) {
+	if c.userID == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), presenceWriteTimeout)
+	defer cancel()
+
+	if err := record(ctx); err != nil {
+		log.Printf("Failed to record presence for connection %s: %v", c.id, err)
+	}
+}
+
+// sendMessage queues a message for this client without blocking
+func (c *Client) sendMessage(message *Message) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("Failed to marshal message: %v", err)
+		return
+	}
+
+	select {
+	case c.send <- data:
+	default:
+		log.Printf("Dropping %s for client %s: send buffer full", message.Type, c.userID)
+	}
+}
+
 // close cleanly closes the client connection
 func (c *Client) close() {
-	close(c.send)
+	c.closeOnce.Do(func() {
+		close(c.send)
+	})
 }
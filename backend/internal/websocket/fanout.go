@@ -0,0 +1,131 @@
+// internal/websocket/fanout.go
+// Redis pub/sub relay that fans hub broadcasts out across API instances
+
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+
+	"tournament-planner/internal/utils"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis channel prefixes; each tournament and user gets its own channel
+const (
+	tournamentChannelPrefix = "ws:tournament:"
+	userChannelPrefix       = "ws:user:"
+)
+
+// envelope wraps a message relayed between hub instances
+type envelope struct {
+	Origin  string   `json:"origin"`
+	Message *Message `json:"message"`
+}
+
+// fanout relays messages between hubs on different instances. Each instance
+// subscribes only to the channels of tournaments and users it has local clients
+// for, and ignores its own publications since it already delivered them locally.
+// A nil fanout is a valid single-instance relay that does nothing.
+type fanout struct {
+	client *redis.Client
+	pubsub *redis.PubSub
+	origin string
+	logger *log.Logger
+
+	// Channels currently subscribed; mu also orders concurrent syncs
+	mu         sync.Mutex
+	subscribed map[string]bool
+}
+
+// newFanout creates a relay on the shared Redis client, or nil without one
+func newFanout(client *redis.Client, logger *log.Logger) *fanout {
+	if client == nil {
+		return nil
+	}
+	return &fanout{
+		client:     client,
+		pubsub:     client.Subscribe(context.Background()),
+		origin:     utils.GenerateUUID(),
+		logger:     logger,
+		subscribed: make(map[string]bool),
+	}
+}
+
+// publish relays a message to other instances on its tournament or user channel
+func (f *fanout) publish(message *Message) {
+	if f == nil {
+		return
+	}
+
+	data, err := json.Marshal(envelope{Origin: f.origin, Message: message})
+	if err != nil {
+		f.logger.Printf("Failed to marshal relayed message: %v", err)
+		return
+	}
+
+	ctx := context.Background()
+	if message.TournamentID != "" {
+		if err := f.client.Publish(ctx, tournamentChannelPrefix+message.TournamentID, data).Err(); err != nil {
+			f.logger.Printf("Failed to relay message for tournament %s: %v", message.TournamentID, err)
+		}
+	}
+	if message.UserID != "" {
+		if err := f.client.Publish(ctx, userChannelPrefix+message.UserID, data).Err(); err != nil {
+			f.logger.Printf("Failed to relay message for user %s: %v", message.UserID, err)
+		}
+	}
+}
+
+// sync brings the subscription for each channel in line with whether
+// listening reports local clients for it at the time of the call
+func (f *fanout) sync(channels []string, listening func(channel string) bool) {
+	if f == nil {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ctx := context.Background()
+	for _, channel := range channels {
+		want := listening(channel)
+		switch {
+		case want && !f.subscribed[channel]:
+			if err := f.pubsub.Subscribe(ctx, channel); err != nil {
+				f.logger.Printf("Failed to subscribe to %s: %v", channel, err)
+				continue
+			}
+			f.subscribed[channel] = true
+		case !want && f.subscribed[channel]:
+			if err := f.pubsub.Unsubscribe(ctx, channel); err != nil {
+				f.logger.Printf("Failed to unsubscribe from %s: %v", channel, err)
+				continue
+			}
+			delete(f.subscribed, channel)
+		}
+	}
+}
+
+// run forwards messages published by other instances until the subscription closes.
+// go-redis reconnects and resubscribes on its own if the connection drops.
+func (f *fanout) run(deliver chan<- *Message) {
+	if f == nil {
+		return
+	}
+
+	for msg := range f.pubsub.Channel() {
+		var env envelope
+		if err := json.Unmarshal([]byte(msg.Payload), &env); err != nil {
+			f.logger.Printf("Failed to unmarshal relayed message: %v", err)
+			continue
+		}
+		if env.Origin == f.origin || env.Message == nil {
+			continue
+		}
+		deliver <- env.Message
+	}
+}
@@ -0,0 +1,129 @@
+// internal/websocket/fanout_test.go
+// Cross-instance relay between two hubs sharing one Redis
+
+package websocket
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"tournament-planner/internal/events"
+	"tournament-planner/internal/services"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestHub starts a hub on its own Redis connection, as a separate instance would
+func newTestHub(t *testing.T, server *miniredis.Miniredis) *Hub {
+	t.Helper()
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	logger := log.New(io.Discard, "", 0)
+	hub := NewHub(&services.Container{Events: events.NewBus(logger)}, client, logger)
+	go hub.Run()
+	return hub
+}
+
+func newTestClient(hub *Hub, userID string, tournaments ...string) *Client {
+	return &Client{
+		id:          userID,
+		hub:         hub,
+		send:        make(chan []byte, 16),
+		userID:      userID,
+		tournaments: tournaments,
+		replayedTo:  make(map[string]int64),
+	}
+}
+
+// waitForSubscribers waits until the channel has the given number of Redis subscribers
+func waitForSubscribers(t *testing.T, server *miniredis.Miniredis, channel string, want int) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for server.PubSubNumSub(channel)[channel] != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("%s has %d subscribers, want %d", channel, server.PubSubNumSub(channel)[channel], want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func receive(t *testing.T, client *Client) *Message {
+	t.Helper()
+
+	select {
+	case data := <-client.send:
+		var message Message
+		if err := json.Unmarshal(data, &message); err != nil {
+			t.Fatalf("unmarshal message: %v", err)
+		}
+		return &message
+	case <-time.After(2 * time.Second):
+		t.Fatal("no message delivered")
+		return nil
+	}
+}
+
+func TestFanoutRelaysBetweenHubs(t *testing.T) {
+	server := miniredis.RunT(t)
+	a := newTestHub(t, server)
+	b := newTestHub(t, server)
+
+	local := newTestClient(a, "user-a", "t1")
+	remote := newTestClient(b, "user-b", "t1")
+	a.register <- local
+	b.register <- remote
+	waitForSubscribers(t, server, tournamentChannelPrefix+"t1", 2)
+	waitForSubscribers(t, server, userChannelPrefix+"user-b", 1)
+
+	a.BroadcastTournamentUpdate("t1", "score_updated", map[string]int{"home": 2})
+
+	got := receive(t, local)
+	if got.Type != "score_updated" || got.Seq != 1 {
+		t.Fatalf("local delivery = %+v, want score_updated seq 1", got)
+	}
+	relayed := receive(t, remote)
+	if relayed.Type != "score_updated" || relayed.Seq != 1 {
+		t.Fatalf("relayed delivery = %+v, want score_updated seq 1 from the originating hub", relayed)
+	}
+
+	// The originating hub ignores its own publication rather than delivering twice
+	select {
+	case data := <-local.send:
+		t.Fatalf("local client got a duplicate: %s", data)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	a.SendToUser("user-b", "notification", "hello")
+	if direct := receive(t, remote); direct.Type != "notification" || direct.UserID != "user-b" {
+		t.Fatalf("user delivery = %+v, want notification for user-b", direct)
+	}
+}
+
+func TestFanoutLeavesChannelsWithoutLocalClients(t *testing.T) {
+	server := miniredis.RunT(t)
+	hub := newTestHub(t, server)
+
+	first := newTestClient(hub, "user-1", "t1")
+	second := newTestClient(hub, "user-2", "t1")
+	hub.register <- first
+	hub.register <- second
+	waitForSubscribers(t, server, tournamentChannelPrefix+"t1", 1)
+
+	// The tournament keeps its channel while anyone on this instance still listens
+	hub.unregister <- first
+	waitForSubscribers(t, server, userChannelPrefix+"user-1", 0)
+	waitForSubscribers(t, server, tournamentChannelPrefix+"t1", 1)
+
+	hub.UnsubscribeFromTournament(second, "t1")
+	waitForSubscribers(t, server, tournamentChannelPrefix+"t1", 0)
+
+	hub.SubscribeToTournament(second, "t2")
+	waitForSubscribers(t, server, tournamentChannelPrefix+"t2", 1)
+}
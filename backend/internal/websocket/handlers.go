@@ -4,32 +4,54 @@
 package websocket
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 	"net/http"
+	"strings"
+	"time"
+
+	"tournament-planner/internal/events"
+	"tournament-planner/internal/services"
+	"tournament-planner/internal/utils"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 )
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		// In production, implement proper origin checking
-		return true
-	},
+// tokenSubprotocol is the subprotocol that carries an access token for browsers,
+// which cannot set headers on a websocket handshake: ["access_token", "<jwt>"]
+const tokenSubprotocol = "access_token"
+
+// newUpgrader accepts handshakes from the allowed origins. Requests without an
+// Origin header come from non-browser clients and are not subject to the check.
+func newUpgrader(allowedOrigins []string) *websocket.Upgrader {
+	return &websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		Subprotocols:    []string{tokenSubprotocol},
+		CheckOrigin: func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				return true
+			}
+			for _, allowed := range allowedOrigins {
+				if strings.EqualFold(origin, allowed) {
+					return true
+				}
+			}
+			return false
+		},
+	}
 }
 
 // HandleConnection handles new WebSocket connections
-func HandleConnection(hub *Hub) gin.HandlerFunc {
+func HandleConnection(hub *Hub, allowedOrigins []string) gin.HandlerFunc {
+	upgrader := newUpgrader(allowedOrigins)
+
 	return func(c *gin.Context) {
-		// Get user ID from context (set by auth middleware)
-		userID, _ := c.Get("user_id")
-		userIDStr := ""
-		if userID != nil {
-			userIDStr = userID.(string)
-		}
+		// Anonymous connections may still follow public tournaments
+		claims := authenticate(c, hub.services.Auth)
 
 		// Upgrade HTTP connection to WebSocket
 		conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
@@ -40,22 +62,32 @@ func HandleConnection(hub *Hub) gin.HandlerFunc {
 
 		// Create new client
 		client := &Client{
+			id:          utils.GenerateUUID(),
 			hub:         hub,
 			conn:        conn,
 			send:        make(chan []byte, 256),
-			userID:      userIDStr,
 			tournaments: make([]string, 0),
+			replayedTo:  make(map[string]int64),
+			reauth:      make(chan time.Time, 1),
+		}
+		if claims != nil {
+			client.userID = claims.UserID
+			client.role = claims.Role
+			client.expiresAt = claims.ExpiresAt.Time
 		}
 
 		// Register client with hub
 		hub.register <- client
+		client.track(func(ctx context.Context) error {
+			return hub.services.Presence.Connected(ctx, client.id, client.userID)
+		})
 
 		// Send welcome message
 		welcomeMsg := Message{
 			Type: "welcome",
 			Data: map[string]interface{}{
 				"message": "Connected to Tournament Planner WebSocket",
-				"user_id": userIDStr,
+				"user_id": client.userID,
 			},
 		}
 
@@ -69,31 +101,67 @@ func HandleConnection(hub *Hub) gin.HandlerFunc {
 	}
 }
 
-// Message types for WebSocket communication
+// authenticate validates the access token of a live connection, taken from the
+// Authorization header, the access_token query parameter or the token subprotocol.
+// It returns nil for anonymous requests and invalid tokens.
+func authenticate(c *gin.Context, authService *services.AuthService) *utils.Claims {
+	token := ""
+	if parts := strings.Split(c.GetHeader("Authorization"), " "); len(parts) == 2 && parts[0] == "Bearer" {
+		token = parts[1]
+	} else if query := c.Query("access_token"); query != "" {
+		token = query
+	} else {
+		protocols := websocket.Subprotocols(c.Request)
+		for i, protocol := range protocols {
+			if protocol == tokenSubprotocol && i+1 < len(protocols) {
+				token = protocols[i+1]
+				break
+			}
+		}
+	}
+	if token == "" {
+		return nil
+	}
+
+	claims, err := authService.ParseToken(token)
+	if err != nil || claims.ExpiresAt == nil {
+		return nil
+	}
+	return claims
+}
+
+// Message types for WebSocket communication.
+// Types delivered from the event bus share their values with events.Type.
 const (
 	// Tournament updates
 	MessageTournamentCreated   = "tournament_created"
 	MessageTournamentUpdated   = "tournament_updated"
-	MessageTournamentPublished = "tournament_published"
+	MessageTournamentPublished = string(events.TournamentPublished)
 	MessageTournamentStarted   = "tournament_started"
 	MessageTournamentCompleted = "tournament_completed"
+	MessageTournamentCancelled = string(events.TournamentCancelled)
 
 	// Match updates
-	MessageMatchScheduled    = "match_scheduled"
-	MessageMatchStarted      = "match_started"
-	MessageMatchScoreUpdated = "match_score_updated"
-	MessageMatchCompleted    = "match_completed"
+	MessageMatchScheduled    = string(events.MatchScheduled)
+	MessageMatchStarted      = string(events.MatchStarted)
+	MessageMatchScoreUpdated = string(events.MatchScoreUpdated)
+	MessageMatchCompleted    = string(events.MatchCompleted)
 
 	// Participant updates
-	MessageParticipantRegistered = "participant_registered"
-	MessageParticipantWithdrawn  = "participant_withdrawn"
-	MessageParticipantCheckedIn  = "participant_checked_in"
+	MessageParticipantRegistered = string(events.ParticipantRegistered)
+	MessageParticipantWithdrawn  = string(events.ParticipantWithdrawn)
+	MessageParticipantCheckedIn  = string(events.ParticipantCheckedIn)
 
 	// Bracket updates
-	MessageBracketUpdated    = "bracket_updated"
-	MessageFixturesGenerated = "fixtures_generated"
+	MessageBracketUpdated    = string(events.BracketUpdated)
+	MessageFixturesGenerated = string(events.FixturesGenerated)
 
 	// Notifications
-	MessageNotification = "notification"
+	MessageNotification = string(events.Notification)
 	MessageAlert        = "alert"
+
+	// Connection control
+	MessageResyncRequired = "resync_required"
+	MessageAuthenticated  = "authenticated"
+	MessageError          = "error"
 )
@@ -6,9 +6,13 @@ package websocket
 import (
 	"encoding/json"
 	"log"
+	"strings"
 	"sync"
 
+	"tournament-planner/internal/events"
 	"tournament-planner/internal/services"
+
+	"github.com/redis/go-redis/v9"
 )
 
 // Hub maintains active websocket connections and broadcasts messages
@@ -16,8 +20,8 @@ type Hub struct {
 	// Registered clients by tournament ID
 	tournaments map[string]map[*Client]bool
 
-	// Registered clients by user ID
-	users map[string]*Client
+	// Registered clients by user ID; a user may be connected from several devices
+	users map[string]map[*Client]bool
 
 	// Register client
 	register chan *Client
@@ -28,6 +32,13 @@ type Hub struct {
 	// Broadcast messages to tournament
 	broadcast chan *Message
 
+	// Messages relayed from hubs on other instances, delivered locally only
+	relay  chan *Message
+	fanout *fanout
+
+	// Sequence numbers and recent history for resuming clients
+	replay replayBuffer
+
 	// Services
 	services *services.Container
 	logger   *log.Logger
@@ -41,24 +52,50 @@ type Message struct {
 	Type         string      `json:"type"`
 	TournamentID string      `json:"tournament_id,omitempty"`
 	UserID       string      `json:"user_id,omitempty"`
+	Seq          int64       `json:"seq,omitempty"`
 	Data         interface{} `json:"data"`
 }
 
-// NewHub creates a new WebSocket hub
-func NewHub(services *services.Container, logger *log.Logger) *Hub {
-	return &Hub{
+// NewHub creates a new WebSocket hub that delivers domain events from the service bus.
+// With a Redis client, broadcasts are also fanned out to hubs on other instances.
+func NewHub(services *services.Container, redisClient *redis.Client, logger *log.Logger) *Hub {
+	hub := &Hub{
 		tournaments: make(map[string]map[*Client]bool),
-		users:       make(map[string]*Client),
+		users:       make(map[string]map[*Client]bool),
 		register:    make(chan *Client),
 		unregister:  make(chan *Client),
 		broadcast:   make(chan *Message, 256),
+		relay:       make(chan *Message, 256),
+		fanout:      newFanout(redisClient, logger),
+		replay:      newReplayBuffer(redisClient),
 		services:    services,
 		logger:      logger,
 	}
+	services.Events.Subscribe(hub.handleEvent)
+	return hub
+}
+
+// handleEvent forwards a domain event to the tournament's subscribers.
+// It runs on the bus goroutine, so it drops rather than blocks when the hub is backed up.
+func (h *Hub) handleEvent(event *events.Event) {
+	message := &Message{
+		Type:         string(event.Type),
+		TournamentID: event.TournamentID,
+		UserID:       event.UserID,
+		Data:         event.Payload,
+	}
+
+	select {
+	case h.broadcast <- message:
+	default:
+		h.logger.Printf("Hub broadcast queue full, dropping %s for tournament %s", event.Type, event.TournamentID)
+	}
 }
 
 // Run starts the hub's main loop
 func (h *Hub) Run() {
+	go h.fanout.run(h.relay)
+
 	for {
 		select {
 		case client := <-h.register:
@@ -68,6 +105,16 @@ func (h *Hub) Run() {
 			h.unregisterClient(client)
 
 		case message := <-h.broadcast:
+			// Only the originating instance numbers a broadcast; relayed copies keep its sequence
+			if message.TournamentID != "" {
+				if err := h.replay.append(message.TournamentID, message); err != nil {
+					h.logger.Printf("Failed to buffer message for tournament %s: %v", message.TournamentID, err)
+				}
+			}
+			h.broadcastMessage(message)
+			h.fanout.publish(message)
+
+		case message := <-h.relay:
 			h.broadcastMessage(message)
 		}
 	}
@@ -75,26 +122,27 @@ func (h *Hub) Run() {
 
 // registerClient adds a new client to the hub
 func (h *Hub) registerClient(client *Client) {
-	h.mu.Lock()
-	defer h.mu.Unlock()
+	var channels []string
 
-	// Register user connection
+	h.mu.Lock()
+	// Register user connection alongside any the user already has
 	if client.userID != "" {
-		// Close existing connection for this user
-		if existing, exists := h.users[client.userID]; exists {
-			existing.close()
-			h.removeClient(existing)
+		if h.users[client.userID] == nil {
+			h.users[client.userID] = make(map[*Client]bool)
+			channels = append(channels, userChannelPrefix+client.userID)
 		}
-		h.users[client.userID] = client
+		h.users[client.userID][client] = true
 	}
 
 	// Register tournament connections
 	for _, tournamentID := range client.tournaments {
-		if h.tournaments[tournamentID] == nil {
-			h.tournaments[tournamentID] = make(map[*Client]bool)
+		if channel := h.addTournamentClient(tournamentID, client); channel != "" {
+			channels = append(channels, channel)
 		}
-		h.tournaments[tournamentID][client] = true
 	}
+	h.mu.Unlock()
+
+	h.syncChannels(channels)
 
 	h.logger.Printf("Client registered: %s (tournaments: %v)", client.userID, client.tournaments)
 }
@@ -102,70 +150,150 @@ func (h *Hub) registerClient(client *Client) {
 // unregisterClient removes a client from the hub
 func (h *Hub) unregisterClient(client *Client) {
 	h.mu.Lock()
-	defer h.mu.Unlock()
-
-	h.removeClient(client)
+	channels := h.removeClient(client)
 	client.close()
+	h.mu.Unlock()
+
+	h.syncChannels(channels)
 
 	h.logger.Printf("Client unregistered: %s", client.userID)
 }
 
-// removeClient removes client from all registrations
-func (h *Hub) removeClient(client *Client) {
+// removeClient removes client from all registrations and returns the relay
+// channels nobody on this instance is listening to any more. Callers hold h.mu.
+func (h *Hub) removeClient(client *Client) []string {
+	var channels []string
+
 	// Remove from user map
-	if client.userID != "" {
-		delete(h.users, client.userID)
+	if clients, exists := h.users[client.userID]; exists {
+		delete(clients, client)
+		if len(clients) == 0 {
+			delete(h.users, client.userID)
+			channels = append(channels, userChannelPrefix+client.userID)
+		}
 	}
 
 	// Remove from tournament maps
 	for _, tournamentID := range client.tournaments {
-		if clients, exists := h.tournaments[tournamentID]; exists {
-			delete(clients, client)
-			if len(clients) == 0 {
-				delete(h.tournaments, tournamentID)
-			}
+		if channel := h.removeTournamentClient(tournamentID, client); channel != "" {
+			channels = append(channels, channel)
 		}
 	}
+
+	return channels
 }
 
-// broadcastMessage sends a message to relevant clients
-func (h *Hub) broadcastMessage(message *Message) {
+// addTournamentClient adds a client to a tournament's local subscribers and returns
+// the tournament's relay channel when this is its first one. Callers hold h.mu.
+func (h *Hub) addTournamentClient(tournamentID string, client *Client) string {
+	var channel string
+	if h.tournaments[tournamentID] == nil {
+		h.tournaments[tournamentID] = make(map[*Client]bool)
+		channel = tournamentChannelPrefix + tournamentID
+	}
+	h.tournaments[tournamentID][client] = true
+	return channel
+}
+
+// removeTournamentClient removes a client from a tournament's local subscribers and
+// returns the tournament's relay channel once nobody on this instance is listening.
+// Callers hold h.mu.
+func (h *Hub) removeTournamentClient(tournamentID string, client *Client) string {
+	if clients, exists := h.tournaments[tournamentID]; exists {
+		delete(clients, client)
+		if len(clients) == 0 {
+			delete(h.tournaments, tournamentID)
+			return tournamentChannelPrefix + tournamentID
+		}
+	}
+	return ""
+}
+
+// syncChannels subscribes to or leaves the relay channels whose local listeners changed.
+// It runs after h.mu is released so a slow Redis never stalls delivery, and checks each
+// channel's listeners afresh since another change may have landed in between.
+func (h *Hub) syncChannels(channels []string) {
+	if len(channels) == 0 {
+		return
+	}
+	h.fanout.sync(channels, h.listening)
+}
+
+// listening reports whether any client on this instance wants a relay channel
+func (h *Hub) listening(channel string) bool {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
+	if tournamentID, ok := strings.CutPrefix(channel, tournamentChannelPrefix); ok {
+		return len(h.tournaments[tournamentID]) > 0
+	}
+	if userID, ok := strings.CutPrefix(channel, userChannelPrefix); ok {
+		return len(h.users[userID]) > 0
+	}
+	return false
+}
+
+// broadcastMessage sends a message to relevant clients
+func (h *Hub) broadcastMessage(message *Message) {
 	data, err := json.Marshal(message)
 	if err != nil {
 		h.logger.Printf("Failed to marshal message: %v", err)
 		return
 	}
 
+	// Clients whose send channel is full; they are dropped once the read lock is released
+	slow := make(map[*Client]bool)
+
+	h.mu.RLock()
 	// Broadcast to tournament participants
 	if message.TournamentID != "" {
-		if clients, exists := h.tournaments[message.TournamentID]; exists {
-			for client := range clients {
-				select {
-				case client.send <- data:
-				default:
-					// Client's send channel is full, close it
-					h.removeClient(client)
-					client.close()
-				}
+		for client := range h.tournaments[message.TournamentID] {
+			// Held until the client's resume replay has been sent. Only the Run
+			// goroutine broadcasts, and resumes hold the write lock to read this.
+			if held, resuming := client.resuming[message.TournamentID]; resuming {
+				client.resuming[message.TournamentID] = append(held, message)
+				continue
+			}
+			// Already delivered by a resume replay
+			if message.Seq != 0 && message.Seq <= client.replayedTo[message.TournamentID] {
+				continue
+			}
+			select {
+			case client.send <- data:
+			default:
+				slow[client] = true
 			}
 		}
 	}
 
-	// Send to specific user
+	// Send to every connection of a specific user
 	if message.UserID != "" {
-		if client, exists := h.users[message.UserID]; exists {
+		for client := range h.users[message.UserID] {
+			if slow[client] {
+				continue
+			}
 			select {
 			case client.send <- data:
 			default:
-				// Client's send channel is full, close it
-				h.removeClient(client)
-				client.close()
+				slow[client] = true
 			}
 		}
 	}
+	h.mu.RUnlock()
+
+	if len(slow) == 0 {
+		return
+	}
+
+	var channels []string
+	h.mu.Lock()
+	for client := range slow {
+		channels = append(channels, h.removeClient(client)...)
+		client.close()
+	}
+	h.mu.Unlock()
+
+	h.syncChannels(channels)
 }
 
 // BroadcastTournamentUpdate broadcasts an update to all tournament participants
@@ -191,24 +319,88 @@ func (h *Hub) SendToUser(userID string, messageType string, data interface{}) {
 // SubscribeToTournament subscribes a client to tournament updates
 func (h *Hub) SubscribeToTournament(client *Client, tournamentID string) {
 	h.mu.Lock()
-	defer h.mu.Unlock()
 
 	// Add tournament to client's list
 	client.tournaments = append(client.tournaments, tournamentID)
 
 	// Add client to tournament's subscriber list
-	if h.tournaments[tournamentID] == nil {
-		h.tournaments[tournamentID] = make(map[*Client]bool)
+	channel := h.addTournamentClient(tournamentID, client)
+	h.mu.Unlock()
+
+	if channel != "" {
+		h.syncChannels([]string{channel})
 	}
-	h.tournaments[tournamentID][client] = true
 
 	h.logger.Printf("Client %s subscribed to tournament %s", client.userID, tournamentID)
 }
 
+// ResumeTournament subscribes a client and replays the broadcasts it missed after lastSeq
+// before live delivery resumes. When the gap is too old to fill, the client is still
+// subscribed but told to resync. It returns the tournament's latest sequence number.
+func (h *Hub) ResumeTournament(client *Client, tournamentID string, lastSeq int64) int64 {
+	// Subscribe first and hold live broadcasts, so nothing sent while the replay
+	// buffer is read is lost; the buffer is read without the hub lock
+	h.mu.Lock()
+	if client.resuming == nil {
+		client.resuming = make(map[string][]*Message)
+	}
+	client.resuming[tournamentID] = []*Message{}
+	client.tournaments = append(client.tournaments, tournamentID)
+	channel := h.addTournamentClient(tournamentID, client)
+	h.mu.Unlock()
+
+	if channel != "" {
+		h.syncChannels([]string{channel})
+	}
+
+	missed, latest, ok, err := h.replay.since(tournamentID, lastSeq)
+	if err != nil {
+		h.logger.Printf("Failed to read replay buffer for tournament %s: %v", tournamentID, err)
+		ok = false
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	held := client.resuming[tournamentID]
+	delete(client.resuming, tournamentID)
+
+	// Closed or unsubscribed while the buffer was read
+	if !h.tournaments[tournamentID][client] {
+		return latest
+	}
+
+	if ok {
+		replayedTo := lastSeq
+		for _, message := range missed {
+			client.sendMessage(message)
+			replayedTo = message.Seq
+		}
+		client.replayedTo[tournamentID] = replayedTo
+	} else {
+		client.sendMessage(&Message{
+			Type:         MessageResyncRequired,
+			TournamentID: tournamentID,
+			Data:         map[string]int64{"last_seq": latest},
+		})
+		delete(client.replayedTo, tournamentID)
+	}
+
+	// Live broadcasts held during the read that the replay didn't already cover
+	for _, message := range held {
+		if message.Seq == 0 || message.Seq > client.replayedTo[tournamentID] {
+			client.sendMessage(message)
+		}
+	}
+
+	h.logger.Printf("Client %s resumed tournament %s from seq %d (latest %d, replayed %v)", client.userID, tournamentID, lastSeq, latest, ok)
+
+	return latest
+}
+
 // UnsubscribeFromTournament unsubscribes a client from tournament updates
 func (h *Hub) UnsubscribeFromTournament(client *Client, tournamentID string) {
 	h.mu.Lock()
-	defer h.mu.Unlock()
 
 	// Remove tournament from client's list
 	for i, id := range client.tournaments {
@@ -219,11 +411,11 @@ func (h *Hub) UnsubscribeFromTournament(client *Client, tournamentID string) {
 	}
 
 	// Remove client from tournament's subscriber list
-	if clients, exists := h.tournaments[tournamentID]; exists {
-		delete(clients, client)
-		if len(clients) == 0 {
-			delete(h.tournaments, tournamentID)
-		}
+	channel := h.removeTournamentClient(tournamentID, client)
+	h.mu.Unlock()
+
+	if channel != "" {
+		h.syncChannels([]string{channel})
 	}
 
 	h.logger.Printf("Client %s unsubscribed from tournament %s", client.userID, tournamentID)
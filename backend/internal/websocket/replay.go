@@ -0,0 +1,189 @@
+// internal/websocket/replay.go
+// Per-tournament sequence numbers and bounded replay buffers for reconnecting clients
+
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// replayBufferSize is how many recent broadcasts are kept per tournament
+	replayBufferSize = 500
+
+	// maxReplayMessages caps a single replay so it fits in a client's send buffer
+	maxReplayMessages = 200
+
+	// replayRetention is how long an idle tournament's buffer and counter survive
+	replayRetention = 7 * 24 * time.Hour
+)
+
+// replayBuffer numbers tournament broadcasts and keeps the most recent ones
+type replayBuffer interface {
+	// append assigns the next sequence number to the message and stores it
+	append(tournamentID string, message *Message) error
+
+	// since returns the messages after lastSeq and the latest sequence number.
+	// ok is false when the gap can no longer be filled and the client must resync.
+	since(tournamentID string, lastSeq int64) (messages []*Message, latest int64, ok bool, err error)
+}
+
+// newReplayBuffer uses Redis when available so every instance shares one
+// sequence per tournament, and an in-memory ring otherwise
+func newReplayBuffer(client *redis.Client) replayBuffer {
+	if client == nil {
+		return &memoryReplayBuffer{tournaments: make(map[string]*memoryRing)}
+	}
+	return &redisReplayBuffer{client: client}
+}
+
+// redisReplayBuffer keeps the sequence in a counter and messages in a sorted set
+// scored by sequence. Each member is the message prefixed with its sequence, so
+// identical payloads broadcast twice stay separate entries.
+type redisReplayBuffer struct {
+	client *redis.Client
+}
+
+// appendScript takes the next sequence and stores the message under it in one
+// step, so a reader that sees the counter also sees every message up to it
+var appendScript = redis.NewScript(`
+local seq = redis.call('INCR', KEYS[1])
+redis.call('ZADD', KEYS[2], seq, seq .. ':' .. ARGV[1])
+redis.call('ZREMRANGEBYRANK', KEYS[2], 0, -tonumber(ARGV[2]) - 1)
+redis.call('EXPIRE', KEYS[1], ARGV[3])
+redis.call('EXPIRE', KEYS[2], ARGV[3])
+return seq
+`)
+
+func (b *redisReplayBuffer) append(tournamentID string, message *Message) error {
+	ctx := context.Background()
+
+	message.Seq = 0
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	keys := []string{fmt.Sprintf("ws:seq:%s", tournamentID), fmt.Sprintf("ws:replay:%s", tournamentID)}
+	seq, err := appendScript.Run(ctx, b.client, keys, data, replayBufferSize, int(replayRetention.Seconds())).Int64()
+	if err != nil {
+		return fmt.Errorf("failed to assign sequence: %w", err)
+	}
+	message.Seq = seq
+	return nil
+}
+
+func (b *redisReplayBuffer) since(tournamentID string, lastSeq int64) ([]*Message, int64, bool, error) {
+	ctx := context.Background()
+
+	// Read the counter and the buffer together so they agree
+	pipe := b.client.TxPipeline()
+	latestCmd := pipe.Get(ctx, fmt.Sprintf("ws:seq:%s", tournamentID))
+	entriesCmd := pipe.ZRangeByScoreWithScores(ctx, fmt.Sprintf("ws:replay:%s", tournamentID), &redis.ZRangeBy{
+		Min: "(" + strconv.FormatInt(lastSeq, 10),
+		Max: "+inf",
+	})
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, 0, false, err
+	}
+
+	latest, err := latestCmd.Int64()
+	if err != nil && err != redis.Nil {
+		return nil, 0, false, err
+	}
+	if lastSeq >= latest {
+		// Up to date, or the counter was reset and the client's sequence is meaningless
+		return nil, latest, lastSeq == latest, nil
+	}
+
+	entries := entriesCmd.Val()
+	messages := make([]*Message, 0, len(entries))
+	for _, entry := range entries {
+		member, _ := entry.Member.(string)
+		_, data, _ := strings.Cut(member, ":")
+
+		var message Message
+		if err := json.Unmarshal([]byte(data), &message); err != nil {
+			return nil, latest, false, err
+		}
+		message.Seq = int64(entry.Score)
+		messages = append(messages, &message)
+	}
+
+	return messages, latest, isContiguous(messages, lastSeq, latest), nil
+}
+
+// memoryReplayBuffer is the single-instance fallback
+type memoryReplayBuffer struct {
+	tournaments map[string]*memoryRing
+	mu          sync.Mutex
+}
+
+// memoryRing holds one tournament's latest sequence and recent messages in order
+type memoryRing struct {
+	seq      int64
+	messages []*Message
+}
+
+func (b *memoryReplayBuffer) append(tournamentID string, message *Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ring := b.tournaments[tournamentID]
+	if ring == nil {
+		ring = &memoryRing{}
+		b.tournaments[tournamentID] = ring
+	}
+
+	ring.seq++
+	message.Seq = ring.seq
+	ring.messages = append(ring.messages, message)
+	if len(ring.messages) > replayBufferSize {
+		ring.messages = ring.messages[len(ring.messages)-replayBufferSize:]
+	}
+	return nil
+}
+
+func (b *memoryReplayBuffer) since(tournamentID string, lastSeq int64) ([]*Message, int64, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ring := b.tournaments[tournamentID]
+	if ring == nil {
+		return nil, 0, lastSeq == 0, nil
+	}
+	if lastSeq >= ring.seq {
+		return nil, ring.seq, lastSeq == ring.seq, nil
+	}
+
+	messages := make([]*Message, 0)
+	for _, message := range ring.messages {
+		if message.Seq > lastSeq {
+			messages = append(messages, message)
+		}
+	}
+
+	return messages, ring.seq, isContiguous(messages, lastSeq, ring.seq), nil
+}
+
+// isContiguous reports whether messages run without a gap from just after
+// lastSeq up to latest and are few enough to replay
+func isContiguous(messages []*Message, lastSeq, latest int64) bool {
+	if len(messages) == 0 || len(messages) > maxReplayMessages {
+		return false
+	}
+	for i, message := range messages {
+		if message.Seq != lastSeq+int64(i)+1 {
+			return false
+		}
+	}
+	return messages[len(messages)-1].Seq == latest
+}
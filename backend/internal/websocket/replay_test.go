@@ -0,0 +1,89 @@
+// internal/websocket/replay_test.go
+// Resuming a tournament from the Redis replay buffer
+
+package websocket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func TestResumeReplaysIdenticalBroadcasts(t *testing.T) {
+	server := miniredis.RunT(t)
+	hub := newTestHub(t, server)
+
+	for i := 0; i < 3; i++ {
+		hub.BroadcastTournamentUpdate("t1", "bracket_updated", map[string]string{"round": "1"})
+	}
+	waitForSeq(t, hub, "t1", 3)
+
+	client := newTestClient(hub, "user-a")
+	if latest := hub.ResumeTournament(client, "t1", 1); latest != 3 {
+		t.Fatalf("latest = %d, want 3", latest)
+	}
+
+	for _, want := range []int64{2, 3} {
+		if message := receive(t, client); message.Seq != want {
+			t.Fatalf("replayed seq %d, want %d", message.Seq, want)
+		}
+	}
+	if got := client.replayedTo["t1"]; got != 3 {
+		t.Fatalf("replayedTo = %d, want 3", got)
+	}
+}
+
+func TestResumeDuringBroadcastsDeliversEachOnce(t *testing.T) {
+	server := miniredis.RunT(t)
+	hub := newTestHub(t, server)
+
+	for i := 0; i < 5; i++ {
+		hub.BroadcastTournamentUpdate("t1", "score_updated", nil)
+	}
+
+	client := newTestClient(hub, "user-a")
+	client.send = make(chan []byte, 64)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			hub.BroadcastTournamentUpdate("t1", "score_updated", nil)
+		}
+	}()
+	hub.ResumeTournament(client, "t1", 0)
+	<-done
+
+	for want := int64(1); want <= 25; want++ {
+		message := receive(t, client)
+		if message.Type == MessageResyncRequired {
+			t.Fatalf("resync requested for a contiguous buffer")
+		}
+		if message.Seq != want {
+			t.Fatalf("delivered seq %d, want %d", message.Seq, want)
+		}
+	}
+	select {
+	case data := <-client.send:
+		t.Fatalf("unexpected extra message %s", data)
+	default:
+	}
+}
+
+// waitForSeq waits until the tournament's replay buffer has reached seq
+func waitForSeq(t *testing.T, hub *Hub, tournamentID string, seq int64) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		_, latest, _, err := hub.replay.since(tournamentID, 0)
+		if err == nil && latest >= seq {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("replay buffer for %s at seq %d, want %d", tournamentID, latest, seq)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,152 @@
+// internal/websocket/sse.go
+// Server-Sent Events stream of tournament updates for clients that cannot use websockets
+
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// Send a heartbeat comment with this period so proxies keep the stream open
+	sseHeartbeatPeriod = 15 * time.Second
+
+	// Reconnect delay suggested to EventSource clients
+	sseRetry = 3 * time.Second
+)
+
+// HandleEventStream streams a tournament's hub messages as Server-Sent Events.
+// Each message's sequence number is its event ID, so a reconnecting EventSource
+// resumes from Last-Event-ID. Public tournaments can be followed anonymously;
+// EventSource cannot set headers, so a token may be passed as access_token.
+func HandleEventStream(hub *Hub) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tournamentID := c.Param("id")
+
+		userID, role := "", ""
+		var expired <-chan time.Time
+		if claims := authenticate(c, hub.services.Auth); claims != nil {
+			userID, role = claims.UserID, claims.Role
+
+			// The stream ends with the token; EventSource then reconnects with a fresh URL
+			expiry := time.NewTimer(time.Until(claims.ExpiresAt.Time))
+			defer expiry.Stop()
+			expired = expiry.C
+		}
+
+		allowed, err := hub.services.Tournament.CanView(c.Request.Context(), tournamentID, userID, role)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+			return
+		}
+		if !allowed {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+			return
+		}
+
+		// EventSource only sends Last-Event-ID when reconnecting; the query parameter
+		// lets a fresh page resume from a sequence it already has
+		lastEventID := c.GetHeader("Last-Event-ID")
+		if lastEventID == "" {
+			lastEventID = c.Query("last_event_id")
+		}
+		var lastSeq *int64
+		if lastEventID != "" {
+			seq, err := strconv.ParseInt(lastEventID, 10, 64)
+			if err != nil || seq < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Last-Event-ID"})
+				return
+			}
+			lastSeq = &seq
+		}
+
+		// The stream outlives the server's write timeout
+		if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+			log.Printf("Failed to clear write deadline for event stream: %v", err)
+		}
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+
+		// The client is subscribed to the tournament only; it never joins the user
+		// registry, so it does not displace the user's websocket connection
+		client := &Client{
+			hub:         hub,
+			send:        make(chan []byte, 256),
+			userID:      userID,
+			tournaments: make([]string, 0),
+			replayedTo:  make(map[string]int64),
+		}
+		if lastSeq != nil {
+			hub.ResumeTournament(client, tournamentID, *lastSeq)
+		} else {
+			hub.SubscribeToTournament(client, tournamentID)
+		}
+		defer func() {
+			hub.UnsubscribeFromTournament(client, tournamentID)
+			client.close()
+		}()
+
+		fmt.Fprintf(c.Writer, "retry: %d\n\n", sseRetry.Milliseconds())
+		c.Writer.Flush()
+
+		ticker := time.NewTicker(sseHeartbeatPeriod)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-c.Request.Context().Done():
+				return
+
+			case <-expired:
+				return
+
+			case data, ok := <-client.send:
+				if !ok {
+					// The hub dropped a client that fell too far behind
+					return
+				}
+				if err := writeEvent(c.Writer, data); err != nil {
+					return
+				}
+				c.Writer.Flush()
+
+			case <-ticker.C:
+				if _, err := fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+					return
+				}
+				c.Writer.Flush()
+			}
+		}
+	}
+}
+
+// writeEvent writes a marshaled hub message as an SSE event named after its type.
+// Sequenced messages carry their sequence as the event ID.
+func writeEvent(w http.ResponseWriter, data []byte) error {
+	var header struct {
+		Type string `json:"type"`
+		Seq  int64  `json:"seq"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+
+	if header.Seq != 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", header.Seq); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", header.Type, data)
+	return err
+}
@@ -54,8 +54,13 @@ CREATE TABLE IF NOT EXISTS tournaments (
     buffer_time INT DEFAULT 5 COMMENT 'in minutes',
     -- Registration settings
     registration_deadline TIMESTAMP NULL,
-    entry_fee DECIMAL(10,2) DEFAULT 0.00,
+    entry_fee_cents BIGINT NOT NULL DEFAULT 0 COMMENT 'in minor units of the currency',
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
     allow_onsite_payment BOOLEAN DEFAULT FALSE,
+    require_paid_checkin BOOLEAN DEFAULT FALSE COMMENT 'block check-in until the entry fee is paid',
+    capture_entry_fees_later BOOLEAN DEFAULT FALSE COMMENT 'only authorize online entry fees; the organizer captures them',
+    pricing JSON COMMENT 'early bird, late and member prices',
+    refund_policy JSON COMMENT 'refund percentages by days before start, less a processing fee',
     -- Capacity (automatically calculated)
     capacity_limit INT NOT NULL,
     current_participants INT DEFAULT 0,
@@ -110,6 +115,13 @@ CREATE TABLE IF NOT EXISTS tournament_participants (
     division VARCHAR(50),
     group_name VARCHAR(50),
     payment_status ENUM('pending', 'paid', 'refunded', 'waived') DEFAULT 'pending',
+    payment_method ENUM('online', 'onsite') DEFAULT 'online',
+    payment_due_at TIMESTAMP NULL,
+    price_tier VARCHAR(20),
+    base_amount_cents BIGINT,
+    discount_code_id VARCHAR(36),
+    discount_cents BIGINT NOT NULL DEFAULT 0,
+    amount_due_cents BIGINT COMMENT 'what the participant owes, fixed at registration',
     checked_in BOOLEAN DEFAULT FALSE,
     registration_data JSON,
     registered_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
@@ -142,6 +154,7 @@ CREATE TABLE IF NOT EXISTS matches (
     referee_id VARCHAR(36),
     next_match_id VARCHAR(36),
     notes TEXT,
+    play_epoch INT NOT NULL DEFAULT 0,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
@@ -156,6 +169,322 @@ CREATE TABLE IF NOT EXISTS matches (
     INDEX idx_venue (venue_id)
 ) ENGINE=InnoDB;
 
+-- Result corrections: audit trail of organizer changes to reported results
+CREATE TABLE IF NOT EXISTS match_result_corrections (
+    id VARCHAR(36) PRIMARY KEY,
+    match_id VARCHAR(36) NOT NULL,
+    tournament_id VARCHAR(36) NOT NULL,
+    corrected_by VARCHAR(36) NOT NULL,
+    reason TEXT NOT NULL,
+    previous_score1 INT,
+    previous_score2 INT,
+    previous_winner_id VARCHAR(36),
+    new_score1 INT NOT NULL,
+    new_score2 INT NOT NULL,
+    new_winner_id VARCHAR(36) NOT NULL,
+    affected_matches JSON,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (match_id) REFERENCES matches(id) ON DELETE CASCADE,
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+    FOREIGN KEY (corrected_by) REFERENCES users(id),
+    INDEX idx_match (match_id),
+    INDEX idx_tournament (tournament_id)
+) ENGINE=InnoDB;
+
+-- Notification outbox: written in the same transaction as the change being announced
+CREATE TABLE IF NOT EXISTS notification_outbox (
+    id VARCHAR(36) PRIMARY KEY,
+    idempotency_key VARCHAR(191) NOT NULL,
+    kind VARCHAR(50) NOT NULL,
+    payload JSON NOT NULL,
+    status ENUM('pending', 'delivered', 'dead') DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until TIMESTAMP NULL,
+    claim_token VARCHAR(36),
+    last_error TEXT,
+    delivered_to JSON,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP NULL,
+    UNIQUE KEY uk_idempotency (idempotency_key),
+    INDEX idx_due (status, next_attempt_at),
+    INDEX idx_claim (claim_token)
+) ENGINE=InnoDB;
+
+-- Rendered notifications held for quiet hours or a daily digest
+CREATE TABLE IF NOT EXISTS held_notifications (
+    id VARCHAR(36) PRIMARY KEY,
+    source_key VARCHAR(191) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    participant_id VARCHAR(36),
+    recipient_name VARCHAR(200) NOT NULL,
+    email VARCHAR(255),
+    phone VARCHAR(20),
+    locale VARCHAR(10) NOT NULL,
+    channel VARCHAR(20) NOT NULL,
+    template VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    summary VARCHAR(500) NOT NULL,
+    digest BOOLEAN DEFAULT FALSE,
+    deliver_after TIMESTAMP NOT NULL,
+    status ENUM('pending', 'delivered', 'dead') DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP NULL,
+    claim_token VARCHAR(36),
+    last_error TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP NULL,
+    UNIQUE KEY uk_source (source_key),
+    INDEX idx_due (status, deliver_after),
+    INDEX idx_claim (claim_token),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+-- Match reminders sent, per offset and scheduled time, to detect reschedules
+CREATE TABLE IF NOT EXISTS match_reminders (
+    match_id VARCHAR(36) NOT NULL,
+    offset_minutes INT NOT NULL,
+    scheduled_for TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    corrected_at TIMESTAMP NULL,
+    PRIMARY KEY (match_id, offset_minutes, scheduled_for),
+    FOREIGN KEY (match_id) REFERENCES matches(id) ON DELETE CASCADE,
+    INDEX idx_uncorrected (corrected_at, scheduled_for)
+) ENGINE=InnoDB;
+
+-- Organizer webhooks per tournament
+CREATE TABLE IF NOT EXISTS webhooks (
+    id VARCHAR(36) PRIMARY KEY,
+    tournament_id VARCHAR(36) NOT NULL,
+    url VARCHAR(500) NOT NULL,
+    secret VARCHAR(100) NOT NULL,
+    event_types JSON,
+    is_active BOOLEAN DEFAULT TRUE,
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by) REFERENCES users(id),
+    INDEX idx_tournament (tournament_id)
+) ENGINE=InnoDB;
+
+-- Webhook delivery log and retry queue
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id VARCHAR(36) PRIMARY KEY,
+    webhook_id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(36) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSON NOT NULL,
+    status ENUM('pending', 'delivered', 'dead') DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until TIMESTAMP NULL,
+    claim_token VARCHAR(36),
+    response_status INT,
+    duration_ms INT,
+    last_error TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP NULL,
+    FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE,
+    INDEX idx_webhook (webhook_id, created_at),
+    INDEX idx_due (status, next_attempt_at),
+    INDEX idx_claim (claim_token)
+) ENGINE=InnoDB;
+
+-- Payments started with the payment provider; their status only changes on provider events.
+-- They outlive the participant's registration so withdrawn participants can be refunded.
+CREATE TABLE IF NOT EXISTS payments (
+    id VARCHAR(36) PRIMARY KEY,
+    tournament_id VARCHAR(36) NOT NULL,
+    participant_id VARCHAR(36) NOT NULL,
+    provider VARCHAR(20) NOT NULL,
+    provider_payment_id VARCHAR(255),
+    amount_cents BIGINT NOT NULL,
+    amount_refunded_cents BIGINT NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL,
+    status ENUM('requires_payment', 'authorized', 'succeeded', 'failed', 'canceled', 'refunded') DEFAULT 'requires_payment',
+    failure_message VARCHAR(500),
+    -- Paid by a participant who had already settled their fee; refunded in full
+    -- and never booked
+    overpayment BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by VARCHAR(36),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    -- Set only while the payment waits for the payer or is authorized, so a
+    -- participant can have at most one open payment
+    open_participant VARCHAR(73) AS (
+        IF(status IN ('requires_payment', 'authorized'), CONCAT(tournament_id, ':', participant_id), NULL)
+    ) STORED,
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL,
+    UNIQUE KEY uk_provider_payment (provider, provider_payment_id),
+    UNIQUE KEY uk_open_payment (open_participant),
+    INDEX idx_participant (tournament_id, participant_id, created_at)
+) ENGINE=InnoDB;
+
+-- Provider webhook events already applied, so redelivered events are skipped
+CREATE TABLE IF NOT EXISTS payment_events (
+    provider VARCHAR(20) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    processed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider, event_id)
+) ENGINE=InnoDB;
+
+-- Payment ledger: every charge, refund, fee and adjustment per tournament participant
+CREATE TABLE IF NOT EXISTS payment_ledger_entries (
+    id VARCHAR(36) PRIMARY KEY,
+    tournament_id VARCHAR(36) NOT NULL,
+    participant_id VARCHAR(36) NOT NULL,
+    payment_id VARCHAR(36),
+    entry_type ENUM('charge', 'refund', 'fee', 'adjustment') NOT NULL,
+    amount_cents BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    provider_reference VARCHAR(255),
+    description VARCHAR(500) NOT NULL DEFAULT '',
+    created_by VARCHAR(36),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+    FOREIGN KEY (payment_id) REFERENCES payments(id),
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL,
+    UNIQUE KEY uk_provider_reference (entry_type, provider_reference),
+    INDEX idx_participant (tournament_id, participant_id, created_at)
+) ENGINE=InnoDB;
+
+-- Discount codes for tournament entry fees
+CREATE TABLE IF NOT EXISTS discount_codes (
+    id VARCHAR(36) PRIMARY KEY,
+    tournament_id VARCHAR(36) NOT NULL,
+    code VARCHAR(50) NOT NULL,
+    discount_type ENUM('percent', 'fixed') NOT NULL,
+    value BIGINT NOT NULL COMMENT 'percentage, or amount in minor units',
+    max_uses INT,
+    used_count INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NULL,
+    is_active BOOLEAN DEFAULT TRUE,
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by) REFERENCES users(id),
+    UNIQUE KEY uk_tournament_code (tournament_id, code)
+) ENGINE=InnoDB;
+
+-- Accounts the organizer lists as members, who pay member prices
+CREATE TABLE IF NOT EXISTS tournament_members (
+    tournament_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    added_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tournament_id, user_id),
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (added_by) REFERENCES users(id)
+) ENGINE=InnoDB;
+
+-- Entry fees collected at the venue by tournament staff
+CREATE TABLE IF NOT EXISTS onsite_payments (
+    id VARCHAR(36) PRIMARY KEY,
+    tournament_id VARCHAR(36) NOT NULL,
+    participant_id VARCHAR(36) NOT NULL,
+    amount_cents BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    method ENUM('cash', 'card') NOT NULL,
+    note VARCHAR(500),
+    collected_by VARCHAR(36) NOT NULL,
+    collected_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+    FOREIGN KEY (collected_by) REFERENCES users(id),
+    INDEX idx_tournament_collected (tournament_id, collected_at)
+) ENGINE=InnoDB;
+
+-- What organizers print on their invoices
+CREATE TABLE IF NOT EXISTS billing_profiles (
+    user_id VARCHAR(36) PRIMARY KEY,
+    legal_name VARCHAR(200) NOT NULL,
+    address VARCHAR(500),
+    tax_id VARCHAR(50),
+    email VARCHAR(255),
+    tax_label VARCHAR(20) NOT NULL DEFAULT '',
+    tax_rate_basis_points INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+-- Last invoice and credit note number issued by each organizer
+CREATE TABLE IF NOT EXISTS invoice_sequences (
+    organizer_id VARCHAR(36) NOT NULL,
+    kind ENUM('invoice', 'credit_note') NOT NULL,
+    last_number INT NOT NULL,
+    PRIMARY KEY (organizer_id, kind),
+    FOREIGN KEY (organizer_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+-- Invoices and credit notes. They are kept when the tournament or participant
+-- goes away, so neither is a foreign key.
+CREATE TABLE IF NOT EXISTS invoices (
+    id VARCHAR(36) PRIMARY KEY,
+    number VARCHAR(20) NOT NULL,
+    kind ENUM('invoice', 'credit_note') NOT NULL,
+    organizer_id VARCHAR(36) NOT NULL,
+    tournament_id VARCHAR(36) NOT NULL,
+    participant_id VARCHAR(36) NOT NULL,
+    credited_invoice_id VARCHAR(36),
+    source_reference VARCHAR(255) NOT NULL,
+    seller JSON NOT NULL,
+    buyer JSON NOT NULL,
+    line_items JSON NOT NULL,
+    subtotal_cents BIGINT NOT NULL,
+    tax_label VARCHAR(20) NOT NULL DEFAULT '',
+    tax_rate_basis_points INT NOT NULL DEFAULT 0,
+    tax_cents BIGINT NOT NULL DEFAULT 0,
+    total_cents BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    payment_method VARCHAR(50) NOT NULL,
+    issued_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (organizer_id) REFERENCES users(id),
+    FOREIGN KEY (credited_invoice_id) REFERENCES invoices(id),
+    UNIQUE KEY uk_organizer_number (organizer_id, kind, number),
+    UNIQUE KEY uk_kind_source (kind, source_reference),
+    INDEX idx_tournament_participant (tournament_id, participant_id)
+) ENGINE=InnoDB;
+
+-- Bulk refunds of a cancelled tournament's payments
+CREATE TABLE IF NOT EXISTS refund_batches (
+    id VARCHAR(36) PRIMARY KEY,
+    tournament_id VARCHAR(36) NOT NULL,
+    kind ENUM('cancellation', 'withdrawal', 'overpayment') NOT NULL DEFAULT 'cancellation',
+    reason VARCHAR(500) NOT NULL DEFAULT '',
+    created_by VARCHAR(36),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP NULL,
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL,
+    INDEX idx_tournament (tournament_id, kind, created_at)
+) ENGINE=InnoDB;
+
+-- One refund per payment in a batch, worked off by the refund worker
+CREATE TABLE IF NOT EXISTS refund_batch_items (
+    id VARCHAR(36) PRIMARY KEY,
+    batch_id VARCHAR(36) NOT NULL,
+    participant_id VARCHAR(36) NOT NULL,
+    payment_id VARCHAR(36) NOT NULL,
+    amount_cents BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    status ENUM('pending', 'requested', 'failed') DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    claim_token VARCHAR(36),
+    locked_until TIMESTAMP NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (batch_id) REFERENCES refund_batches(id) ON DELETE CASCADE,
+    FOREIGN KEY (payment_id) REFERENCES payments(id),
+    UNIQUE KEY uk_batch_payment (batch_id, payment_id),
+    INDEX idx_due (status, next_attempt_at)
+) ENGINE=InnoDB;
+
 -- Referees table
 CREATE TABLE IF NOT EXISTS referees (
     id VARCHAR(36) PRIMARY KEY,
@@ -74,6 +74,10 @@ db.createCollection("match_updates", {
                     bsonType: "string",
                     description: "user who made the update"
                 },
+                play_epoch: {
+                    bsonType: ["int", "long"],
+                    description: "play of the match a score update belongs to"
+                },
                 timestamp: {
                     bsonType: "date",
                     description: "must be a date and is required"
@@ -203,12 +207,18 @@ db.activity_logs.createIndex({ "entity_type": 1, "entity_id": 1 });
 db.match_updates.createIndex({ "match_id": 1, "timestamp": -1 });
 db.match_updates.createIndex({ "tournament_id": 1, "timestamp": -1 });
 db.match_updates.createIndex({ "update_type": 1 });
+db.match_updates.createIndex(
+    { "match_id": 1, "play_epoch": 1, "sequence": 1 },
+    { unique: true, partialFilterExpression: { "update_type": "score" } }
+);
 
 db.user_preferences.createIndex({ "user_id": 1 }, { unique: true });
 
 db.websocket_connections.createIndex({ "connection_id": 1 }, { unique: true });
 db.websocket_connections.createIndex({ "user_id": 1 });
 db.websocket_connections.createIndex({ "tournament_subscriptions": 1 });
+// Connections left behind by an instance that died are cleaned up after an hour without heartbeats
+db.websocket_connections.createIndex({ "last_ping": 1 }, { expireAfterSeconds: 3600 });
 
 db.tournament_analytics.createIndex({ "tournament_id": 1, "date": 1 }, { unique: true });
 